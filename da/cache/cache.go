@@ -0,0 +1,326 @@
+package cache
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/da"
+)
+
+const (
+  // blob data is stored under blobPrefix + hash
+  blobPrefix = "b"
+  // the access order is indexed under accessPrefix + seq + hash, with the blob size as value
+  accessPrefix = "a"
+  // the current seq of a blob is stored under seqPrefix + hash
+  seqPrefix = "s"
+)
+
+func blobKey(hash common.Hash) []byte {
+  return append([]byte(blobPrefix), hash.Bytes()...)
+}
+
+func seqKey(hash common.Hash) []byte {
+  return append([]byte(seqPrefix), hash.Bytes()...)
+}
+
+func accessKey(seq uint64, hash common.Hash) []byte {
+  key := binary.BigEndian.AppendUint64([]byte(accessPrefix), seq)
+  return append(key, hash.Bytes()...)
+}
+
+type Metrics interface {
+  RecordDACacheGet(hit bool)
+  RecordDACacheSize(entries int, size uint64)
+  RecordDACacheEviction()
+}
+
+type NoopMetrics struct{}
+
+func (NoopMetrics) RecordDACacheGet(bool)         {}
+func (NoopMetrics) RecordDACacheSize(int, uint64) {}
+func (NoopMetrics) RecordDACacheEviction()        {}
+
+// RefVerifier verifies a batch ref included in an L1 block of the given timestamp and returns the
+// hash of the batch data it certifies. ok is false for the refs that certify no hash, like the refs
+// carrying their data, which are not cached.
+type RefVerifier func(ref []byte, l1Time uint64) (hash common.Hash, ok bool, err error)
+
+// Cache is a da.Client keeping the batches retrieved from another DA client on disk, keyed by
+// the hash certified by their refs, so that they are not downloaded again on pipeline resets and
+// stay available while the DA is down. The least recently used blobs are evicted once the cache
+// exceeds its maximum size.
+type Cache struct {
+  da da.Client
+  verify RefVerifier
+  db ethdb.KeyValueStore
+  // maximum total size of the blobs, 0 for no limit
+  maxSize uint64
+  log log.Logger
+  metrics Metrics
+
+  mu sync.Mutex
+  nextSeq uint64
+  entries int
+  size uint64
+}
+
+// Open creates a cache stored in a LevelDB database at path
+func Open(logger log.Logger, path string, daClient da.Client, verify RefVerifier, maxSize uint64, m Metrics) (*Cache, error) {
+  db, err := leveldb.New(path, 16, 16, "da_cache", false)
+  if err != nil {
+    return nil, fmt.Errorf("could not open DA cache database: %w", err)
+  }
+  c, err := New(logger, db, daClient, verify, maxSize, m)
+  if err != nil {
+    db.Close()
+    return nil, err
+  }
+  return c, nil
+}
+
+// New creates a cache stored in db, which is closed with the cache
+func New(logger log.Logger, db ethdb.KeyValueStore, daClient da.Client, verify RefVerifier, maxSize uint64, m Metrics) (*Cache, error) {
+  c := &Cache{
+    da: daClient,
+    verify: verify,
+    db: db,
+    maxSize: maxSize,
+    log: logger,
+    metrics: m,
+  }
+
+  it := db.NewIterator([]byte(accessPrefix), nil)
+  defer it.Release()
+  for it.Next() {
+    c.entries++
+    c.size += binary.BigEndian.Uint64(it.Value())
+    c.nextSeq = binary.BigEndian.Uint64(it.Key()[len(accessPrefix):]) + 1
+  }
+  if err := it.Error(); err != nil {
+    return nil, fmt.Errorf("could not load DA cache index: %w", err)
+  }
+
+  c.log.Info("Loaded DA cache", "entries", c.entries, "size", c.size, "max_size", c.maxSize)
+  c.metrics.RecordDACacheSize(c.entries, c.size)
+  return c, nil
+}
+
+func (c *Cache) Close() error {
+  return c.db.Close()
+}
+
+func (c *Cache) PostBatch(ctx context.Context, data []byte, l1Time uint64) (da.BatchRef, error) {
+  return c.da.PostBatch(ctx, data, l1Time)
+}
+
+// GetBatch verifies the ref and serves its batch from the cache, retrieving it from the DA on a miss
+func (c *Cache) GetBatch(ctx context.Context, ref []byte, l1Time uint64) ([]byte, error) {
+  hash, ok, err := c.verify(ref, l1Time)
+  if err != nil {
+    return nil, err
+  }
+  if !ok {
+    return c.da.GetBatch(ctx, ref, l1Time)
+  }
+
+  c.mu.Lock()
+  data, hit := c.get(hash)
+  c.mu.Unlock()
+  c.metrics.RecordDACacheGet(hit)
+  if hit {
+    return data, nil
+  }
+
+  data, err = c.da.GetBatch(ctx, ref, l1Time)
+  if err != nil {
+    return nil, err
+  }
+
+  c.mu.Lock()
+  defer c.mu.Unlock()
+  if err := c.put(hash, data); err != nil {
+    c.log.Warn("Could not cache DA batch", "hash", hash, "err", err)
+  }
+  return data, nil
+}
+
+// get returns the blob of hash and marks it as the most recently used
+func (c *Cache) get(hash common.Hash) ([]byte, bool) {
+  data, err := c.db.Get(blobKey(hash))
+  if err != nil {
+    return nil, false
+  }
+  // the data is used as if certified by the ref, so it is checked against the disk going bad
+  if crypto.Keccak256Hash(data) != hash {
+    c.log.Warn("Dropping corrupted DA cache entry", "hash", hash)
+    if err := c.delete(hash); err != nil {
+      c.log.Warn("Could not drop corrupted DA cache entry", "hash", hash, "err", err)
+    }
+    return nil, false
+  }
+  if err := c.touch(hash, uint64(len(data))); err != nil {
+    c.log.Warn("Could not update DA cache access order", "hash", hash, "err", err)
+  }
+  return data, true
+}
+
+func (c *Cache) seq(hash common.Hash) (uint64, bool) {
+  value, err := c.db.Get(seqKey(hash))
+  if err != nil {
+    return 0, false
+  }
+  return binary.BigEndian.Uint64(value), true
+}
+
+func (c *Cache) touch(hash common.Hash, size uint64) error {
+  batch := c.db.NewBatch()
+  if seq, ok := c.seq(hash); ok {
+    if err := batch.Delete(accessKey(seq, hash)); err != nil {
+      return err
+    }
+  }
+  if err := c.index(batch, hash, size); err != nil {
+    return err
+  }
+  return batch.Write()
+}
+
+// index adds the blob as the most recently used to the access order
+func (c *Cache) index(batch ethdb.Batch, hash common.Hash, size uint64) error {
+  seq := c.nextSeq
+  c.nextSeq++
+  if err := batch.Put(accessKey(seq, hash), binary.BigEndian.AppendUint64(nil, size)); err != nil {
+    return err
+  }
+  return batch.Put(seqKey(hash), binary.BigEndian.AppendUint64(nil, seq))
+}
+
+// put stores the blob of hash and evicts the least recently used blobs above the maximum size
+func (c *Cache) put(hash common.Hash, data []byte) error {
+  size := uint64(len(data))
+  if c.maxSize != 0 && size > c.maxSize {
+    return fmt.Errorf("blob of %v bytes larger than the cache", size)
+  }
+  if _, ok := c.seq(hash); ok {
+    return c.touch(hash, size)
+  }
+
+  batch := c.db.NewBatch()
+  if err := batch.Put(blobKey(hash), data); err != nil {
+    return err
+  }
+  if err := c.index(batch, hash, size); err != nil {
+    return err
+  }
+  if err := batch.Write(); err != nil {
+    return err
+  }
+  c.entries++
+  c.size += size
+
+  for c.maxSize != 0 && c.size > c.maxSize {
+    if err := c.evictOldest(); err != nil {
+      return fmt.Errorf("could not evict: %w", err)
+    }
+  }
+  c.metrics.RecordDACacheSize(c.entries, c.size)
+  return nil
+}
+
+func (c *Cache) evictOldest() error {
+  it := c.db.NewIterator([]byte(accessPrefix), nil)
+  defer it.Release()
+  if !it.Next() {
+    if err := it.Error(); err != nil {
+      return err
+    }
+    return errors.New("no entry left")
+  }
+  hash := common.BytesToHash(it.Key()[len(accessPrefix) + 8:])
+  if err := c.delete(hash); err != nil {
+    return err
+  }
+  c.metrics.RecordDACacheEviction()
+  return nil
+}
+
+func (c *Cache) delete(hash common.Hash) error {
+  seq, ok := c.seq(hash)
+  if !ok {
+    return c.db.Delete(blobKey(hash))
+  }
+  accessed := accessKey(seq, hash)
+  value, err := c.db.Get(accessed)
+  if err != nil {
+    return err
+  }
+
+  batch := c.db.NewBatch()
+  for _, key := range [][]byte{blobKey(hash), seqKey(hash), accessed} {
+    if err := batch.Delete(key); err != nil {
+      return err
+    }
+  }
+  if err := batch.Write(); err != nil {
+    return err
+  }
+  c.entries--
+  c.size -= binary.BigEndian.Uint64(value)
+  c.metrics.RecordDACacheSize(c.entries, c.size)
+  return nil
+}
+
+// Export returns up to limit cached blobs from the cursor, from the least to the most recently
+// used, and the cursor of the blobs following them, 0 once there is none. The first cursor is 0.
+// Blobs accessed during an export move past the cursor: they may be exported twice, but never skipped.
+func (c *Cache) Export(cursor uint64, limit int) ([][]byte, uint64, error) {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  it := c.db.NewIterator([]byte(accessPrefix), binary.BigEndian.AppendUint64(nil, cursor))
+  defer it.Release()
+  var blobs [][]byte
+  for it.Next() {
+    seq := binary.BigEndian.Uint64(it.Key()[len(accessPrefix):])
+    if len(blobs) == limit {
+      return blobs, seq, nil
+    }
+    hash := common.BytesToHash(it.Key()[len(accessPrefix) + 8:])
+    data, err := c.db.Get(blobKey(hash))
+    if err != nil {
+      return nil, 0, fmt.Errorf("could not read blob %v: %w", hash, err)
+    }
+    blobs = append(blobs, data)
+  }
+  return blobs, 0, it.Error()
+}
+
+// Import adds blobs exported by another cache and returns the number of blobs that were not cached yet.
+// The blobs need no trust, as they are keyed by their hash and only served for refs certifying it.
+func (c *Cache) Import(blobs [][]byte) (int, error) {
+  c.mu.Lock()
+  defer c.mu.Unlock()
+
+  added := 0
+  for _, data := range blobs {
+    hash := crypto.Keccak256Hash(data)
+    if _, ok := c.seq(hash); ok {
+      continue
+    }
+    if err := c.put(hash, data); err != nil {
+      return added, fmt.Errorf("could not import blob %v: %w", hash, err)
+    }
+    added++
+  }
+  return added, nil
+}
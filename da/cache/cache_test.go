@@ -0,0 +1,234 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/da"
+)
+
+var errUnavailable = errors.New("unavailable")
+
+// testDA serves the batches of its refs, which are the hashes of the batches prefixed by 0x01.
+// Other refs carry their batch data.
+type testDA struct {
+  batches map[common.Hash][]byte
+  gets int
+  down bool
+}
+
+func newTestDA(batches ...[]byte) *testDA {
+  d := &testDA{batches: map[common.Hash][]byte{}}
+  for _, batch := range batches {
+    d.batches[crypto.Keccak256Hash(batch)] = batch
+  }
+  return d
+}
+
+func (d *testDA) PostBatch(ctx context.Context, data []byte, l1Time uint64) (da.BatchRef, error) {
+  return nil, errors.New("not implemented")
+}
+
+func (d *testDA) GetBatch(ctx context.Context, ref []byte, l1Time uint64) ([]byte, error) {
+  d.gets++
+  if ref[0] != 1 {
+    return ref[1:], nil
+  }
+  if d.down {
+    return nil, errUnavailable
+  }
+  return d.batches[common.BytesToHash(ref[1:])], nil
+}
+
+func verifyTestRef(ref []byte, l1Time uint64) (common.Hash, bool, error) {
+  if len(ref) == 0 {
+    return common.Hash{}, false, da.ErrInvalidBatchRef
+  }
+  if ref[0] != 1 {
+    return common.Hash{}, false, nil
+  }
+  return common.BytesToHash(ref[1:]), true, nil
+}
+
+func testRef(batch []byte) []byte {
+  return append([]byte{1}, crypto.Keccak256(batch)...)
+}
+
+type testMetrics struct {
+  hits, misses, evictions, entries int
+  size uint64
+}
+
+func (m *testMetrics) RecordDACacheGet(hit bool) {
+  if hit {
+    m.hits++
+  } else {
+    m.misses++
+  }
+}
+
+func (m *testMetrics) RecordDACacheSize(entries int, size uint64) {
+  m.entries = entries
+  m.size = size
+}
+
+func (m *testMetrics) RecordDACacheEviction() {
+  m.evictions++
+}
+
+func getBatch(t *testing.T, c *Cache, batch []byte) {
+  t.Helper()
+  data, err := c.GetBatch(context.Background(), testRef(batch), 0)
+  if err != nil {
+    t.Fatalf("got an error: %v", err)
+  }
+  if !bytes.Equal(data, batch) {
+    t.Fatalf("got %x, want %x", data, batch)
+  }
+}
+
+func TestCache(t *testing.T) {
+  batch := []byte("some batch data")
+  d := newTestDA(batch)
+  m := &testMetrics{}
+  c, err := New(log.New(), memorydb.New(), d, verifyTestRef, 0, m)
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  getBatch(t, c, batch)
+  d.down = true
+  getBatch(t, c, batch)
+  if d.gets != 1 || m.hits != 1 || m.misses != 1 {
+    t.Errorf("expected a single DA get, got %v gets, %v hits, %v misses", d.gets, m.hits, m.misses)
+  }
+  if m.entries != 1 || m.size != uint64(len(batch)) {
+    t.Errorf("expected 1 entry of %v bytes, got %v entries of %v bytes", len(batch), m.entries, m.size)
+  }
+
+  // refs carrying their data are not cached
+  inline := append([]byte{0xca}, batch...)
+  for i := 0; i < 2; i++ {
+    if data, err := c.GetBatch(context.Background(), inline, 0); err != nil || !bytes.Equal(data, batch) {
+      t.Fatalf("inline ref: got %x, %v", data, err)
+    }
+  }
+  if d.gets != 3 || m.entries != 1 {
+    t.Errorf("inline ref: expected uncached DA gets, got %v gets and %v entries", d.gets, m.entries)
+  }
+
+  if _, err := c.GetBatch(context.Background(), nil, 0); !errors.Is(err, da.ErrInvalidBatchRef) {
+    t.Errorf("invalid ref: expected an invalid batch ref error, got %v", err)
+  }
+
+  // a missing batch still needs the DA
+  if _, err := c.GetBatch(context.Background(), testRef([]byte("other batch")), 0); !errors.Is(err, errUnavailable) {
+    t.Errorf("DA down: expected the DA error, got %v", err)
+  }
+}
+
+func TestCacheEviction(t *testing.T) {
+  batches := [][]byte{[]byte("batch 0"), []byte("batch 1"), []byte("batch 2")}
+  d := newTestDA(batches...)
+  m := &testMetrics{}
+  db := memorydb.New()
+  // room for two batches
+  c, err := New(log.New(), db, d, verifyTestRef, 15, m)
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  getBatch(t, c, batches[0])
+  getBatch(t, c, batches[1])
+  // batch 0 becomes the most recently used
+  getBatch(t, c, batches[0])
+  getBatch(t, c, batches[2])
+  if m.evictions != 1 || m.entries != 2 || m.size != 14 {
+    t.Fatalf("expected 1 eviction and 2 entries of 14 bytes, got %v evictions and %v entries of %v bytes", m.evictions, m.entries, m.size)
+  }
+
+  d.down = true
+  getBatch(t, c, batches[0])
+  getBatch(t, c, batches[2])
+  if _, err := c.GetBatch(context.Background(), testRef(batches[1]), 0); !errors.Is(err, errUnavailable) {
+    t.Errorf("expected batch 1 to be evicted, got %v", err)
+  }
+
+  // the index is restored from the database
+  reopened, err := New(log.New(), db, d, verifyTestRef, 15, m)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if reopened.entries != 2 || reopened.size != 14 || reopened.nextSeq != c.nextSeq {
+    t.Errorf("reopened: got %v entries of %v bytes and next seq %v, want 2 entries of 14 bytes and %v", reopened.entries, reopened.size, reopened.nextSeq, c.nextSeq)
+  }
+}
+
+func TestCacheCorruption(t *testing.T) {
+  batch := []byte("some batch data")
+  d := newTestDA(batch)
+  db := memorydb.New()
+  c, err := New(log.New(), db, d, verifyTestRef, 0, NoopMetrics{})
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  getBatch(t, c, batch)
+  if err := db.Put(blobKey(crypto.Keccak256Hash(batch)), []byte("corrupted")); err != nil {
+    t.Fatal(err)
+  }
+  getBatch(t, c, batch)
+  if d.gets != 2 {
+    t.Errorf("expected the corrupted batch to be fetched again, got %v gets", d.gets)
+  }
+}
+
+func TestExportImport(t *testing.T) {
+  batches := [][]byte{[]byte("batch 0"), []byte("batch 1")}
+  d := newTestDA(batches...)
+  source, err := New(log.New(), memorydb.New(), d, verifyTestRef, 0, NoopMetrics{})
+  if err != nil {
+    t.Fatal(err)
+  }
+  for _, batch := range batches {
+    getBatch(t, source, batch)
+  }
+
+  // the blobs are exported a page at a time
+  blobs, cursor, err := source.Export(0, 1)
+  if err != nil || len(blobs) != 1 || cursor == 0 {
+    t.Fatalf("first page: got %v blobs, cursor %v, %v", len(blobs), cursor, err)
+  }
+  // a blob accessed during the export moves past the cursor
+  getBatch(t, source, batches[0])
+  page, cursor, err := source.Export(cursor, 2)
+  if err != nil || len(page) != 2 || cursor != 0 {
+    t.Fatalf("last page: got %v blobs, cursor %v, %v", len(page), cursor, err)
+  }
+  if !bytes.Equal(page[0], batches[1]) || !bytes.Equal(page[1], batches[0]) {
+    t.Fatalf("last page: expected the blobs by access order, got %q", page)
+  }
+  blobs = append(blobs, page[0])
+
+  down := &testDA{down: true}
+  seeded, err := New(log.New(), memorydb.New(), down, verifyTestRef, 0, NoopMetrics{})
+  if err != nil {
+    t.Fatal(err)
+  }
+  if added, err := seeded.Import(blobs); err != nil || added != 2 {
+    t.Fatalf("import: got %v added, %v", added, err)
+  }
+  if added, err := seeded.Import(blobs); err != nil || added != 0 {
+    t.Fatalf("import again: got %v added, %v", added, err)
+  }
+  for _, batch := range batches {
+    getBatch(t, seeded, batch)
+  }
+}
@@ -0,0 +1,163 @@
+package celestia
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/da"
+)
+
+const (
+  // CelestiaBatchHeaderID prefixes the refs of the batches posted to a blob namespace, it is
+  // distinct from the headers of the refs of the other DA clients
+  CelestiaBatchHeaderID uint8 = 0x0c
+
+  // NamespaceSize is the size of a versioned namespace: a version byte and a 28 bytes id
+  NamespaceSize = 29
+  CommitmentSize = 32
+
+  heightLength = 8
+  batchRefLength = 1 + heightLength + CommitmentSize
+
+  // DefaultGasPrice lets the DA node estimate the gas price of the blob submissions
+  DefaultGasPrice = -1.0
+)
+
+var (
+  ErrInvalidNamespace = errors.New("invalid blob namespace")
+  // the commitment of a blob is only known once the blob is included, if the submitted data
+  // cannot be found at the returned height the node is faulty
+  ErrBlobNotIncluded = fmt.Errorf("%w: submitted blob not found at its inclusion height", da.ErrUnavailable)
+  ErrBlobMismatch = fmt.Errorf("%w: blob does not match the ref", da.ErrUnavailable)
+)
+
+// Blob is a blob of the JSON-RPC API of the DA node, its byte fields are base64 encoded
+type Blob struct {
+  Namespace []byte `json:"namespace"`
+  Data []byte `json:"data"`
+  ShareVersion uint32 `json:"share_version"`
+  Commitment []byte `json:"commitment"`
+  Index int `json:"index"`
+}
+
+// client posts batches as blobs of a namespace of a Celestia-style DA node. Unlike the DAC
+// certificates, the refs cannot be verified by the derivation pipeline: the DA node is trusted to
+// serve the blobs committed to at the height of the ref, which it checks by data availability
+// sampling, and the batcher is trusted to only post refs of included blobs.
+type client struct {
+  rpc *rpc.Client
+  namespace []byte
+  addr common.Address
+}
+
+type batchRef struct {
+  addr common.Address
+  height uint64
+  commitment []byte
+}
+
+// ToTx encodes the ref as:
+// <          1            ><   8    ><     32     >
+// < CelestiaBatchHeaderID >< height >< commitment >
+func (r *batchRef) ToTx() (da.Tx, error) {
+  data := make([]byte, 0, batchRefLength)
+  data = append(data, CelestiaBatchHeaderID)
+  data = binary.BigEndian.AppendUint64(data, r.height)
+  data = append(data, r.commitment...)
+  return da.Tx{To: &r.addr, Data: data}, nil
+}
+
+// parseBatchRef decodes a ref produced by batchRef.ToTx
+func parseBatchRef(dataRef []byte) (*batchRef, error) {
+  if len(dataRef) != batchRefLength || dataRef[0] != CelestiaBatchHeaderID {
+    return nil, fmt.Errorf("%w: invalid blob batch ref", da.ErrInvalidBatchRef)
+  }
+  return &batchRef{
+    height: binary.BigEndian.Uint64(dataRef[1:]),
+    commitment: dataRef[1+heightLength:],
+  }, nil
+}
+
+// CheckNamespace checks that the namespace is a versioned namespace
+func CheckNamespace(namespace []byte) error {
+  if len(namespace) != NamespaceSize {
+    return fmt.Errorf("%w: got %v bytes, expected %v", ErrInvalidNamespace, len(namespace), NamespaceSize)
+  }
+  return nil
+}
+
+// NewClient creates a client of the JSON-RPC API of a DA node at rpcUrl, authenticated by the
+// auth token if not empty
+func NewClient(rpcUrl string, authToken string, namespace []byte, addr common.Address, httpClient *http.Client) (da.Client, error) {
+  if err := CheckNamespace(namespace); err != nil {
+    return nil, err
+  }
+  rpcClient, err := rpc.DialHTTPWithClient(rpcUrl, httpClient)
+  if err != nil {
+    return nil, fmt.Errorf("%w: invalid DA node url: %v", da.ErrMisconfigured, err)
+  }
+  if authToken != "" {
+    rpcClient.SetHeader("Authorization", "Bearer " + authToken)
+  }
+  return &client{rpcClient, namespace, addr}, nil
+}
+
+// rpcError classifies the errors of the DA node API
+func rpcError(op string, err error) error {
+  var httpErr rpc.HTTPError
+  if errors.As(err, &httpErr) && (httpErr.StatusCode == http.StatusUnauthorized || httpErr.StatusCode == http.StatusForbidden) {
+    return fmt.Errorf("%w: %v: %v", da.ErrMisconfigured, op, err)
+  }
+  var rpcErr rpc.Error
+  if errors.As(err, &rpcErr) && strings.Contains(rpcErr.Error(), "not found") {
+    return fmt.Errorf("%w: %v: %v", da.ErrBatchNotFound, op, err)
+  }
+  return fmt.Errorf("%w: %v: %v", da.ErrUnavailable, op, err)
+}
+
+// PostBatch submits the batch as a blob, and looks up its commitment at its inclusion height
+func (c *client) PostBatch(ctx context.Context, data []byte, l1Time uint64) (da.BatchRef, error) {
+  blob := Blob{Namespace: c.namespace, Data: data}
+  var height uint64
+  if err := c.rpc.CallContext(ctx, &height, "blob.Submit", []Blob{blob}, DefaultGasPrice); err != nil {
+    return nil, rpcError("submit blob", err)
+  }
+
+  var blobs []Blob
+  if err := c.rpc.CallContext(ctx, &blobs, "blob.GetAll", height, [][]byte{c.namespace}); err != nil {
+    return nil, rpcError("get included blobs", err)
+  }
+  for _, included := range blobs {
+    if bytes.Equal(included.Data, data) && len(included.Commitment) == CommitmentSize {
+      return &batchRef{c.addr, height, included.Commitment}, nil
+    }
+  }
+  return nil, fmt.Errorf("%w: height %v", ErrBlobNotIncluded, height)
+}
+
+func (c *client) GetBatch(ctx context.Context, dataRef []byte, l1Time uint64) ([]byte, error) {
+  ref, err := parseBatchRef(dataRef)
+  if err != nil {
+    return nil, err
+  }
+
+  var blob *Blob
+  if err := c.rpc.CallContext(ctx, &blob, "blob.Get", ref.height, c.namespace, ref.commitment); err != nil {
+    return nil, rpcError("get blob", err)
+  }
+  if blob == nil {
+    return nil, fmt.Errorf("%w: no blob at height %v", da.ErrBatchNotFound, ref.height)
+  }
+  if !bytes.Equal(blob.Namespace, c.namespace) || !bytes.Equal(blob.Commitment, ref.commitment) {
+    return nil, fmt.Errorf("%w: height %v", ErrBlobMismatch, ref.height)
+  }
+  return blob.Data, nil
+}
@@ -0,0 +1,76 @@
+package celestia
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/da"
+)
+
+var testNamespace = append(make([]byte, NamespaceSize - 4), []byte("test")...)
+
+func TestClient(t *testing.T) {
+  ctx := context.Background()
+  srv := NewMockServer("token")
+  defer srv.Close()
+  inbox := common.HexToAddress("0xff00000000000000000000000000000000000420")
+
+  c, err := NewClient(srv.URL, "token", testNamespace, inbox, http.DefaultClient)
+  if err != nil {
+    t.Fatal(err)
+  }
+  data := []byte("some batch data")
+  ref, err := c.PostBatch(ctx, data, 0)
+  if err != nil {
+    t.Fatal(err)
+  }
+  tx, _ := ref.ToTx()
+  if *tx.To != inbox || len(tx.Data) != batchRefLength || tx.Data[0] != CelestiaBatchHeaderID {
+    t.Fatalf("unexpected ref tx: %v %x", tx.To, tx.Data)
+  }
+  got, err := c.GetBatch(ctx, tx.Data, 0)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if !bytes.Equal(got, data) {
+    t.Errorf("expected %x, got %x", data, got)
+  }
+
+  if _, err := c.GetBatch(ctx, tx.Data[1:], 0); !errors.Is(err, da.ErrInvalidBatchRef) {
+    t.Errorf("truncated ref: expected an invalid batch ref, got %v", err)
+  }
+  missing := append([]byte{}, tx.Data...)
+  missing[len(missing) - 1] ^= 1
+  if _, err := c.GetBatch(ctx, missing, 0); !errors.Is(err, da.ErrBatchNotFound) {
+    t.Errorf("unknown commitment: expected a batch not found, got %v", err)
+  }
+
+  otherNamespace, _ := NewClient(srv.URL, "token", append(make([]byte, NamespaceSize - 5), []byte("other")...), inbox, http.DefaultClient)
+  if _, err := otherNamespace.GetBatch(ctx, tx.Data, 0); !errors.Is(err, da.ErrBatchNotFound) {
+    t.Errorf("other namespace: expected a batch not found, got %v", err)
+  }
+
+  unauthenticated, _ := NewClient(srv.URL, "", testNamespace, inbox, http.DefaultClient)
+  if _, err := unauthenticated.GetBatch(ctx, tx.Data, 0); !errors.Is(err, da.ErrMisconfigured) {
+    t.Errorf("unauthenticated: expected a misconfigured client, got %v", err)
+  }
+
+  srv.SetUnavailable(true)
+  if _, err := c.GetBatch(ctx, tx.Data, 0); !errors.Is(err, da.ErrUnavailable) {
+    t.Errorf("unavailable get: expected an unavailable DA, got %v", err)
+  }
+  if _, err := c.PostBatch(ctx, data, 0); !errors.Is(err, da.ErrUnavailable) {
+    t.Errorf("unavailable post: expected an unavailable DA, got %v", err)
+  }
+}
+
+func TestNewClientNamespace(t *testing.T) {
+  if _, err := NewClient("http://localhost", "", testNamespace[1:], common.Address{}, http.DefaultClient); !errors.Is(err, ErrInvalidNamespace) {
+    t.Errorf("expected an invalid namespace, got %v", err)
+  }
+}
@@ -0,0 +1,143 @@
+package celestia
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+// MockServer is an in-memory DA node serving the blob JSON-RPC API, for tests. Every submission
+// is included in a new block, and the commitments are plain hashes of the namespace and data
+// rather than share commitments.
+type MockServer struct {
+  *httptest.Server
+  authToken string
+
+  mu sync.Mutex
+  height uint64
+  blobs map[uint64][]Blob
+  unavailable bool
+}
+
+// NewMockServer starts a DA node requiring the auth token if not empty
+func NewMockServer(authToken string) *MockServer {
+  m := &MockServer{authToken: authToken, blobs: make(map[uint64][]Blob)}
+  m.Server = httptest.NewServer(http.HandlerFunc(m.handle))
+  return m
+}
+
+// SetUnavailable makes the node fail every request while set
+func (m *MockServer) SetUnavailable(unavailable bool) {
+  m.mu.Lock()
+  defer m.mu.Unlock()
+  m.unavailable = unavailable
+}
+
+// Height is the height of the last submission
+func (m *MockServer) Height() uint64 {
+  m.mu.Lock()
+  defer m.mu.Unlock()
+  return m.height
+}
+
+type mockRequest struct {
+  ID json.RawMessage `json:"id"`
+  Method string `json:"method"`
+  Params []json.RawMessage `json:"params"`
+}
+
+type mockError struct {
+  Code int `json:"code"`
+  Message string `json:"message"`
+}
+
+type mockResponse struct {
+  Version string `json:"jsonrpc"`
+  ID json.RawMessage `json:"id"`
+  Result interface{} `json:"result,omitempty"`
+  Error *mockError `json:"error,omitempty"`
+}
+
+func (m *MockServer) handle(w http.ResponseWriter, r *http.Request) {
+  if m.authToken != "" && r.Header.Get("Authorization") != "Bearer " + m.authToken {
+    w.WriteHeader(http.StatusUnauthorized)
+    return
+  }
+  m.mu.Lock()
+  defer m.mu.Unlock()
+  if m.unavailable {
+    w.WriteHeader(http.StatusServiceUnavailable)
+    return
+  }
+
+  var req mockRequest
+  if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+    w.WriteHeader(http.StatusBadRequest)
+    return
+  }
+  result, err := m.call(req.Method, req.Params)
+  resp := mockResponse{Version: "2.0", ID: req.ID, Result: result, Error: err}
+  w.Header().Set("Content-Type", "application/json")
+  json.NewEncoder(w).Encode(resp)
+}
+
+func (m *MockServer) call(method string, params []json.RawMessage) (interface{}, *mockError) {
+  invalidParams := &mockError{-32602, "invalid params"}
+  switch method {
+  case "blob.Submit":
+    var blobs []Blob
+    if len(params) < 1 || json.Unmarshal(params[0], &blobs) != nil {
+      return nil, invalidParams
+    }
+    m.height++
+    for i, blob := range blobs {
+      if CheckNamespace(blob.Namespace) != nil {
+        return nil, invalidParams
+      }
+      commitment := sha256.Sum256(append(append([]byte{}, blob.Namespace...), blob.Data...))
+      blob.Commitment = commitment[:]
+      blob.Index = i
+      m.blobs[m.height] = append(m.blobs[m.height], blob)
+    }
+    return m.height, nil
+
+  case "blob.Get":
+    var height uint64
+    var namespace, commitment []byte
+    if len(params) < 3 || json.Unmarshal(params[0], &height) != nil ||
+      json.Unmarshal(params[1], &namespace) != nil || json.Unmarshal(params[2], &commitment) != nil {
+      return nil, invalidParams
+    }
+    for _, blob := range m.blobs[height] {
+      if bytes.Equal(blob.Namespace, namespace) && bytes.Equal(blob.Commitment, commitment) {
+        return blob, nil
+      }
+    }
+    return nil, &mockError{1, "blob: not found"}
+
+  case "blob.GetAll":
+    var height uint64
+    var namespaces [][]byte
+    if len(params) < 2 || json.Unmarshal(params[0], &height) != nil || json.Unmarshal(params[1], &namespaces) != nil {
+      return nil, invalidParams
+    }
+    blobs := []Blob{}
+    for _, blob := range m.blobs[height] {
+      for _, namespace := range namespaces {
+        if bytes.Equal(blob.Namespace, namespace) {
+          blobs = append(blobs, blob)
+        }
+      }
+    }
+    if len(blobs) == 0 {
+      return nil, &mockError{1, "blob: not found"}
+    }
+    return blobs, nil
+
+  default:
+    return nil, &mockError{-32601, "method not found"}
+  }
+}
@@ -0,0 +1,206 @@
+package dac
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/da"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+  ErrThresholdNotReached = fmt.Errorf("%w: not enough DAC members signed the batch", da.ErrUnavailable)
+)
+
+// Metricer records the interactions of an aggregator with the DAC members
+type Metricer interface {
+  RecordDACMemberSignature(member int, duration time.Duration)
+  RecordDACMemberFailure(member int)
+  RecordDACBatchCertified(signers int, duration time.Duration)
+}
+
+type NoopMetrics struct{}
+
+func (NoopMetrics) RecordDACMemberSignature(int, time.Duration) {}
+func (NoopMetrics) RecordDACMemberFailure(int)                  {}
+func (NoopMetrics) RecordDACBatchCertified(int, time.Duration)  {}
+
+// aggregator is a da.Client posting batches to every DAC member and aggregating
+// their signatures itself, so that no aggregation service has to be trusted
+type aggregator struct {
+  // batches are read from the members that signed them
+  *reader
+
+  addr common.Address
+  // timeout of a single member request
+  timeout time.Duration
+  metrics Metricer
+  // authenticates posted batches, nil to post without authentication
+  auth BatchAuth
+}
+
+// NewAggregator creates a da.Client talking to the DAC members directly.
+// memberUrls maps the hex public key of every member of the scheduled committees to its endpoint.
+func NewAggregator(
+  logger log.Logger, memberUrls map[string]string, addr common.Address, committees CommitteeSchedule, schemes SchemeConfig, timeout time.Duration, m Metricer, auth BatchAuth, httpClient *http.Client,
+) (da.Client, error) {
+  r, err := newReader(logger, memberUrls, committees, schemes, httpClient)
+  if err != nil {
+    return nil, err
+  }
+  return &aggregator{
+    reader: r,
+    addr: addr,
+    timeout: timeout,
+    metrics: m,
+    auth: auth,
+  }, nil
+}
+
+type memberSignature struct {
+  member int
+  signature Signature
+  err error
+}
+
+// encodePayloads encodes the post body of every member of the committee, and returns the
+// certified hash along with the message each member signs: the data hash, or for erasure coded
+// batches the chunk commitment root along with the index of the chunk of the member
+func (a *aggregator) encodePayloads(committee Committee, data []byte, version SchemeVersion) ([]byte, string, [][]byte, [][]byte, error) {
+  payloads := make([][]byte, len(committee.Keyset))
+  messages := make([][]byte, len(committee.Keyset))
+  if !committee.ErasureCoded() {
+    dataHash := crypto.Keccak256(data)
+    encoded, err := encodeBatchPayload(data, dataHash, version, a.auth)
+    if err != nil {
+      return nil, "", nil, nil, err
+    }
+    for i := range payloads {
+      payloads[i] = encoded
+      messages[i] = dataHash
+    }
+    return dataHash, batchPath, payloads, messages, nil
+  }
+
+  // member i stores the chunk at index i
+  root, chunks, err := EncodeChunks(data, uint64(committee.DataShards), uint64(len(committee.Keyset)))
+  if err != nil {
+    return nil, "", nil, nil, fmt.Errorf("%w: %v", da.ErrMisconfigured, err)
+  }
+  for i, chunk := range chunks {
+    if payloads[i], err = encodeChunkPayload(chunk, root, version, a.auth); err != nil {
+      return nil, "", nil, nil, err
+    }
+    messages[i] = ChunkMessage(root, uint64(i))
+  }
+  return root.Bytes(), chunkPath, payloads, messages, nil
+}
+
+// PostBatch posts the batch, or one chunk of the erasure coded batch, to every member of the
+// committee active at l1Time concurrently, and returns as soon as threshold members returned a
+// valid signature of the locally computed hash, or of the chunk commitment root and their index.
+func (a *aggregator) PostBatch(ctx context.Context, data []byte, l1Time uint64) (da.BatchRef, error) {
+  start := time.Now()
+  committee := a.committees.At(l1Time)
+  // the caller certifies for the L1 time the ref is included from, up to the next activation
+  version := a.schemes.versionAt(l1Time)
+  domain := a.schemes.batchDomain(version)
+  if committee.ErasureCoded() {
+    domain = a.schemes.chunkDomain(version)
+  }
+
+  certified, path, payloads, messages, err := a.encodePayloads(committee, data, version)
+  if err != nil {
+    return nil, err
+  }
+
+  ctx, cancel := context.WithCancel(ctx)
+  defer cancel()
+
+  // buffered so that late members never block once we stopped listening
+  results := make(chan memberSignature, len(committee.Keyset))
+  for i := range committee.Keyset {
+    go func(member int) {
+      memberStart := time.Now()
+      signature, err := a.postToMember(ctx, committee.Keyset[member], member, path, payloads[member], domain, messages[member])
+      if err == nil {
+        a.metrics.RecordDACMemberSignature(member, time.Since(memberStart))
+      }
+      results <- memberSignature{member, signature, err}
+    }(i)
+  }
+
+  signatures := make([]Signature, 0, committee.Threshold)
+  mask := uint64(0)
+  for range committee.Keyset {
+    result := <-results
+    if result.err != nil {
+      // a cancelled request is not a member failure
+      if ctx.Err() == nil {
+        a.log.Warn("DAC member failed to sign batch", "member", result.member, "err", result.err)
+        a.metrics.RecordDACMemberFailure(result.member)
+      }
+      continue
+    }
+
+    signatures = append(signatures, result.signature)
+    mask |= 1 << result.member
+    if uint(len(signatures)) >= committee.Threshold {
+      a.metrics.RecordDACBatchCertified(len(signatures), time.Since(start))
+      return &batchRef{
+        addr: a.addr,
+        version: version,
+        dataHash: certified,
+        signature: AggregateSignatures(signatures).ToBytes(),
+        mask: mask,
+      }, nil
+    }
+  }
+
+  return nil, fmt.Errorf("%w: got %v signatures, need %v", ErrThresholdNotReached, len(signatures), committee.Threshold)
+}
+
+// postToMember posts the encoded batch or chunk to a member and verifies the returned signature
+// of the message against the member key. A member storing a chunk must report the index of the
+// chunk as its position in the keyset.
+func (a *aggregator) postToMember(ctx context.Context, key PublicKey, member int, path string, encoded []byte, domain Domain, message []byte) (Signature, error) {
+  ctx, cancel := context.WithTimeout(ctx, a.timeout)
+  defer cancel()
+
+  resp, err := postBatch(ctx, a.httpClient, a.members.of(key), path, encoded)
+  if err != nil {
+    return Signature{}, err
+  }
+  defer resp.Body.Close()
+
+  type response struct {
+    Signature string `json:"signature"`
+    Index *uint64 `json:"index,omitempty"`
+  }
+  r := response{}
+  if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+    return Signature{}, fmt.Errorf("%w: invalid post batch response data: %v", da.ErrUnavailable, err)
+  }
+  if path == chunkPath && (r.Index == nil || *r.Index != uint64(member)) {
+    return Signature{}, fmt.Errorf("%w: member %v did not store its chunk", ErrInvalidChunk, member)
+  }
+  rawSignature, err := hex.DecodeString(r.Signature)
+  if err != nil {
+    return Signature{}, fmt.Errorf("%w: signature is not valid hex: %v", da.ErrUnavailable, err)
+  }
+
+  isValid, err := key.VerifyMessage(domain, message, rawSignature)
+  if err != nil {
+    return Signature{}, fmt.Errorf("could not verify signature: %w", err)
+  }
+  if !isValid {
+    return Signature{}, ErrInvalidBatchSignature
+  }
+  return NewSignature(rawSignature)
+}
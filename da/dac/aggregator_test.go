@@ -0,0 +1,230 @@
+package dac
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/da"
+	"github.com/ethereum-optimism/optimism/op-service/backoff"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// testMember is a minimal in-memory dac-member
+type testMember struct {
+  signer Signer
+  down bool
+  delay time.Duration
+  // chunkIndex overrides the index of the chunks the member claims to store
+  chunkIndex *uint64
+
+  mu sync.Mutex
+  batches map[string][]byte
+  chunks map[string]Chunk
+  gets int
+}
+
+func (m *testMember) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+  if r.Method == http.MethodGet {
+    m.mu.Lock()
+    m.gets++
+    m.mu.Unlock()
+  }
+  if m.down {
+    w.WriteHeader(http.StatusServiceUnavailable)
+    return
+  }
+  time.Sleep(m.delay)
+
+  m.mu.Lock()
+  defer m.mu.Unlock()
+
+  if strings.HasPrefix(r.URL.Path, "/chunk") {
+    m.serveChunk(w, r)
+    return
+  }
+  if r.Method == http.MethodGet {
+    data, ok := m.batches[r.URL.Path[len("/batch/"):]]
+    if !ok {
+      w.WriteHeader(http.StatusNotFound)
+      return
+    }
+    json.NewEncoder(w).Encode(map[string]string{"data": hex.EncodeToString(data)})
+    return
+  }
+
+  p := BatchPayload{}
+  json.NewDecoder(r.Body).Decode(&p)
+  data, _ := hex.DecodeString(p.Data)
+  dataHash := crypto.Keccak256(data)
+  m.batches[hex.EncodeToString(dataHash)] = data
+
+  sig, _ := m.signer.Sign(BatchDomain(p.Version, testChainID), dataHash)
+  json.NewEncoder(w).Encode(map[string]string{"signature": hex.EncodeToString(sig.ToBytes())})
+}
+
+func (m *testMember) serveChunk(w http.ResponseWriter, r *http.Request) {
+  if r.Method == http.MethodGet {
+    chunk, ok := m.chunks[r.URL.Path[len("/chunk/"):]]
+    if !ok {
+      w.WriteHeader(http.StatusNotFound)
+      return
+    }
+    json.NewEncoder(w).Encode(chunk)
+    return
+  }
+
+  p := ChunkPayload{}
+  json.NewDecoder(r.Body).Decode(&p)
+  m.chunks[hex.EncodeToString(p.Root.Bytes())] = p.Chunk
+
+  index := p.Index
+  if m.chunkIndex != nil {
+    index = *m.chunkIndex
+  }
+  sig, _ := m.signer.Sign(ChunkDomain(p.Version, testChainID), ChunkMessage(p.Root, index))
+  json.NewEncoder(w).Encode(map[string]interface{}{"signature": hex.EncodeToString(sig.ToBytes()), "index": index})
+}
+
+// startTestMembers serves a member for each signer, it returns their urls by hex public key
+func startTestMembers(t *testing.T, signers []Signer) ([]*testMember, map[string]string) {
+  members := make([]*testMember, len(signers))
+  urls := make(map[string]string, len(signers))
+  for i, signer := range signers {
+    members[i] = &testMember{signer: signer, batches: map[string][]byte{}, chunks: map[string]Chunk{}}
+    srv := httptest.NewServer(members[i])
+    t.Cleanup(srv.Close)
+    urls[hex.EncodeToString(signer.GetPublicKey().ToBytes())] = srv.URL
+  }
+  return members, urls
+}
+
+func TestAggregator(t *testing.T) {
+  signers, keyset := newTestCommittee(t, 3)
+  members, urls := startTestMembers(t, signers)
+  data := []byte("some batch data")
+
+  v1Time := uint64(0)
+  schemes := SchemeConfig{testChainID, &v1Time}
+  a, err := NewAggregator(log.New(), urls, common.Address{}, SingleCommittee(keyset, 2), schemes, time.Second, NoopMetrics{}, nil, http.DefaultClient)
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  // a member signing with the wrong key is ignored
+  members[0].signer = signers[1]
+  ref, err := a.PostBatch(context.Background(), data, v1Time)
+  if err != nil {
+    t.Fatalf("post batch: got an error: %v", err)
+  }
+  if mask := ref.(*batchRef).mask; mask != 0b110 {
+    t.Errorf("post batch: expected mask 0b110, got %b", mask)
+  }
+  if version := ref.(*batchRef).version; version != SchemeV1 {
+    t.Errorf("post batch: expected a v1 certificate, got version %v", version)
+  }
+
+  tx, _ := ref.ToTx()
+  got, err := a.GetBatch(context.Background(), tx.Data, v1Time)
+  if err != nil {
+    t.Fatalf("get batch: got an error: %v", err)
+  }
+  if !bytes.Equal(got, data) {
+    t.Fatalf("get batch: got %x, want %x", got, data)
+  }
+
+  // a slow member times out
+  members[2].delay = 2 * time.Second
+  if _, err := a.PostBatch(context.Background(), data, v1Time); !errors.Is(err, ErrThresholdNotReached) {
+    t.Errorf("expected threshold not to be reached, got %v", err)
+  }
+
+  delete(urls, hex.EncodeToString(signers[2].GetPublicKey().ToBytes()))
+  if _, err := NewAggregator(log.New(), urls, common.Address{}, SingleCommittee(keyset, 2), schemes, time.Second, NoopMetrics{}, nil, http.DefaultClient); !errors.Is(err, ErrMissingMemberUrl) {
+    t.Errorf("expected a missing member url, got %v", err)
+  }
+}
+
+func TestAggregatorErasureCoded(t *testing.T) {
+  signers, keyset := newTestCommittee(t, 4)
+  members, urls := startTestMembers(t, signers)
+  data := bytes.Repeat([]byte("some batch data"), 100)
+  committees := CommitteeSchedule{{Committee: Committee{Keyset: keyset, Threshold: 3, DataShards: 2}}}
+
+  c, err := NewAggregator(log.New(), urls, common.Address{}, committees, SchemeConfig{}, time.Second, NoopMetrics{}, nil, http.DefaultClient)
+  if err != nil {
+    t.Fatal(err)
+  }
+  a := c.(*aggregator)
+  a.strategy = backoff.Fixed(0)
+
+  members[3].down = true
+  ref, err := a.PostBatch(context.Background(), data, 0)
+  if err != nil {
+    t.Fatalf("post batch: got an error: %v", err)
+  }
+  if mask := ref.(*batchRef).mask; mask != 0b0111 {
+    t.Errorf("post batch: expected mask 0b0111, got %b", mask)
+  }
+  for i, member := range members[:3] {
+    if len(member.batches) != 0 || len(member.chunks) != 1 {
+      t.Fatalf("member %v: expected a single chunk, got %v batches and %v chunks", i, len(member.batches), len(member.chunks))
+    }
+  }
+
+  // any two signers reconstruct the batch
+  tx, _ := ref.ToTx()
+  members[0].down = true
+  got, err := a.GetBatch(context.Background(), tx.Data, 0)
+  if err != nil {
+    t.Fatalf("get batch: got an error: %v", err)
+  }
+  if !bytes.Equal(got, data) {
+    t.Fatalf("get batch: got %x, want %x", got, data)
+  }
+
+  members[1].down = true
+  if _, err := a.GetBatch(context.Background(), tx.Data, 0); !errors.Is(err, da.ErrUnavailable) {
+    t.Errorf("single signer: expected an unavailable error, got %v", err)
+  }
+
+  // a certificate of the chunk commitment root is not a certificate of a data hash
+  cert, _ := DecodeCertificate(tx.Data)
+  if isValid, _ := keyset.VerifyMessage(BatchDomain(SchemeLegacy, nil), cert.DataHash.Bytes(), cert.Signature, cert.Mask); isValid {
+    t.Errorf("a chunk commitment root certificate must not verify as a data hash certificate")
+  }
+
+  // the chunk of another member does not count
+  members[1].down = false
+  root := hex.EncodeToString(ref.(*batchRef).dataHash)
+  members[1].chunks[root] = members[2].chunks[root]
+  if _, err := a.GetBatch(context.Background(), tx.Data, 0); !errors.Is(err, ErrInvalidChunk) {
+    t.Errorf("duplicate chunk: expected an invalid chunk, got %v", err)
+  }
+
+  // a member signing for the chunk of another one is not counted, the certificate would not
+  // prove that distinct chunks are stored
+  for _, member := range members {
+    member.down = false
+  }
+  zero := uint64(0)
+  members[1].chunkIndex = &zero
+  members[2].chunkIndex = &zero
+  if _, err := a.PostBatch(context.Background(), data, 0); !errors.Is(err, ErrThresholdNotReached) {
+    t.Errorf("other chunk index: expected threshold not to be reached, got %v", err)
+  }
+
+  if _, err := NewClient("http://localhost", common.Address{}, committees, nil, SchemeConfig{}, http.DefaultClient).PostBatch(context.Background(), data, 0); !errors.Is(err, ErrErasureCoded) {
+    t.Errorf("DA API client: expected an erasure coded error, got %v", err)
+  }
+}
@@ -0,0 +1,109 @@
+package dac
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+  ErrInvalidAuthSignature = errors.New("invalid batch authentication signature")
+)
+
+// batchAuthPrefix domain separates the batch authentication signatures from the other signatures
+// of the batcher key, which is by default its L1 transaction key
+var batchAuthPrefix = []byte("OP-DAC-BATCH-AUTH")
+
+// BatchAuth signs the hash of a posted batch so that DAC members can check
+// that the batch comes from an authorized batcher
+type BatchAuth func(dataHash []byte) ([]byte, error)
+
+// BatchAuthHash returns the message signed to authenticate the batch of the given hash to the
+// members of the L2 chain: keccak256(batchAuthPrefix || chainID || dataHash)
+func BatchAuthHash(chainID *big.Int, dataHash []byte) []byte {
+  var id common.Hash
+  if chainID != nil {
+    id = common.BigToHash(chainID)
+  }
+  return crypto.Keccak256(batchAuthPrefix, id.Bytes(), dataHash)
+}
+
+// PrivateKeyBatchAuth signs the batch authentication hashes of the L2 chain with a secp256k1
+// private key
+func PrivateKeyBatchAuth(key *ecdsa.PrivateKey, chainID *big.Int) BatchAuth {
+  return func(dataHash []byte) ([]byte, error) {
+    return crypto.Sign(BatchAuthHash(chainID, dataHash), key)
+  }
+}
+
+// RecoverBatchSigner returns the address that produced the batch authentication signature of
+// the L2 chain
+func RecoverBatchSigner(chainID *big.Int, dataHash []byte, signature []byte) (common.Address, error) {
+  if len(signature) != crypto.SignatureLength {
+    return common.Address{}, ErrInvalidAuthSignature
+  }
+  pubKey, err := crypto.SigToPub(BatchAuthHash(chainID, dataHash), signature)
+  if err != nil {
+    return common.Address{}, fmt.Errorf("%w: %v", ErrInvalidAuthSignature, err)
+  }
+  return crypto.PubkeyToAddress(*pubKey), nil
+}
+
+// BatchPayload is the JSON body of a batch post to a DAC member
+type BatchPayload struct {
+  Data string `json:"data"`
+  // Signature is the hex encoded batch authentication signature of the data hash.
+  // It is omitted when posting without authentication.
+  Signature string `json:"signature,omitempty"`
+  // Version is the signature scheme the members must sign the data hash with
+  Version SchemeVersion `json:"version,omitempty"`
+}
+
+// encodeBatchPayload encodes a batch post body, authenticated if auth is set
+func encodeBatchPayload(data []byte, dataHash []byte, version SchemeVersion, auth BatchAuth) ([]byte, error) {
+  p := BatchPayload{
+    Data: hex.EncodeToString(data),
+    Version: version,
+  }
+  if auth != nil {
+    signature, err := auth(dataHash)
+    if err != nil {
+      return nil, fmt.Errorf("could not authenticate batch: %w", err)
+    }
+    p.Signature = hex.EncodeToString(signature)
+  }
+  return json.Marshal(p)
+}
+
+// ChunkPayload is the JSON body of a chunk post to a member of an erasure coded committee.
+// The members sign the chunk commitment root rather than the data hash.
+type ChunkPayload struct {
+  Chunk
+  Root common.Hash `json:"root"`
+  // Signature is the hex encoded batch authentication signature of the root
+  Signature string `json:"signature,omitempty"`
+  Version SchemeVersion `json:"version,omitempty"`
+}
+
+// encodeChunkPayload encodes a chunk post body, authenticated if auth is set
+func encodeChunkPayload(chunk Chunk, root common.Hash, version SchemeVersion, auth BatchAuth) ([]byte, error) {
+  p := ChunkPayload{
+    Chunk: chunk,
+    Root: root,
+    Version: version,
+  }
+  if auth != nil {
+    signature, err := auth(root.Bytes())
+    if err != nil {
+      return nil, fmt.Errorf("could not authenticate chunk: %w", err)
+    }
+    p.Signature = hex.EncodeToString(signature)
+  }
+  return json.Marshal(p)
+}
@@ -6,16 +6,24 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"math/bits"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
-	"github.com/ethereum/go-ethereum/crypto"
 	bls "github.com/ethereum/go-ethereum/crypto/bls12381"
 )
 
 var (
   ErrKeySetTooLarge = errors.New("key set size is 64 maximum")
+  ErrInvalidMask = errors.New("mask references keys outside of the key set")
+  ErrDuplicateKey = errors.New("duplicate key in the key set")
+  ErrInvalidProofOfPossession = errors.New("invalid proof of possession")
 )
 
+// popDomainTag separates legacy proofs of possession from legacy batch signatures,
+// so that one can never be replayed as the other
+var popDomainTag = []byte("DAC_PROOF_OF_POSSESSION_V1")
+
 const (
   MaxKeySetSize = 64
   AllKeysMask = uint64(0)
@@ -40,8 +48,14 @@ func PublicKeyFromString(s string) (PublicKey, error) {
   return PublicKey{p}, nil
 }
 
-func (p PublicKey) VerifyMessage(message []byte, signature []byte) (bool, error) {
-  return verify(message, signature, p)
+func (p PublicKey) VerifyMessage(domain Domain, message []byte, signature []byte) (bool, error) {
+  return verify(domain, message, signature, p)
+}
+
+// VerifyProofOfPossession checks that the proof is a signature of the public key by
+// its own private key, which rules out rogue key attacks on aggregated signatures
+func (p PublicKey) VerifyProofOfPossession(proof []byte) (bool, error) {
+  return verify(proofOfPossessionDomain, p.ToBytes(), proof, p)
 }
 
 func (p PublicKey) ToBytes() []byte {
@@ -90,8 +104,17 @@ func NewSigner(privateKey string) (Signer, error) {
   return Signer{key}, nil
 }
 
-func (s Signer) Sign(message []byte) (Signature, error) {
-  msgPoint, err := hashToG2(message)
+func (s Signer) Sign(domain Domain, message []byte) (Signature, error) {
+  return s.sign(domain, message)
+}
+
+// ProofOfPossession signs the signer public key under the proof of possession domain
+func (s Signer) ProofOfPossession() (Signature, error) {
+  return s.sign(proofOfPossessionDomain, s.GetPublicKey().ToBytes())
+}
+
+func (s Signer) sign(domain Domain, message []byte) (Signature, error) {
+  msgPoint, err := domain.hashToG2(message)
   if err != nil {
     return Signature{}, err
   }
@@ -125,16 +148,56 @@ func NewKeySet(uncompressedKeys [][]byte) (KeySet, error) {
 }
 
 func NewKeySetFromString(uncompressedKeys []string) (KeySet, error) {
-  encoded := make([][]byte, len(uncompressedKeys))
+  encoded, err := decodeHexStrings(uncompressedKeys)
+  if err != nil {
+    return nil, fmt.Errorf("could not decode key of the set: %w", err)
+  }
+  return NewKeySet(encoded)
+}
+
+// NewKeySetWithProofs creates a key set of unique keys, each one proven to be
+// owned by a proof of possession. proofs[i] is the proof of uncompressedKeys[i].
+func NewKeySetWithProofs(uncompressedKeys []string, proofs []string) (KeySet, error) {
+  if len(proofs) != len(uncompressedKeys) {
+    return nil, fmt.Errorf("%w: %v proofs for %v keys", ErrInvalidProofOfPossession, len(proofs), len(uncompressedKeys))
+  }
+  set, err := NewKeySetFromString(uncompressedKeys)
+  if err != nil {
+    return nil, err
+  }
+  decodedProofs, err := decodeHexStrings(proofs)
+  if err != nil {
+    return nil, fmt.Errorf("could not decode proof of possession: %w", err)
+  }
 
-  for i, str := range uncompressedKeys {
+  seen := map[string]struct{}{}
+  for i, key := range set {
+    if _, ok := seen[string(key.ToBytes())]; ok {
+      return nil, fmt.Errorf("%w: key %v", ErrDuplicateKey, i)
+    }
+    seen[string(key.ToBytes())] = struct{}{}
+
+    isValid, err := key.VerifyProofOfPossession(decodedProofs[i])
+    if err != nil {
+      return nil, fmt.Errorf("%w: key %v: %v", ErrInvalidProofOfPossession, i, err)
+    }
+    if !isValid {
+      return nil, fmt.Errorf("%w: key %v", ErrInvalidProofOfPossession, i)
+    }
+  }
+  return set, nil
+}
+
+func decodeHexStrings(strs []string) ([][]byte, error) {
+  decoded := make([][]byte, len(strs))
+  for i, str := range strs {
     var err error
-    encoded[i], err = hex.DecodeString(str)
+    decoded[i], err = hex.DecodeString(str)
     if err != nil {
-      return nil, fmt.Errorf("could not decode key %v of the set: %w", i, err)
+      return nil, fmt.Errorf("invalid hex string %v: %w", i, err)
     }
   }
-  return NewKeySet(encoded)
+  return decoded, nil
 }
 
 // ComputeMask computes a uint64 mask of the keyset. i'th bit is set iif
@@ -156,6 +219,29 @@ func (s KeySet) ComputeMask(keys []PublicKey) uint64 {
   return mask
 }
 
+// SignerCount returns the number of keys of the keyset selected by the mask.
+// It errors if the mask selects keys that are not part of the keyset.
+func (s KeySet) SignerCount(mask uint64) (uint, error) {
+  if mask == AllKeysMask {
+    return uint(len(s)), nil
+  }
+  if len(s) < MaxKeySetSize && mask >> len(s) != 0 {
+    return 0, ErrInvalidMask
+  }
+  return uint(bits.OnesCount64(mask)), nil
+}
+
+// Signers returns the indexes of the keys of the keyset selected by the mask
+func (s KeySet) Signers(mask uint64) []int {
+  signers := make([]int, 0, len(s))
+  for i := range s {
+    if mask == AllKeysMask || (mask >> i) & 0x1 == 1 {
+      signers = append(signers, i)
+    }
+  }
+  return signers
+}
+
 func (s KeySet) Aggregate(mask uint64) PublicKey {
   g1 := bls.NewG1()
 
@@ -173,13 +259,34 @@ func (s KeySet) Aggregate(mask uint64) PublicKey {
   return PublicKey{aggKey}
 }
 
-func (s KeySet) VerifyMessage(message []byte, signature []byte, mask uint64) (bool, error) {
+func (s KeySet) VerifyMessage(domain Domain, message []byte, signature []byte, mask uint64) (bool, error) {
   aggKey := s.Aggregate(mask)
-  return verify(message, signature, aggKey)
+  return verify(domain, message, signature, aggKey)
 }
 
-func verify(message []byte, signature []byte, key PublicKey) (bool, error) {
-  msgPoint, err := hashToG2(message)
+// VerifyChunkRoot verifies the aggregated signature of the keys selected by the mask, each one
+// over the chunk commitment root along with its own index in the keyset
+func (s KeySet) VerifyChunkRoot(domain Domain, root common.Hash, signature []byte, mask uint64) (bool, error) {
+  sig, err := NewSignature(signature)
+  if err != nil {
+    return false, fmt.Errorf("could not parse signature: %w", err)
+  }
+
+  // e(P_1, H(m_1)) * ... * e(P_n, H(m_n)) == e(G, S)
+  engine := bls.NewPairingEngine()
+  for _, i := range s.Signers(mask) {
+    msgPoint, err := domain.hashToG2(ChunkMessage(root, uint64(i)))
+    if err != nil {
+      return false, fmt.Errorf("could not map message to curve: %w", err)
+    }
+    engine.AddPair(s[i].p, msgPoint)
+  }
+  engine.AddPairInv(engine.G1.One(), sig.p)
+  return engine.Check(), nil
+}
+
+func verify(domain Domain, message []byte, signature []byte, key PublicKey) (bool, error) {
+  msgPoint, err := domain.hashToG2(message)
   if err != nil {
     return false, fmt.Errorf("could not map message to curve: %w", err)
   }
@@ -200,19 +307,6 @@ func verify(message []byte, signature []byte, key PublicKey) (bool, error) {
 
 }
 
-func hashToG2(message []byte) (*bls.PointG2, error) {
-  // hash so that we don't sign an arbitrary value
-  hashed := crypto.Keccak256(message)
-
-  g2 := bls.NewG2()
-  padding := [64]byte{}
-  msgPoint, err := g2.MapToCurve(append(padding[:], hashed...))
-  if err != nil {
-    return nil, fmt.Errorf("could not map message to curve: %w", err)
-  }
-  return msgPoint, nil
-}
-
 func AggregateSignatures(signatures []Signature) Signature {
   g2 := bls.NewG2()
   agg := g2.Zero()
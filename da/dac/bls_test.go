@@ -1,6 +1,8 @@
 package dac_test
 
 import (
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -26,3 +28,40 @@ func TestBLSPublicKey(t *testing.T) {
 
   fmt.Println(pubKey, keyset)
 }
+
+func TestProofOfPossession(t *testing.T) {
+  keys := make([]string, 2)
+  proofs := make([]string, 2)
+  for i, privateKey := range []string{"0x1001", "0x1002"} {
+    signer, err := dac.NewSigner(privateKey)
+    if err != nil {
+      t.Fatal(err)
+    }
+    proof, err := signer.ProofOfPossession()
+    if err != nil {
+      t.Fatal(err)
+    }
+    keys[i] = hex.EncodeToString(signer.GetPublicKey().ToBytes())
+    proofs[i] = hex.EncodeToString(proof.ToBytes())
+  }
+
+  if _, err := dac.NewKeySetWithProofs(keys, proofs); err != nil {
+    t.Errorf("valid proofs: got an error: %v", err)
+  }
+  if _, err := dac.NewKeySetWithProofs(keys, proofs[:1]); !errors.Is(err, dac.ErrInvalidProofOfPossession) {
+    t.Errorf("missing proof: expected invalid proof of possession, got %v", err)
+  }
+  if _, err := dac.NewKeySetWithProofs(keys, []string{proofs[1], proofs[0]}); !errors.Is(err, dac.ErrInvalidProofOfPossession) {
+    t.Errorf("swapped proofs: expected invalid proof of possession, got %v", err)
+  }
+  if _, err := dac.NewKeySetWithProofs([]string{keys[0], keys[0]}, []string{proofs[0], proofs[0]}); !errors.Is(err, dac.ErrDuplicateKey) {
+    t.Errorf("duplicate key: expected duplicate key, got %v", err)
+  }
+
+  // a proof of possession is not a valid signature of the public key as a message
+  key, _ := dac.PublicKeyFromString(keys[0])
+  proof, _ := hex.DecodeString(proofs[0])
+  if isValid, _ := key.VerifyMessage(dac.BatchDomain(dac.SchemeLegacy, nil), key.ToBytes(), proof); isValid {
+    t.Errorf("proof of possession verified as a message signature")
+  }
+}
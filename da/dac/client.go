@@ -2,43 +2,70 @@ package dac
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 
 	"github.com/ethereum-optimism/optimism/da"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 const (
   DACBatchHeaderID uint8 = 1
+
+  dataHashLength = 32
+  signatureLength = 192
+  maskLength = 8
+  // legacy refs have no version byte
+  legacyBatchRefLength = 1 + dataHashLength + signatureLength + maskLength
+  batchRefLength = legacyBatchRefLength + 1
 )
 
 var (
-  ErrInvalidBatchSignature = errors.New("invalid batch signature")
+  ErrInvalidBatchSignature = fmt.Errorf("%w: invalid batch signature", da.ErrInvalidBatchRef)
+  ErrNotEnoughSigners = fmt.Errorf("%w: not enough signers", da.ErrInvalidBatchRef)
+  // the certificate is valid, so the data must exist: a mismatch is a faulty DA API
+  ErrDataHashMismatch = fmt.Errorf("%w: batch data does not match the certified hash", da.ErrUnavailable)
+  ErrInactiveScheme = fmt.Errorf("%w: signature scheme is not active", da.ErrInvalidBatchRef)
+  // erasure coded batches are only stored by the members, as chunks
+  ErrErasureCoded = fmt.Errorf("%w: erasure coded batches are posted to and retrieved from the DAC members", da.ErrMisconfigured)
+)
+
+const (
+  batchPath = "batch"
+  chunkPath = "chunk"
 )
 
 type client struct {
   url *url.URL
   addr common.Address
-  keyset KeySet
+  committees CommitteeSchedule
+  // authenticates posted batches, nil to post without authentication
+  auth BatchAuth
+  schemes SchemeConfig
+  httpClient *http.Client
 }
 
 type batchRef struct {
   addr common.Address
+  version SchemeVersion
   dataHash []byte
   signature []byte
   mask uint64
 }
 
 func (r *batchRef) ToTx() (da.Tx, error) {
-  data := make([]byte, 0, 1 + len(r.dataHash) + len(r.signature) + 8)
+  data := make([]byte, 0, batchRefLength)
 
   data = append(data, DACBatchHeaderID)
+  if r.version != SchemeLegacy {
+    data = append(data, uint8(r.version))
+  }
   data = append(data, r.dataHash...)
   data = append(data, r.signature...)
   data = binary.BigEndian.AppendUint64(data, r.mask)
@@ -51,36 +78,63 @@ func (r *batchRef) ToTx() (da.Tx, error) {
 
 
 // FIXME: remove addr
-func NewClient(apiUrl string, addr common.Address, keyset KeySet) da.Client {
+func NewClient(apiUrl string, addr common.Address, committees CommitteeSchedule, auth BatchAuth, schemes SchemeConfig, httpClient *http.Client) da.Client {
   parsed, err := url.Parse(apiUrl)
   if err != nil {
     panic(fmt.Errorf("invalid DA url: %w", err))
   }
-  return &client{parsed, addr, keyset}
+  return &client{parsed, addr, committees, auth, schemes, httpClient}
 }
 
-func (c *client) PostBatch(data []byte) (da.BatchRef, error) {
-  apiUrl := *c.url
-  apiUrl.Path = "batch"
+// responseError classifies the unexpected response codes of a DA API
+func responseError(op string, statusCode int) error {
+  switch statusCode {
+  case http.StatusNotFound:
+    return fmt.Errorf("%w: %v response code %v", da.ErrBatchNotFound, op, statusCode)
+  case http.StatusUnauthorized, http.StatusForbidden:
+    return fmt.Errorf("%w: %v response code %v", da.ErrMisconfigured, op, statusCode)
+  default:
+    return fmt.Errorf("%w: %v response code %v", da.ErrUnavailable, op, statusCode)
+  }
+}
 
-  httpClient := http.DefaultClient
+// postBatch posts the encoded batch, or chunk at the chunk path, to a DA API, the DAS or a DAC member
+func postBatch(ctx context.Context, httpClient *http.Client, baseUrl *url.URL, path string, encoded []byte) (*http.Response, error) {
+  // the API may be served under the path of the base url
+  apiUrl := baseUrl.JoinPath(path)
 
-  type payload struct {
-    Data string `json:"data"`
-  }
-  p := payload{
-    Data: hex.EncodeToString(data),
-  }
-  encoded, _ := json.Marshal(p)
-  resp, err := httpClient.Post(apiUrl.String(), "application/json", bytes.NewReader(encoded))
+  req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiUrl.String(), bytes.NewReader(encoded))
   if err != nil {
-    return nil, fmt.Errorf("could not post batch: %w", err)
+    return nil, fmt.Errorf("%w: could not create request: %v", da.ErrMisconfigured, err)
   }
-  defer resp.Body.Close()
+  req.Header.Set("Content-Type", "application/json")
 
+  resp, err := httpClient.Do(req)
+  if err != nil {
+    return nil, fmt.Errorf("%w: could not post batch: %v", da.ErrUnavailable, err)
+  }
   if resp.StatusCode != 200 {
-    return nil, fmt.Errorf("invalid post batch response code: %v", resp.StatusCode)
+    resp.Body.Close()
+    return nil, responseError("post batch", resp.StatusCode)
+  }
+  return resp, nil
+}
+
+func (c *client) PostBatch(ctx context.Context, data []byte, l1Time uint64) (da.BatchRef, error) {
+  if c.committees.At(l1Time).ErasureCoded() {
+    return nil, ErrErasureCoded
   }
+  version := c.schemes.versionAt(l1Time)
+  dataHash := crypto.Keccak256(data)
+  encoded, err := encodeBatchPayload(data, dataHash, version, c.auth)
+  if err != nil {
+    return nil, err
+  }
+  resp, err := postBatch(ctx, c.httpClient, c.url, batchPath, encoded)
+  if err != nil {
+    return nil, err
+  }
+  defer resp.Body.Close()
 
   type response struct {
     DataHash string `json:"data_hash"`
@@ -89,11 +143,13 @@ func (c *client) PostBatch(data []byte) (da.BatchRef, error) {
   }
   r := response{}
   if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
-    return nil, fmt.Errorf("invalid post batch response data: %w", err)
+    return nil, fmt.Errorf("%w: invalid post batch response data: %v", da.ErrUnavailable, err)
   }
-  dataHash, err := hex.DecodeString(r.DataHash)
-  if err != nil {
-    return nil, fmt.Errorf("data hash is not valid hex")
+  // the certificate is verified against the hash of the posted data, not the one of the server
+  if serverHash, err := hex.DecodeString(r.DataHash); err != nil {
+    return nil, fmt.Errorf("%w: data hash is not valid hex", da.ErrUnavailable)
+  } else if !bytes.Equal(serverHash, dataHash) {
+    return nil, fmt.Errorf("%w: server data hash %x", ErrDataHashMismatch, serverHash)
   }
 
   publicKeys := make([]PublicKey, 0, len(r.PublicKeys))
@@ -102,61 +158,183 @@ func (c *client) PostBatch(data []byte) (da.BatchRef, error) {
     // FIXME: maybe we should ignore and error here.
     // A broken DAC member should not break the entire batch posting
     if err != nil {
-      return nil, fmt.Errorf("invalid signer public key %v: %w", i, err)
+      return nil, fmt.Errorf("%w: invalid signer public key %v: %v", da.ErrUnavailable, i, err)
     }
     publicKeys = append(publicKeys, publicKey)
   }
 
   signature, err := hex.DecodeString(r.Signature)
   if err != nil {
-    return nil, fmt.Errorf("invalid signature: %w", err)
+    return nil, fmt.Errorf("%w: invalid signature: %v", da.ErrUnavailable, err)
   }
 
-
-  // FIXME: absolutely wrong to rely on the dataHash of the aggregator service
-  // We should compute the hash locally and verify the signature against it
-  isValid, mask, err := c.verifySignature(dataHash, publicKeys, signature)
+  keyset := c.committees.At(l1Time).Keyset
+  isValid, mask, err := verifySignature(keyset, c.schemes.batchDomain(version), dataHash, publicKeys, signature)
   if err != nil {
-    return nil, fmt.Errorf("could not verify batch signature: %w", err)
+    return nil, fmt.Errorf("%w: could not verify batch signature: %v", da.ErrUnavailable, err)
   }
   if !isValid {
     return nil, ErrInvalidBatchSignature
   }
 
-  return &batchRef{c.addr, dataHash, signature, mask}, nil
+  return &batchRef{c.addr, version, dataHash, signature, mask}, nil
 }
 
-func (c *client) verifySignature(
-  dataHash []byte, publicKeys []PublicKey, signature []byte,
+func verifySignature(
+  keyset KeySet, domain Domain, dataHash []byte, publicKeys []PublicKey, signature []byte,
 ) (bool, uint64, error) {
-  mask := c.keyset.ComputeMask(publicKeys)
-  isValid, err := c.keyset.VerifyMessage(dataHash, signature, mask)
+  mask := keyset.ComputeMask(publicKeys)
+  isValid, err := keyset.VerifyMessage(domain, dataHash, signature, mask)
   return isValid, mask, err
 }
 
-func (c *client) GetBatch(dataRef []byte) ([]byte, error) {
-  if len(dataRef) < 33 || dataRef[0] != DACBatchHeaderID {
-    return nil, fmt.Errorf("invalid DAC batch header %x", dataRef[0])
+// parseBatchRef decodes a ref produced by batchRef.ToTx
+func parseBatchRef(dataRef []byte) (*batchRef, error) {
+  if len(dataRef) == 0 || dataRef[0] != DACBatchHeaderID {
+    return nil, fmt.Errorf("%w: invalid DAC batch header", da.ErrInvalidBatchRef)
   }
 
-  // <       1          ><    32   ><     128    ><  8   >
+  // legacy refs:
+  // <       1          ><    32    ><    192    ><  8   >
   // < DACBatchHeaderID >< dataHash >< signature >< mask >
-  dataHash := dataRef[1:33]
+  // versioned refs:
+  // <       1          ><    1    ><    32    ><    192    ><  8   >
+  // < DACBatchHeaderID >< version >< dataHash >< signature >< mask >
+  version := SchemeLegacy
+  offset := 1
+  switch len(dataRef) {
+  case legacyBatchRefLength:
+  case batchRefLength:
+    version = SchemeVersion(dataRef[offset])
+    offset += 1
+    // legacy refs have a single encoding
+    if version == SchemeLegacy {
+      return nil, fmt.Errorf("%w: versioned legacy DAC batch ref", da.ErrInvalidBatchRef)
+    }
+  default:
+    return nil, fmt.Errorf("%w: invalid DAC batch ref length %v", da.ErrInvalidBatchRef, len(dataRef))
+  }
+
+  dataHash := dataRef[offset:offset+dataHashLength]
+  offset += dataHashLength
+  signature := dataRef[offset:offset+signatureLength]
+  offset += signatureLength
+  mask := binary.BigEndian.Uint64(dataRef[offset:])
+
+  return &batchRef{version: version, dataHash: dataHash, signature: signature, mask: mask}, nil
+}
+
+// verifyBatchRef checks that the ref is certified by at least threshold members of the committee,
+// with a signature scheme active at the L1 timestamp the ref was included at.
+// Any failure wraps da.ErrInvalidBatchRef as such a ref can never become valid.
+func verifyBatchRef(committee Committee, schemes SchemeConfig, l1Time uint64, ref *batchRef) error {
+  keyset, threshold := committee.Keyset, committee.Threshold
+  if !schemes.accepts(ref.version, l1Time) {
+    return fmt.Errorf("%w: version %v at L1 time %v", ErrInactiveScheme, ref.version, l1Time)
+  }
+
+  signers, err := keyset.SignerCount(ref.mask)
+  if err != nil {
+    return fmt.Errorf("%w: %v", da.ErrInvalidBatchRef, err)
+  }
+  if signers < threshold {
+    return fmt.Errorf("%w: got %v, need %v", ErrNotEnoughSigners, signers, threshold)
+  }
 
-  apiUrl := *c.url
-  apiUrl.Path = fmt.Sprintf("batch/%s", hex.EncodeToString(dataHash))
+  isValid, err := verifyRefSignature(committee, schemes, ref)
+  if err != nil {
+    return fmt.Errorf("%w: could not verify batch signature: %v", da.ErrInvalidBatchRef, err)
+  }
+  if !isValid {
+    return ErrInvalidBatchSignature
+  }
+  return nil
+}
 
-  fmt.Println(apiUrl.Path)
-  httpClient := http.DefaultClient
+// verifyRefSignature checks the aggregated signature of the signers of the ref: a signature of the
+// data hash, or for an erasure coded committee a signature of the chunk commitment root along with
+// the index of the chunk each signer stores
+func verifyRefSignature(committee Committee, schemes SchemeConfig, ref *batchRef) (bool, error) {
+  if committee.ErasureCoded() {
+    return committee.Keyset.VerifyChunkRoot(schemes.chunkDomain(ref.version), common.BytesToHash(ref.dataHash), ref.signature, ref.mask)
+  }
+  return committee.Keyset.VerifyMessage(schemes.batchDomain(ref.version), ref.dataHash, ref.signature, ref.mask)
+}
 
-  resp, err := httpClient.Get(apiUrl.String())
+// VerifyBatchRef checks the certificate of a ref included in an L1 block of the given timestamp,
+// and returns the certified hash of the batch data. It lets clients retrieving the batch data by
+// other means reuse the verification of the DAC clients.
+func VerifyBatchRef(dataRef []byte, committees CommitteeSchedule, schemes SchemeConfig, l1Time uint64) (common.Hash, error) {
+  ref, err := parseBatchRef(dataRef)
   if err != nil {
-    return nil, fmt.Errorf("could not get batch: %w", err)
+    return common.Hash{}, err
+  }
+  if err := verifyBatchRef(committees.At(l1Time), schemes, l1Time, ref); err != nil {
+    return common.Hash{}, err
+  }
+  return common.BytesToHash(ref.dataHash), nil
+}
+
+// Certificate is the certificate of a batch, as decoded from a DAC batch ref
+type Certificate struct {
+  Version SchemeVersion
+  DataHash common.Hash
+  Signature []byte
+  Mask uint64
+}
+
+// DecodeCertificate decodes a DAC batch ref without verifying its certificate, for inspection
+func DecodeCertificate(dataRef []byte) (Certificate, error) {
+  ref, err := parseBatchRef(dataRef)
+  if err != nil {
+    return Certificate{}, err
+  }
+  return Certificate{ref.version, common.BytesToHash(ref.dataHash), ref.signature, ref.mask}, nil
+}
+
+// Verify checks the certificate as the DAC clients do for a ref included in an L1 block of the
+// given timestamp, against the committee active at that time
+func (c Certificate) Verify(committees CommitteeSchedule, schemes SchemeConfig, l1Time uint64) error {
+  ref := &batchRef{version: c.Version, dataHash: c.DataHash.Bytes(), signature: c.Signature, mask: c.Mask}
+  return verifyBatchRef(committees.At(l1Time), schemes, l1Time, ref)
+}
+
+// VerifySignature checks the aggregated signature of the certificate against the committee,
+// whatever the signer count and the scheme activation
+func (c Certificate) VerifySignature(committee Committee, schemes SchemeConfig) (bool, error) {
+  ref := &batchRef{version: c.Version, dataHash: c.DataHash.Bytes(), signature: c.Signature, mask: c.Mask}
+  return verifyRefSignature(committee, schemes, ref)
+}
+
+func (c *client) GetBatch(ctx context.Context, dataRef []byte, l1Time uint64) ([]byte, error) {
+  dataHash, err := VerifyBatchRef(dataRef, c.committees, c.schemes, l1Time)
+  if err != nil {
+    return nil, err
+  }
+  if c.committees.At(l1Time).ErasureCoded() {
+    return nil, ErrErasureCoded
+  }
+
+  return FetchBatch(ctx, c.httpClient, c.url, dataHash.Bytes())
+}
+
+// FetchBatch retrieves the batch data of the given hash from a DA API, the DAS or a DAC member,
+// and checks it against the hash
+func FetchBatch(ctx context.Context, httpClient *http.Client, baseUrl *url.URL, dataHash []byte) ([]byte, error) {
+  apiUrl := baseUrl.JoinPath(batchPath, hex.EncodeToString(dataHash))
+
+  req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiUrl.String(), nil)
+  if err != nil {
+    return nil, fmt.Errorf("%w: could not create request: %v", da.ErrMisconfigured, err)
+  }
+  resp, err := httpClient.Do(req)
+  if err != nil {
+    return nil, fmt.Errorf("%w: could not get batch: %v", da.ErrUnavailable, err)
   }
   defer resp.Body.Close()
 
   if resp.StatusCode != 200 {
-    return nil, fmt.Errorf("invalid get batch response code: %v", resp.StatusCode)
+    return nil, responseError("get batch", resp.StatusCode)
   }
 
   type response struct {
@@ -164,12 +342,45 @@ func (c *client) GetBatch(dataRef []byte) ([]byte, error) {
   }
   r := response{}
   if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
-    return nil, fmt.Errorf("invalid get batch response data: %w", err)
+    return nil, fmt.Errorf("%w: invalid get batch response data: %v", da.ErrUnavailable, err)
   }
 
   rawData, err := hex.DecodeString(r.Data)
   if err != nil {
-    return nil, fmt.Errorf("invalid batch data: %w", err)
+    return nil, fmt.Errorf("%w: invalid batch data: %v", da.ErrUnavailable, err)
+  }
+
+  if !bytes.Equal(crypto.Keccak256(rawData), dataHash) {
+    return nil, ErrDataHashMismatch
   }
   return rawData, nil
 }
+
+// FetchChunk retrieves the chunk a member of an erasure coded committee stores for the given
+// chunk commitment root, and checks its inclusion in the root among total chunks
+func FetchChunk(ctx context.Context, httpClient *http.Client, baseUrl *url.URL, root common.Hash, total uint64) (Chunk, error) {
+  apiUrl := baseUrl.JoinPath(chunkPath, hex.EncodeToString(root.Bytes()))
+
+  req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiUrl.String(), nil)
+  if err != nil {
+    return Chunk{}, fmt.Errorf("%w: could not create request: %v", da.ErrMisconfigured, err)
+  }
+  resp, err := httpClient.Do(req)
+  if err != nil {
+    return Chunk{}, fmt.Errorf("%w: could not get chunk: %v", da.ErrUnavailable, err)
+  }
+  defer resp.Body.Close()
+
+  if resp.StatusCode != 200 {
+    return Chunk{}, responseError("get chunk", resp.StatusCode)
+  }
+
+  var chunk Chunk
+  if err := json.NewDecoder(resp.Body).Decode(&chunk); err != nil {
+    return Chunk{}, fmt.Errorf("%w: invalid get chunk response data: %v", da.ErrUnavailable, err)
+  }
+  if chunk.Total != total || !chunk.Verify(root) {
+    return Chunk{}, ErrInvalidChunk
+  }
+  return chunk, nil
+}
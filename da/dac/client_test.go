@@ -1,11 +1,20 @@
 package dac
 
 import (
+	"bytes"
+	"context"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/ethereum-optimism/optimism/da"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 func TestClient(t *testing.T) {
@@ -32,8 +41,8 @@ func TestClient(t *testing.T) {
    dataHash, _ := hex.DecodeString(dataHashStr)
    signature, _ := hex.DecodeString(signatureStr)
 
-   c := NewClient("", (common.Address{}), keyset).(*client)
-   fmt.Println(c.verifySignature(dataHash, keyset, signature))
+   legacy := BatchDomain(SchemeLegacy, nil)
+   fmt.Println(verifySignature(keyset, legacy, dataHash, keyset, signature))
 
    signatures := make([]Signature, len(signaturesStr))
 
@@ -52,11 +61,280 @@ func TestClient(t *testing.T) {
    if err != nil {
      t.Fatal(err)
    }
-   sig, err := signer.Sign(dataHash)
+   sig, err := signer.Sign(legacy, dataHash)
    if err != nil {
      t.Fatal(err)
    }
 
    
-   fmt.Println(signer.GetPublicKey().VerifyMessage(dataHash, sig.ToBytes()))
+   fmt.Println(signer.GetPublicKey().VerifyMessage(legacy, dataHash, sig.ToBytes()))
+}
+
+var testChainID = big.NewInt(901)
+
+func newTestCommittee(t *testing.T, size int) ([]Signer, KeySet) {
+  signers := make([]Signer, size)
+  keys := make([][]byte, size)
+  for i := range signers {
+    signer, err := NewSigner(fmt.Sprintf("0x%x", 0x1000+i))
+    if err != nil {
+      t.Fatal(err)
+    }
+    signers[i] = signer
+    keys[i] = signer.GetPublicKey().ToBytes()
+  }
+  keyset, err := NewKeySet(keys)
+  if err != nil {
+    t.Fatal(err)
+  }
+  return signers, keyset
+}
+
+func certify(t *testing.T, domain Domain, signers []Signer, dataHash []byte) ([]byte, uint64) {
+  signatures := make([]Signature, 0, len(signers))
+  mask := uint64(0)
+  for i, signer := range signers {
+    if signer.b == nil {
+      continue
+    }
+    sig, err := signer.Sign(domain, dataHash)
+    if err != nil {
+      t.Fatal(err)
+    }
+    signatures = append(signatures, sig)
+    mask |= 1 << i
+  }
+  return AggregateSignatures(signatures).ToBytes(), mask
+}
+
+// certifyChunks aggregates the signatures of the chunk commitment root by the signers,
+// each one along with its own index
+func certifyChunks(t *testing.T, domain Domain, signers []Signer, root common.Hash) ([]byte, uint64) {
+  signatures := make([]Signature, 0, len(signers))
+  mask := uint64(0)
+  for i, signer := range signers {
+    if signer.b == nil {
+      continue
+    }
+    sig, err := signer.Sign(domain, ChunkMessage(root, uint64(i)))
+    if err != nil {
+      t.Fatal(err)
+    }
+    signatures = append(signatures, sig)
+    mask |= 1 << i
+  }
+  return AggregateSignatures(signatures).ToBytes(), mask
+}
+
+func TestGetBatch(t *testing.T) {
+  data := []byte("some batch data")
+  dataHash := crypto.Keccak256(data)
+  served := data
+
+  srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    if r.URL.Path != "/batch/" + hex.EncodeToString(dataHash) {
+      w.WriteHeader(http.StatusNotFound)
+      return
+    }
+    json.NewEncoder(w).Encode(map[string]string{"data": hex.EncodeToString(served)})
+  }))
+  defer srv.Close()
+
+  signers, keyset := newTestCommittee(t, 3)
+  v1Time := uint64(1000)
+  c := NewClient(srv.URL, common.Address{}, SingleCommittee(keyset, 2), nil, SchemeConfig{testChainID, &v1Time}, http.DefaultClient)
+
+  toVersionedRef := func(version SchemeVersion, signature []byte, mask uint64) []byte {
+    tx, err := (&batchRef{version: version, dataHash: dataHash, signature: signature, mask: mask}).ToTx()
+    if err != nil {
+      t.Fatal(err)
+    }
+    return tx.Data
+  }
+  toRef := func(signature []byte, mask uint64) []byte {
+    return toVersionedRef(SchemeLegacy, signature, mask)
+  }
+  toV1Ref := func(signature []byte, mask uint64) []byte {
+    return toVersionedRef(SchemeV1, signature, mask)
+  }
+  legacy := BatchDomain(SchemeLegacy, testChainID)
+  v1 := BatchDomain(SchemeV1, testChainID)
+
+  // two out of three members signed
+  signature, mask := certify(t, legacy, []Signer{signers[0], {}, signers[2]}, dataHash)
+  v1Signature, _ := certify(t, v1, []Signer{signers[0], {}, signers[2]}, dataHash)
+  validRefs := map[string]struct{
+    ref []byte
+    l1Time uint64
+  }{
+    "legacy": {toRef(signature, mask), 0},
+    "v1": {toV1Ref(v1Signature, mask), v1Time},
+  }
+  for name, valid := range validRefs {
+    got, err := c.GetBatch(context.Background(), valid.ref, valid.l1Time)
+    if err != nil {
+      t.Fatalf("%v: got an error: %v", name, err)
+    }
+    if !bytes.Equal(got, data) {
+      t.Fatalf("%v: got %x, want %x", name, got, data)
+    }
+  }
+
+  versionedLegacy := append([]byte{DACBatchHeaderID, uint8(SchemeLegacy)}, toRef(signature, mask)[1:]...)
+  // legacy refs are checked before v1 is active, so that they are rejected for their certificate
+  invalidRefs := map[string]struct{
+    ref []byte
+    l1Time uint64
+  }{
+    "below threshold": {toRef(certify(t, legacy, []Signer{signers[0]}, dataHash)), 0},
+    "wrong mask": {toRef(signature, 0b011), 0},
+    "mask outside keyset": {toRef(signature, mask | 0b1000), 0},
+    "forged signature": {toRef(certify(t, legacy, []Signer{signers[0], signers[1]}, crypto.Keccak256([]byte("other")))), 0},
+    "truncated": {toRef(signature, mask)[:100], 0},
+    "v1 with legacy signature": {toV1Ref(signature, mask), v1Time},
+    "legacy with v1 signature": {toRef(v1Signature, mask), 0},
+    "v1 of another chain": {toV1Ref(certify(t, BatchDomain(SchemeV1, big.NewInt(902)), []Signer{signers[0], {}, signers[2]}, dataHash)), v1Time},
+    "unknown version": {toVersionedRef(SchemeV1 + 1, v1Signature, mask), v1Time},
+    "versioned legacy": {versionedLegacy, v1Time},
+  }
+  for name, invalid := range invalidRefs {
+    if _, err := c.GetBatch(context.Background(), invalid.ref, invalid.l1Time); !errors.Is(err, da.ErrInvalidBatchRef) {
+      t.Errorf("%v: expected an invalid batch ref error, got %v", name, err)
+    }
+  }
+  // legacy certificates are not bound to the chain, they could be replayed once v1 is active
+  if _, err := c.GetBatch(context.Background(), toRef(signature, mask), v1Time); !errors.Is(err, ErrInactiveScheme) {
+    t.Errorf("legacy after v1 activation: expected an inactive scheme error, got %v", err)
+  }
+  if _, err := c.GetBatch(context.Background(), toV1Ref(v1Signature, mask), v1Time - 1); !errors.Is(err, ErrInactiveScheme) {
+    t.Errorf("v1 before activation: expected an inactive scheme error, got %v", err)
+  }
+
+  // a valid certificate with data not matching the hash is not an invalid ref
+  served = []byte("tampered data")
+  if _, err := c.GetBatch(context.Background(), toRef(signature, mask), 0); !errors.Is(err, ErrDataHashMismatch) {
+    t.Errorf("tampered data: expected a data hash mismatch, got %v", err)
+  }
+}
+
+func TestPostBatch(t *testing.T) {
+  data := []byte("some batch data")
+  dataHash := crypto.Keccak256(data)
+  signers, keyset := newTestCommittee(t, 3)
+  domain := BatchDomain(SchemeLegacy, testChainID)
+
+  // the server certifies the hash it responds with, which may not be the hash of the posted data
+  respondedHash := dataHash
+  srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    signature, _ := certify(t, domain, signers[:2], respondedHash)
+    json.NewEncoder(w).Encode(map[string]interface{}{
+      "data_hash": hex.EncodeToString(respondedHash),
+      "public_keys": []string{
+        hex.EncodeToString(signers[0].GetPublicKey().ToBytes()),
+        hex.EncodeToString(signers[1].GetPublicKey().ToBytes()),
+      },
+      "signature": hex.EncodeToString(signature),
+    })
+  }))
+  defer srv.Close()
+  c := NewClient(srv.URL, common.Address{}, SingleCommittee(keyset, 2), nil, SchemeConfig{ChainID: testChainID}, http.DefaultClient)
+
+  ref, err := c.PostBatch(context.Background(), data, 0)
+  if err != nil {
+    t.Fatalf("got an error: %v", err)
+  }
+  if got := ref.(*batchRef).dataHash; !bytes.Equal(got, dataHash) {
+    t.Fatalf("got a ref of %x, want %x", got, dataHash)
+  }
+
+  respondedHash = crypto.Keccak256([]byte("other batch data"))
+  if _, err := c.PostBatch(context.Background(), data, 0); !errors.Is(err, ErrDataHashMismatch) {
+    t.Errorf("other data hash: expected a data hash mismatch, got %v", err)
+  }
+}
+
+func TestCommitteeRotation(t *testing.T) {
+  data := []byte("some batch data")
+  dataHash := crypto.Keccak256(data)
+  srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    json.NewEncoder(w).Encode(map[string]string{"data": hex.EncodeToString(data)})
+  }))
+  defer srv.Close()
+
+  signers, _ := newTestCommittee(t, 4)
+  keys := make([][]byte, len(signers))
+  for i, signer := range signers {
+    keys[i] = signer.GetPublicKey().ToBytes()
+  }
+  genesis, _ := NewKeySet(keys[:3])
+  rotated, _ := NewKeySet(keys[1:])
+
+  if _, err := NewCommitteeSchedule(
+    ScheduledCommittee{100, Committee{Keyset: genesis, Threshold: 2}},
+    ScheduledCommittee{100, Committee{Keyset: rotated, Threshold: 2}},
+  ); !errors.Is(err, ErrInvalidCommitteeSchedule) {
+    t.Errorf("expected an invalid schedule, got %v", err)
+  }
+  committees, err := NewCommitteeSchedule(
+    ScheduledCommittee{0, Committee{Keyset: genesis, Threshold: 2}},
+    ScheduledCommittee{100, Committee{Keyset: rotated, Threshold: 3}},
+  )
+  if err != nil {
+    t.Fatal(err)
+  }
+  c := NewClient(srv.URL, common.Address{}, committees, nil, SchemeConfig{}, http.DefaultClient)
+
+  toRef := func(signature []byte, mask uint64) []byte {
+    tx, _ := (&batchRef{dataHash: dataHash, signature: signature, mask: mask}).ToTx()
+    return tx.Data
+  }
+  legacy := BatchDomain(SchemeLegacy, nil)
+  // members 1 and 2 are at index 0 and 1 of the rotated keyset
+  byGenesis := toRef(certify(t, legacy, []Signer{{}, signers[1], signers[2]}, dataHash))
+  byRotated := toRef(certify(t, legacy, signers[1:], dataHash))
+
+  if _, err := c.GetBatch(context.Background(), byGenesis, 99); err != nil {
+    t.Errorf("genesis committee before rotation: got an error: %v", err)
+  }
+  if _, err := c.GetBatch(context.Background(), byGenesis, 100); !errors.Is(err, da.ErrInvalidBatchRef) {
+    t.Errorf("genesis committee after rotation: expected an invalid batch ref error, got %v", err)
+  }
+  if _, err := c.GetBatch(context.Background(), byRotated, 100); err != nil {
+    t.Errorf("rotated committee after rotation: got an error: %v", err)
+  }
+  if _, err := c.GetBatch(context.Background(), byRotated, 99); !errors.Is(err, da.ErrInvalidBatchRef) {
+    t.Errorf("rotated committee before rotation: expected an invalid batch ref error, got %v", err)
+  }
+}
+
+func TestDecodeCertificate(t *testing.T) {
+  dataHash := crypto.Keccak256([]byte("some batch data"))
+  signers, keyset := newTestCommittee(t, 3)
+  committees := SingleCommittee(keyset, 2)
+  schemes := SchemeConfig{ChainID: big.NewInt(901), V1Time: new(uint64)}
+
+  signature, mask := certify(t, BatchDomain(SchemeV1, schemes.ChainID), []Signer{signers[0], {}, signers[2]}, dataHash)
+  tx, _ := (&batchRef{version: SchemeV1, dataHash: dataHash, signature: signature, mask: mask}).ToTx()
+
+  cert, err := DecodeCertificate(tx.Data)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if cert.Version != SchemeV1 || cert.DataHash != common.BytesToHash(dataHash) || cert.Mask != mask {
+    t.Errorf("unexpected certificate: %+v", cert)
+  }
+  if signers := keyset.Signers(cert.Mask); len(signers) != 2 || signers[0] != 0 || signers[1] != 2 {
+    t.Errorf("expected signers 0 and 2, got %v", signers)
+  }
+  if err := cert.Verify(committees, schemes, 0); err != nil {
+    t.Errorf("expected a valid certificate, got %v", err)
+  }
+
+  cert.Mask = 0b011
+  if err := cert.Verify(committees, schemes, 0); !errors.Is(err, ErrInvalidBatchSignature) {
+    t.Errorf("wrong signers: expected an invalid signature, got %v", err)
+  }
+  if _, err := DecodeCertificate(tx.Data[:len(tx.Data)-2]); !errors.Is(err, da.ErrInvalidBatchRef) {
+    t.Errorf("expected an invalid batch ref, got %v", err)
+  }
 }
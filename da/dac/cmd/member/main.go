@@ -1,14 +1,12 @@
 package main
 
 import (
-	"encoding/hex"
 	"fmt"
-	"net/http"
 	"os"
 
-	"github.com/gorilla/mux"
 	"github.com/urfave/cli"
 
+	"github.com/ethereum-optimism/optimism/da/dac/member"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -19,63 +17,28 @@ var (
 	GitDate   = ""
 )
 
-var flags = []cli.Flag{
-  cli.IntFlag{
-    Name: "port",
-    Usage: "port of the HTTP server",
-    EnvVar: "PORT",
-    Value: 3000,
-  },
-  cli.StringFlag{
-    Name: "private-key",
-    Usage: "32 bytes BLS private key",
-    EnvVar: "PRIVATE_KEY",
-  },
-  cli.StringFlag{
-    Name: "directory",
-    Usage: "path to directory where batches will be stored",
-    EnvVar: "DIRECTORY",
-  },
-}
-
 func main() {
 	oplog.SetupDefaults()
 
 	app := cli.NewApp()
-	app.Flags = flags
+	app.Flags = member.Flags
 	app.Version = fmt.Sprintf("%s-%s-%s", Version, GitCommit, GitDate)
 	app.Name = "dac-member"
 	app.Usage = "DAC Member"
 	app.Description = "Service for storing batches of data and sign a proof of storage"
-	app.Action = Member
-	app.Commands = []cli.Command{}
+	app.Action = member.Main
+	app.Commands = []cli.Command{
+		{
+			Name:        "sync",
+			Usage:       "Backfills the storage with the batches of other members",
+			Description: "Streams the batches of every peer in turn and stores the ones missing locally, after checking they hash to their id",
+			Flags:       member.SyncFlags,
+			Action:      member.Sync,
+		},
+	}
 
 	err := app.Run(os.Args)
 	if err != nil {
 		log.Crit("Application failed", "message", err)
 	}
 }
-
-func Member(ctx *cli.Context) error {
-  port := ctx.Int("port")
-
-  storage := newFileStorage(ctx.String("directory"))
-
-  member, err := newMember(storage, ctx.String("private-key"))
-  if err != nil {
-    return err
-  }
-
-  r := mux.NewRouter()
-  r.HandleFunc("/batch", member.handlePost).Methods("POST")
-  r.HandleFunc("/batch/{dataHash}", member.handleGet).Methods("GET")
-
-  srv := &http.Server{
-    Addr:    fmt.Sprintf(":%v", port),
-    Handler: r,
-  }
-
-  publicKey := member.signer.GetPublicKey()
-  log.Info("HTTP server start", "port", port, "public_key", hex.EncodeToString(publicKey.ToBytes()))
-  return srv.ListenAndServe()
-}
@@ -0,0 +1,111 @@
+package dac
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+var (
+  ErrInvalidCommitteeSchedule = errors.New("DAC committee activation times must be increasing")
+  ErrMissingMemberUrl = errors.New("no url for DAC member")
+)
+
+// Committee is a DAC keyset with the minimum number of its members that must sign a batch
+type Committee struct {
+  Keyset KeySet
+  Threshold uint
+  // DataShards is the number of chunks any of which reconstruct a batch, when the batches are
+  // erasure coded into one chunk per member. Zero stores the full batch on every member.
+  DataShards uint
+}
+
+// ErasureCoded reports whether the members store chunks of the batches rather than full batches
+func (c Committee) ErasureCoded() bool {
+  return c.DataShards > 0
+}
+
+// ScheduledCommittee is a committee verifying the batch refs included at or after an L1 timestamp
+type ScheduledCommittee struct {
+  ActivationTime uint64
+  Committee
+}
+
+// CommitteeSchedule lists the committees by increasing activation time. The first committee is
+// also active before its activation time.
+type CommitteeSchedule []ScheduledCommittee
+
+// NewCommitteeSchedule checks that the committees are ordered by activation time
+func NewCommitteeSchedule(committees ...ScheduledCommittee) (CommitteeSchedule, error) {
+  if len(committees) == 0 {
+    return nil, fmt.Errorf("%w: no committee", ErrInvalidCommitteeSchedule)
+  }
+  for i := 1; i < len(committees); i++ {
+    if committees[i].ActivationTime <= committees[i-1].ActivationTime {
+      return nil, fmt.Errorf("%w: committee %v", ErrInvalidCommitteeSchedule, i)
+    }
+  }
+  return CommitteeSchedule(committees), nil
+}
+
+// SingleCommittee is the schedule of a committee that never rotates
+func SingleCommittee(keyset KeySet, threshold uint) CommitteeSchedule {
+  return CommitteeSchedule{{Committee: Committee{Keyset: keyset, Threshold: threshold}}}
+}
+
+// At returns the committee active at the given L1 timestamp
+func (s CommitteeSchedule) At(l1Time uint64) Committee {
+  for i := len(s) - 1; i > 0; i-- {
+    if l1Time >= s[i].ActivationTime {
+      return s[i].Committee
+    }
+  }
+  return s[0].Committee
+}
+
+// ParseMemberUrls parses "<hex public key>=<url>" entries into a map of public keys to urls
+func ParseMemberUrls(entries []string) (map[string]string, error) {
+  urls := make(map[string]string, len(entries))
+  for _, entry := range entries {
+    key, memberUrl, ok := strings.Cut(entry, "=")
+    if !ok {
+      return nil, fmt.Errorf("invalid DAC member %q, expected <public key>=<url>", entry)
+    }
+    urls[key] = memberUrl
+  }
+  return urls, nil
+}
+
+// memberEndpoints maps the members of every committee of the schedule to their url,
+// indexed by the bytes of their public key
+type memberEndpoints map[string]*url.URL
+
+func newMemberEndpoints(memberUrls map[string]string, committees CommitteeSchedule) (memberEndpoints, error) {
+  endpoints := memberEndpoints{}
+  for key, memberUrl := range memberUrls {
+    publicKey, err := PublicKeyFromString(strings.TrimPrefix(key, "0x"))
+    if err != nil {
+      return nil, fmt.Errorf("invalid DAC member public key %v: %w", key, err)
+    }
+    parsed, err := url.Parse(memberUrl)
+    if err != nil {
+      return nil, fmt.Errorf("invalid DAC member %v url: %w", key, err)
+    }
+    endpoints[string(publicKey.ToBytes())] = parsed
+  }
+
+  for _, committee := range committees {
+    for i, key := range committee.Keyset {
+      if _, ok := endpoints[string(key.ToBytes())]; !ok {
+        return nil, fmt.Errorf("%w: %v, member %v of the committee active at %v", ErrMissingMemberUrl, hex.EncodeToString(key.ToBytes()), i, committee.ActivationTime)
+      }
+    }
+  }
+  return endpoints, nil
+}
+
+func (e memberEndpoints) of(key PublicKey) *url.URL {
+  return e[string(key.ToBytes())]
+}
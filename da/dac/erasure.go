@@ -0,0 +1,192 @@
+package dac
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/klauspost/reedsolomon"
+
+	"github.com/ethereum-optimism/optimism/da"
+)
+
+var (
+  ErrInvalidErasureCoding = errors.New("invalid erasure coding parameters")
+  ErrInvalidChunk = fmt.Errorf("%w: chunk is not included in the chunk commitment root", da.ErrUnavailable)
+  ErrNotEnoughChunks = fmt.Errorf("%w: not enough chunks to reconstruct the batch", da.ErrUnavailable)
+  // the chunks were not encoded from a single batch, no subset of them is trusted. The committee
+  // certified the root of an invalid encoding, which can never resolve to a batch
+  ErrRootMismatch = fmt.Errorf("%w: reconstructed batch does not match the chunk commitment root", da.ErrInvalidBatchRef)
+)
+
+// the chunk commitment tree leaves and nodes are domain separated, so that a node can never be
+// proven as a chunk
+var (
+  chunkLeafPrefix = []byte{0}
+  chunkNodePrefix = []byte{1}
+)
+
+// Chunk is a Reed-Solomon shard of a batch, with the proof of its inclusion in the chunk
+// commitment root at its index
+type Chunk struct {
+  Index uint64 `json:"index"`
+  // Total is the number of chunks the batch is encoded into, one per committee member
+  Total uint64 `json:"total"`
+  Data hexutil.Bytes `json:"data"`
+  Proof []common.Hash `json:"proof"`
+}
+
+func chunkLeaf(data []byte) common.Hash {
+  return crypto.Keccak256Hash(chunkLeafPrefix, data)
+}
+
+func chunkNode(left, right common.Hash) common.Hash {
+  return crypto.Keccak256Hash(chunkNodePrefix, left.Bytes(), right.Bytes())
+}
+
+// chunkTreeDepth is the depth of the commitment tree of total chunks, padded with zero leaves
+// to a power of two
+func chunkTreeDepth(total uint64) int {
+  depth := 0
+  for uint64(1) << depth < total {
+    depth++
+  }
+  return depth
+}
+
+// Verify checks that the chunk is included in the chunk commitment root at its index
+func (c *Chunk) Verify(root common.Hash) bool {
+  if c.Index >= c.Total || len(c.Proof) != chunkTreeDepth(c.Total) {
+    return false
+  }
+  node := chunkLeaf(c.Data)
+  for i, sibling := range c.Proof {
+    if (c.Index >> i) & 1 == 0 {
+      node = chunkNode(node, sibling)
+    } else {
+      node = chunkNode(sibling, node)
+    }
+  }
+  return node == root
+}
+
+// commitChunks returns the chunk commitment root of the shards, and sets the proofs of the chunks
+func commitChunks(shards [][]byte) (common.Hash, []Chunk) {
+  total := uint64(len(shards))
+  depth := chunkTreeDepth(total)
+  level := make([]common.Hash, 1 << depth)
+  for i, shard := range shards {
+    level[i] = chunkLeaf(shard)
+  }
+
+  chunks := make([]Chunk, total)
+  for i := range chunks {
+    chunks[i] = Chunk{Index: uint64(i), Total: total, Data: shards[i], Proof: make([]common.Hash, 0, depth)}
+  }
+  for len(level) > 1 {
+    for i := range chunks {
+      position := i >> len(chunks[i].Proof)
+      chunks[i].Proof = append(chunks[i].Proof, level[position ^ 1])
+    }
+    next := make([]common.Hash, len(level) / 2)
+    for i := range next {
+      next[i] = chunkNode(level[2 * i], level[2 * i + 1])
+    }
+    level = next
+  }
+  return level[0], chunks
+}
+
+// encodeShards splits the batch, prefixed by its length, into dataShards shards zero padded to
+// the same size, and extends them with parity shards up to total shards
+func encodeShards(data []byte, dataShards, total uint64) ([][]byte, error) {
+  if dataShards == 0 || dataShards > total {
+    return nil, fmt.Errorf("%w: %v data shards out of %v", ErrInvalidErasureCoding, dataShards, total)
+  }
+  enc, err := reedsolomon.New(int(dataShards), int(total - dataShards))
+  if err != nil {
+    return nil, fmt.Errorf("%w: %v", ErrInvalidErasureCoding, err)
+  }
+
+  prefixed := binary.BigEndian.AppendUint64(make([]byte, 0, 8 + len(data)), uint64(len(data)))
+  prefixed = append(prefixed, data...)
+  shardSize := (uint64(len(prefixed)) + dataShards - 1) / dataShards
+  shards := make([][]byte, total)
+  for i := range shards {
+    shards[i] = make([]byte, shardSize)
+    if uint64(i) < dataShards {
+      start := uint64(i) * shardSize
+      if start < uint64(len(prefixed)) {
+        copy(shards[i], prefixed[start:])
+      }
+    }
+  }
+  if err := enc.Encode(shards); err != nil {
+    return nil, fmt.Errorf("could not encode batch: %w", err)
+  }
+  return shards, nil
+}
+
+// EncodeChunks erasure codes the batch into total chunks, any dataShards of which reconstruct
+// it, and returns them with their chunk commitment root
+func EncodeChunks(data []byte, dataShards, total uint64) (common.Hash, []Chunk, error) {
+  shards, err := encodeShards(data, dataShards, total)
+  if err != nil {
+    return common.Hash{}, nil, err
+  }
+  root, chunks := commitChunks(shards)
+  return root, chunks, nil
+}
+
+// ReconstructBatch decodes the batch from at least dataShards distinct chunks out of total,
+// verified against the root. The batch is encoded again and checked against the root, so that
+// the chunks of an invalid encoding never decode to different batches depending on the subset
+// retrieved.
+func ReconstructBatch(chunks []Chunk, dataShards, total uint64, root common.Hash) ([]byte, error) {
+  if dataShards == 0 || dataShards > total {
+    return nil, fmt.Errorf("%w: %v data shards out of %v", ErrInvalidErasureCoding, dataShards, total)
+  }
+  shards := make([][]byte, total)
+  found := uint64(0)
+  for _, chunk := range chunks {
+    if chunk.Total != total || !chunk.Verify(root) {
+      return nil, ErrInvalidChunk
+    }
+    if shards[chunk.Index] == nil {
+      shards[chunk.Index] = chunk.Data
+      found++
+    }
+  }
+  if found < dataShards {
+    return nil, fmt.Errorf("%w: got %v, need %v", ErrNotEnoughChunks, found, dataShards)
+  }
+
+  enc, err := reedsolomon.New(int(dataShards), int(total - dataShards))
+  if err != nil {
+    return nil, fmt.Errorf("%w: %v", ErrInvalidErasureCoding, err)
+  }
+  if err := enc.ReconstructData(shards); err != nil {
+    return nil, fmt.Errorf("%w: %v", ErrRootMismatch, err)
+  }
+
+  prefixed := make([]byte, 0, uint64(len(shards[0])) * dataShards)
+  for _, shard := range shards[:dataShards] {
+    prefixed = append(prefixed, shard...)
+  }
+  if len(prefixed) < 8 {
+    return nil, ErrRootMismatch
+  }
+  length := binary.BigEndian.Uint64(prefixed)
+  if length > uint64(len(prefixed) - 8) {
+    return nil, ErrRootMismatch
+  }
+  data := prefixed[8:8 + length]
+
+  if encoded, _, err := EncodeChunks(data, dataShards, total); err != nil || encoded != root {
+    return nil, ErrRootMismatch
+  }
+  return data, nil
+}
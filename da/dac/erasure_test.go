@@ -0,0 +1,73 @@
+package dac
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestEncodeChunks(t *testing.T) {
+  data := bytes.Repeat([]byte("some batch data"), 10)
+  root, chunks, err := EncodeChunks(data, 3, 5)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if len(chunks) != 5 {
+    t.Fatalf("expected 5 chunks, got %v", len(chunks))
+  }
+  for i := range chunks {
+    if !chunks[i].Verify(root) {
+      t.Errorf("chunk %v does not verify against the root", i)
+    }
+  }
+
+  // any 3 chunks reconstruct the batch
+  for _, subset := range [][]int{{0, 1, 2}, {2, 3, 4}, {4, 0, 3}, {1, 1, 3, 4}} {
+    var selected []Chunk
+    for _, i := range subset {
+      selected = append(selected, chunks[i])
+    }
+    got, err := ReconstructBatch(selected, 3, 5, root)
+    if err != nil {
+      t.Fatalf("chunks %v: got an error: %v", subset, err)
+    }
+    if !bytes.Equal(got, data) {
+      t.Fatalf("chunks %v: got %x, want %x", subset, got, data)
+    }
+  }
+
+  if _, err := ReconstructBatch([]Chunk{chunks[0], chunks[0], chunks[4]}, 3, 5, root); !errors.Is(err, ErrNotEnoughChunks) {
+    t.Errorf("duplicate chunks: expected not enough chunks, got %v", err)
+  }
+
+  tampered := chunks[1]
+  tampered.Data = append([]byte{}, tampered.Data...)
+  tampered.Data[0] ^= 1
+  if _, err := ReconstructBatch([]Chunk{chunks[0], tampered, chunks[2]}, 3, 5, root); !errors.Is(err, ErrInvalidChunk) {
+    t.Errorf("tampered chunk: expected an invalid chunk, got %v", err)
+  }
+  moved := chunks[1]
+  moved.Index = 3
+  if moved.Verify(root) {
+    t.Errorf("a chunk must not verify at another index")
+  }
+
+  if _, _, err := EncodeChunks(data, 6, 5); !errors.Is(err, ErrInvalidErasureCoding) {
+    t.Errorf("too many data shards: expected invalid erasure coding, got %v", err)
+  }
+}
+
+func TestReconstructBatchInvalidEncoding(t *testing.T) {
+  // parity shards not matching the data shards are committed to: the batch reconstructed from
+  // the data shards is rejected, so that no subset of chunks decodes to another batch
+  shards, err := encodeShards([]byte("some batch data"), 2, 4)
+  if err != nil {
+    t.Fatal(err)
+  }
+  shards[3] = bytes.Repeat([]byte{0xff}, len(shards[3]))
+  root, chunks := commitChunks(shards)
+
+  if _, err := ReconstructBatch(chunks[:2], 2, 4, root); !errors.Is(err, ErrRootMismatch) {
+    t.Errorf("expected a root mismatch, got %v", err)
+  }
+}
@@ -0,0 +1,166 @@
+package member
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/time/rate"
+)
+
+// Authorizer decides whether the signer of a batch may have it stored and signed
+type Authorizer interface {
+  IsAuthorized(addr common.Address) bool
+}
+
+// Allowlist authorizes a static set of batcher addresses
+type Allowlist map[common.Address]struct{}
+
+func NewAllowlist(addrs []string) Allowlist {
+  l := Allowlist{}
+  for _, addr := range addrs {
+    l[common.HexToAddress(addr)] = struct{}{}
+  }
+  return l
+}
+
+func (l Allowlist) IsAuthorized(addr common.Address) bool {
+  _, ok := l[addr]
+  return ok
+}
+
+// systemConfigAuthorizer authorizes the batcher address of the rollup SystemConfig contract,
+// refreshed periodically
+type systemConfigAuthorizer struct {
+  caller *bindings.SystemConfigCaller
+
+  mu sync.RWMutex
+  batcher common.Address
+}
+
+func newSystemConfigAuthorizer(ctx context.Context, client bind.ContractCaller, addr common.Address) (*systemConfigAuthorizer, error) {
+  caller, err := bindings.NewSystemConfigCaller(addr, client)
+  if err != nil {
+    return nil, err
+  }
+  a := &systemConfigAuthorizer{caller: caller}
+  if err := a.refresh(ctx); err != nil {
+    return nil, err
+  }
+  return a, nil
+}
+
+func (a *systemConfigAuthorizer) refresh(ctx context.Context) error {
+  batcherHash, err := a.caller.BatcherHash(&bind.CallOpts{Context: ctx})
+  if err != nil {
+    return err
+  }
+  batcher := common.BytesToAddress(batcherHash[:])
+
+  a.mu.Lock()
+  defer a.mu.Unlock()
+  if batcher != a.batcher {
+    log.Info("authorized batcher updated", "batcher", batcher)
+  }
+  a.batcher = batcher
+  return nil
+}
+
+// Run refreshes the batcher address every interval until the context is done
+func (a *systemConfigAuthorizer) Run(ctx context.Context, interval time.Duration) {
+  ticker := time.NewTicker(interval)
+  defer ticker.Stop()
+
+  for {
+    select {
+    case <-ctx.Done():
+      return
+    case <-ticker.C:
+      if err := a.refresh(ctx); err != nil {
+        log.Warn("could not refresh authorized batcher", "err", err)
+      }
+    }
+  }
+}
+
+func (a *systemConfigAuthorizer) IsAuthorized(addr common.Address) bool {
+  a.mu.RLock()
+  defer a.mu.RUnlock()
+  return addr == a.batcher
+}
+
+// maxTrackedClients bounds the memory used by the rate limiter, all clients are
+// forgotten once it is reached
+const maxTrackedClients = 10_000
+
+type clientLimits struct {
+  requests *rate.Limiter
+  bytes *rate.Limiter
+}
+
+// ClientLimiter rate limits the requests and the stored bytes of each client IP.
+// A zero rate disables the corresponding limit.
+type ClientLimiter struct {
+  requestRate rate.Limit
+  requestBurst int
+  byteRate rate.Limit
+  byteBurst int
+
+  mu sync.Mutex
+  clients map[string]*clientLimits
+}
+
+func NewClientLimiter(requestRate float64, requestBurst int, byteRate float64, byteBurst int) *ClientLimiter {
+  return &ClientLimiter{
+    requestRate: rate.Limit(requestRate),
+    requestBurst: requestBurst,
+    byteRate: rate.Limit(byteRate),
+    byteBurst: byteBurst,
+    clients: map[string]*clientLimits{},
+  }
+}
+
+func (l *ClientLimiter) limits(req *http.Request) *clientLimits {
+  client, _, err := net.SplitHostPort(req.RemoteAddr)
+  if err != nil {
+    client = req.RemoteAddr
+  }
+
+  l.mu.Lock()
+  defer l.mu.Unlock()
+
+  limits, ok := l.clients[client]
+  if !ok {
+    if len(l.clients) >= maxTrackedClients {
+      l.clients = map[string]*clientLimits{}
+    }
+    limits = &clientLimits{
+      requests: rate.NewLimiter(l.requestRate, l.requestBurst),
+      bytes: rate.NewLimiter(l.byteRate, l.byteBurst),
+    }
+    l.clients[client] = limits
+  }
+  return limits
+}
+
+// AllowRequest reports whether the client may send another request now
+func (l *ClientLimiter) AllowRequest(req *http.Request) bool {
+  if l.requestRate == 0 {
+    return true
+  }
+  return l.limits(req).requests.Allow()
+}
+
+// AllowBytes reports whether the client may have n more bytes stored now
+func (l *ClientLimiter) AllowBytes(req *http.Request, n int) bool {
+  if l.byteRate == 0 {
+    return true
+  }
+  return l.limits(req).bytes.AllowN(time.Now(), n)
+}
@@ -0,0 +1,179 @@
+package member
+
+import (
+	"time"
+
+	"github.com/urfave/cli"
+
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+// storageFlags configure the storage backend, shared by the server and the sync command
+var storageFlags = []cli.Flag{
+  cli.StringFlag{
+    Name: "directory",
+    Usage: "path to directory where batches will be stored, for the file and leveldb storages",
+    EnvVar: "DIRECTORY",
+  },
+  cli.StringFlag{
+    Name: "storage",
+    Usage: "storage backend, one of: file, leveldb, s3",
+    EnvVar: "STORAGE",
+    Value: "file",
+  },
+  cli.StringFlag{
+    Name: "s3-endpoint",
+    Usage: "base URL of the S3-compatible service",
+    EnvVar: "S3_ENDPOINT",
+  },
+  cli.StringFlag{
+    Name: "s3-region",
+    Usage: "region of the S3 bucket",
+    EnvVar: "S3_REGION",
+    Value: "us-east-1",
+  },
+  cli.StringFlag{
+    Name: "s3-bucket",
+    Usage: "S3 bucket where batches will be stored",
+    EnvVar: "S3_BUCKET",
+  },
+  cli.StringFlag{
+    Name: "s3-prefix",
+    Usage: "prefix of the S3 object keys",
+    EnvVar: "S3_PREFIX",
+  },
+  cli.StringFlag{
+    Name: "s3-access-key",
+    Usage: "S3 access key id",
+    EnvVar: "S3_ACCESS_KEY",
+  },
+  cli.StringFlag{
+    Name: "s3-secret-key",
+    Usage: "S3 secret access key",
+    EnvVar: "S3_SECRET_KEY",
+  },
+}
+
+// Flags configure the member server
+var Flags = append([]cli.Flag{
+  cli.IntFlag{
+    Name: "port",
+    Usage: "port of the HTTP server",
+    EnvVar: "PORT",
+    Value: 3000,
+  },
+  cli.StringFlag{
+    Name: "private-key",
+    Usage: "32 bytes BLS private key",
+    EnvVar: "PRIVATE_KEY",
+  },
+  cli.DurationFlag{
+    Name: "retention",
+    Usage: "duration batches are kept for before being garbage collected, 0 keeps them forever",
+    EnvVar: "RETENTION",
+  },
+  cli.DurationFlag{
+    Name: "gc-interval",
+    Usage: "interval between two garbage collections of expired batches",
+    EnvVar: "GC_INTERVAL",
+    Value: time.Hour,
+  },
+  cli.StringSliceFlag{
+    Name: "batcher-addresses",
+    Usage: "addresses allowed to submit batches",
+    EnvVar: "BATCHER_ADDRESSES",
+  },
+  cli.StringFlag{
+    Name: "l1-eth-rpc",
+    Usage: "HTTP provider URL for L1, to authorize the batcher address of the SystemConfig contract",
+    EnvVar: "L1_ETH_RPC",
+  },
+  cli.StringFlag{
+    Name: "system-config-address",
+    Usage: "address of the rollup SystemConfig contract on L1",
+    EnvVar: "SYSTEM_CONFIG_ADDRESS",
+  },
+  cli.BoolFlag{
+    Name: "insecure-accept-unauthenticated",
+    Usage: "accept batches from anyone when neither batcher-addresses nor system-config-address is set",
+    EnvVar: "INSECURE_ACCEPT_UNAUTHENTICATED",
+  },
+  cli.DurationFlag{
+    Name: "system-config-refresh",
+    Usage: "interval between two reads of the SystemConfig batcher address",
+    EnvVar: "SYSTEM_CONFIG_REFRESH",
+    Value: time.Minute,
+  },
+  cli.Uint64Flag{
+    Name: "chain-id",
+    Usage: "L2 chain ID the batch signatures and batch authentications are bound to, 0 to only produce legacy signatures of unauthenticated batches",
+    EnvVar: "CHAIN_ID",
+  },
+  cli.Int64SliceFlag{
+    Name: "keyset-indexes",
+    Usage: "positions of the member key in the keysets of the erasure coded committees, the only chunk indexes the member stores",
+    EnvVar: "KEYSET_INDEXES",
+  },
+  cli.IntFlag{
+    Name: "max-batch-size",
+    Usage: "maximum size in bytes of a submitted batch, 0 for no limit",
+    EnvVar: "MAX_BATCH_SIZE",
+    Value: 1_000_000,
+  },
+  cli.Float64Flag{
+    Name: "rate-limit",
+    Usage: "maximum number of batch submissions per second and per client, 0 for no limit",
+    EnvVar: "RATE_LIMIT",
+  },
+  cli.IntFlag{
+    Name: "rate-limit-burst",
+    Usage: "maximum burst of batch submissions per client",
+    EnvVar: "RATE_LIMIT_BURST",
+    Value: 10,
+  },
+  cli.Float64Flag{
+    Name: "rate-limit-bytes",
+    Usage: "maximum number of submitted bytes per second and per client, 0 for no limit",
+    EnvVar: "RATE_LIMIT_BYTES",
+  },
+  cli.BoolFlag{
+    Name: opmetrics.EnabledFlagName,
+    Usage: "enable the metrics server",
+    EnvVar: "METRICS_ENABLED",
+  },
+  cli.StringFlag{
+    Name: opmetrics.ListenAddrFlagName,
+    Usage: "metrics listening address",
+    EnvVar: "METRICS_ADDR",
+    Value: "0.0.0.0",
+  },
+  cli.IntFlag{
+    Name: opmetrics.PortFlagName,
+    Usage: "metrics listening port",
+    EnvVar: "METRICS_PORT",
+    Value: 7300,
+  },
+  cli.DurationFlag{
+    Name: "metrics.storage-interval",
+    Usage: "interval between two counts of the stored batches",
+    EnvVar: "METRICS_STORAGE_INTERVAL",
+    Value: 5 * time.Minute,
+  },
+}, storageFlags...)
+
+// SyncFlags configure the sync command
+var SyncFlags = append([]cli.Flag{
+  cli.StringSliceFlag{
+    Name: "peers",
+    Usage: "URLs of the members to sync from",
+    EnvVar: "PEERS",
+  },
+  cli.Int64Flag{
+    Name: "from",
+    Usage: "unix timestamp of the oldest batches to sync, 0 for no bound",
+  },
+  cli.Int64Flag{
+    Name: "to",
+    Usage: "unix timestamp the synced batches must be stored before, 0 for no bound",
+  },
+}, storageFlags...)
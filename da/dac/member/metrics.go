@@ -0,0 +1,166 @@
+package member
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+
+	ophttp "github.com/ethereum-optimism/optimism/op-service/httputil"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+const Namespace = "dac_member"
+
+// Metricer records the activity of a member
+type Metricer interface {
+  // RecordRequest records a served request by route template and response code
+  RecordRequest(route string, code int, duration time.Duration)
+  RecordBatchStored(size int)
+  RecordSignature(duration time.Duration)
+  // RecordStorage records the number of stored batches
+  RecordStorage(batches int)
+}
+
+type NoopMetrics struct{}
+
+func (NoopMetrics) RecordRequest(string, int, time.Duration) {}
+func (NoopMetrics) RecordBatchStored(int)                    {}
+func (NoopMetrics) RecordSignature(time.Duration)            {}
+func (NoopMetrics) RecordStorage(int)                        {}
+
+type Metrics struct {
+  registry *prometheus.Registry
+
+  requests *prometheus.CounterVec
+  requestDuration *prometheus.HistogramVec
+  storedBatches prometheus.Counter
+  storedBytes prometheus.Counter
+  signatureDuration prometheus.Histogram
+  batches prometheus.Gauge
+}
+
+var _ Metricer = (*Metrics)(nil)
+
+func NewMetrics() *Metrics {
+  registry := opmetrics.NewRegistry()
+  factory := opmetrics.With(registry)
+  return &Metrics{
+    registry: registry,
+    requests: factory.NewCounterVec(prometheus.CounterOpts{
+      Namespace: Namespace,
+      Name: "requests_total",
+      Help: "Number of served requests, by route and response code.",
+    }, []string{"route", "code"}),
+    requestDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+      Namespace: Namespace,
+      Name: "request_seconds",
+      Help: "Duration of the served requests, by route.",
+      Buckets: prometheus.ExponentialBuckets(0.001, 2, 14),
+    }, []string{"route"}),
+    storedBatches: factory.NewCounter(prometheus.CounterOpts{
+      Namespace: Namespace,
+      Name: "stored_batches_total",
+      Help: "Number of batches stored and signed.",
+    }),
+    storedBytes: factory.NewCounter(prometheus.CounterOpts{
+      Namespace: Namespace,
+      Name: "stored_bytes_total",
+      Help: "Total size of the batches stored and signed.",
+    }),
+    signatureDuration: factory.NewHistogram(prometheus.HistogramOpts{
+      Namespace: Namespace,
+      Name: "signature_seconds",
+      Help: "Duration of the signature of a batch.",
+      Buckets: prometheus.ExponentialBuckets(0.0005, 2, 12),
+    }),
+    batches: factory.NewGauge(prometheus.GaugeOpts{
+      Namespace: Namespace,
+      Name: "batches",
+      Help: "Number of batches in the storage, as of the last storage scan.",
+    }),
+  }
+}
+
+func (m *Metrics) Serve(ctx context.Context, host string, port int) error {
+  return opmetrics.ListenAndServe(ctx, m.registry, host, port)
+}
+
+func (m *Metrics) RecordRequest(route string, code int, duration time.Duration) {
+  m.requests.WithLabelValues(route, strconv.Itoa(code)).Inc()
+  m.requestDuration.WithLabelValues(route).Observe(duration.Seconds())
+}
+
+func (m *Metrics) RecordBatchStored(size int) {
+  m.storedBatches.Inc()
+  m.storedBytes.Add(float64(size))
+}
+
+func (m *Metrics) RecordSignature(duration time.Duration) {
+  m.signatureDuration.Observe(duration.Seconds())
+}
+
+func (m *Metrics) RecordStorage(batches int) {
+  m.batches.Set(float64(batches))
+}
+
+// flushingResponseWriter records the response status, and keeps the streamed responses flushable
+type flushingResponseWriter struct {
+  *ophttp.WrappedResponseWriter
+  w http.ResponseWriter
+}
+
+func (w flushingResponseWriter) Flush() {
+  if flusher, ok := w.w.(http.Flusher); ok {
+    flusher.Flush()
+  }
+}
+
+// meterRequests is a router middleware recording the requests by route template, so that
+// the batch hashes do not label the metrics
+func meterRequests(metrics Metricer) mux.MiddlewareFunc {
+  return func(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+      start := time.Now()
+      wrapped := ophttp.NewWrappedResponseWriter(w)
+      next.ServeHTTP(flushingResponseWriter{wrapped, w}, req)
+
+      route := "unknown"
+      if current := mux.CurrentRoute(req); current != nil {
+        if template, err := current.GetPathTemplate(); err == nil {
+          route = template
+        }
+      }
+      metrics.RecordRequest(route, wrapped.StatusCode, time.Since(start))
+    })
+  }
+}
+
+// runStorageMetrics counts the stored batches every interval until the context is done
+func runStorageMetrics(ctx context.Context, storage PrunableStorage, metrics Metricer, interval time.Duration) {
+  ticker := time.NewTicker(interval)
+  defer ticker.Stop()
+
+  for {
+    batches := 0
+    err := storage.List(func(string, time.Time) error {
+      batches++
+      return nil
+    })
+    if err != nil {
+      log.Warn("could not count stored batches", "err", err)
+    } else {
+      metrics.RecordStorage(batches)
+    }
+
+    select {
+    case <-ctx.Done():
+      return
+    case <-ticker.C:
+    }
+  }
+}
@@ -0,0 +1,412 @@
+package member
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/da/dac"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/gorilla/mux"
+)
+
+// Server serves the HTTP api of a DAC member: it stores the submitted batches and signs
+// their hash
+type Server struct {
+  storage PrunableStorage
+  signer dac.Signer
+  // just so we don't recompute it too often
+  publicKey dac.PublicKey
+  proofOfPossession dac.Signature
+
+  // authorizes the batch signers, nil accepts unauthenticated batches
+  authorizer Authorizer
+  limiter *ClientLimiter
+  // maximum size of a decoded batch, 0 for no limit
+  maxBatchSize int
+  // L2 chain ID of the SchemeV1 signatures, nil to only sign legacy signatures
+  chainID *big.Int
+  // positions of the member in the keysets of the erasure coded committees, the only chunk
+  // indexes it stores and signs
+  keysetIndexes []uint64
+  metrics Metricer
+}
+
+func NewServer(storage PrunableStorage, privateKey string, authorizer Authorizer, limiter *ClientLimiter, maxBatchSize int, chainID *big.Int, keysetIndexes []uint64, m Metricer) (*Server, error) {
+  signer, err := dac.NewSigner(privateKey)
+  if err != nil {
+    return nil, fmt.Errorf("could not instanciate the signer: %w", err)
+  }
+  proofOfPossession, err := signer.ProofOfPossession()
+  if err != nil {
+    return nil, fmt.Errorf("could not create the proof of possession: %w", err)
+  }
+  return &Server{
+    storage: storage,
+    signer: signer,
+    publicKey: signer.GetPublicKey(),
+    proofOfPossession: proofOfPossession,
+    authorizer: authorizer,
+    limiter: limiter,
+    maxBatchSize: maxBatchSize,
+    chainID: chainID,
+    keysetIndexes: keysetIndexes,
+    metrics: m,
+  }, nil
+}
+
+// PublicKey is the BLS public key of the member, verifying its signatures
+func (m *Server) PublicKey() dac.PublicKey {
+  return m.publicKey
+}
+
+// ProofOfPossession proves the ownership of the private key of the member
+func (m *Server) ProofOfPossession() dac.Signature {
+  return m.proofOfPossession
+}
+
+// Router routes the requests of the member HTTP api
+func (m *Server) Router() *mux.Router {
+  r := mux.NewRouter()
+  r.HandleFunc("/batch", m.handlePost).Methods("POST")
+  r.HandleFunc("/batch/{dataHash}", m.handleGet).Methods("GET")
+  r.HandleFunc("/chunk", m.handlePostChunk).Methods("POST")
+  r.HandleFunc("/chunk/{root}", m.handleGetChunk).Methods("GET")
+  r.HandleFunc("/batches", m.handleList).Methods("GET")
+  r.HandleFunc("/batches/stream", m.handleStream).Methods("GET")
+  r.HandleFunc("/proof_of_possession", m.handleProofOfPossession).Methods("GET")
+  r.HandleFunc("/healthz", m.handleHealth).Methods("GET")
+  r.Use(meterRequests(m.metrics))
+  return r
+}
+
+// healthProbeId is the id of a batch that is never stored, its data would have to hash to zero
+var healthProbeId = hex.EncodeToString(make([]byte, 32))
+
+// handleHealth reports the member as healthy while its storage can be read
+func (m *Server) handleHealth(w http.ResponseWriter, req *http.Request) {
+  type response struct {
+    Status string `json:"status"`
+    Error string `json:"error,omitempty"`
+  }
+
+  w.Header().Set("Content-Type", "application/json")
+  data, err := m.storage.Fetch(healthProbeId)
+  if err == nil {
+    data.Close()
+  } else if err != ErrNotFound {
+    log.Warn("storage is unhealthy", "err", err)
+    w.WriteHeader(http.StatusServiceUnavailable)
+    json.NewEncoder(w).Encode(response{Status: "unhealthy", Error: err.Error()})
+    return
+  }
+  json.NewEncoder(w).Encode(response{Status: "ok"})
+}
+
+// handleProofOfPossession serves the proof that the member owns the private key of its
+// public key, required to register the key in a committee keyset
+func (m *Server) handleProofOfPossession(w http.ResponseWriter, req *http.Request) {
+  type response struct {
+    PublicKey string `json:"public_key"`
+    ProofOfPossession string `json:"proof_of_possession"`
+  }
+
+  w.Header().Set("Content-Type", "application/json")
+  json.NewEncoder(w).Encode(response{
+    PublicKey: hex.EncodeToString(m.publicKey.ToBytes()),
+    ProofOfPossession: hex.EncodeToString(m.proofOfPossession.ToBytes()),
+  })
+}
+
+func (m *Server) handleGet(w http.ResponseWriter, req *http.Request) {
+  dataHash := mux.Vars(req)["dataHash"]
+
+  log.Info("retrieving batch", "data_hash", dataHash)
+
+  data, err := m.storage.Fetch(dataHash)
+  if err != nil {
+    if err == ErrNotFound {
+      w.WriteHeader(http.StatusNotFound)
+      return
+    }
+    log.Warn("could not fetch batch", "err", err, "data_hash", dataHash)
+    w.WriteHeader(http.StatusInternalServerError)
+    return
+  }
+  defer data.Close()
+
+  // streams {"data": "<hex>"}, the format expected by dac clients
+  w.Header().Set("Content-Type", "application/json")
+  io.WriteString(w, `{"data":"`)
+  if written, err := io.Copy(hex.NewEncoder(w), data); err != nil {
+    log.Warn("could not write batch", "err", err, "written", written)
+    return
+  }
+  io.WriteString(w, `"}`)
+}
+
+// supportsScheme reports whether the member can sign with the signature scheme
+func (m *Server) supportsScheme(version dac.SchemeVersion) bool {
+  return version == dac.SchemeLegacy || (version == dac.SchemeV1 && m.chainID != nil)
+}
+
+// isAuthorized checks the hex encoded batch authentication signature of the message, every
+// message is authorized when no authorizer is set
+func (m *Server) isAuthorized(message []byte, signatureHex string) bool {
+  if m.authorizer == nil {
+    return true
+  }
+  signature, err := hex.DecodeString(signatureHex)
+  if err != nil {
+    log.Info("batch authentication signature is not valid hex", "message", hex.EncodeToString(message))
+    return false
+  }
+  signer, err := dac.RecoverBatchSigner(m.chainID, message, signature)
+  if err != nil || !m.authorizer.IsAuthorized(signer) {
+    log.Info("unauthorized batch", "err", err, "signer", signer, "message", hex.EncodeToString(message))
+    return false
+  }
+  return true
+}
+
+// holdsIndex reports whether the member stores the chunks at the given index
+func (m *Server) holdsIndex(index uint64) bool {
+  for _, i := range m.keysetIndexes {
+    if i == index {
+      return true
+    }
+  }
+  return false
+}
+
+// sign signs the message in the domain of the signature scheme
+func (m *Server) sign(domain dac.Domain, message []byte) (dac.Signature, error) {
+  start := time.Now()
+  signature, err := m.signer.Sign(domain, message)
+  if err != nil {
+    return dac.Signature{}, err
+  }
+  m.metrics.RecordSignature(time.Since(start))
+  return signature, nil
+}
+
+func (m *Server) handlePost(w http.ResponseWriter, req *http.Request) {
+  defer req.Body.Close()
+
+  if !m.limiter.AllowRequest(req) {
+    log.Info("client is rate limited", "remote_addr", req.RemoteAddr)
+    w.WriteHeader(http.StatusTooManyRequests)
+    return
+  }
+
+  body := io.Reader(req.Body)
+  if m.maxBatchSize > 0 {
+    // hex encoding doubles the size, leave room for the signature and the JSON structure
+    body = http.MaxBytesReader(w, req.Body, int64(2 * m.maxBatchSize + 1024))
+  }
+
+  payload := &dac.BatchPayload{}
+  if err := json.NewDecoder(body).Decode(payload); err != nil || len(payload.Data) == 0 {
+    var maxBytesErr *http.MaxBytesError
+    if errors.As(err, &maxBytesErr) {
+      log.Info("payload is too large", "limit", maxBytesErr.Limit)
+      w.WriteHeader(http.StatusRequestEntityTooLarge)
+      return
+    }
+    log.Info("payload is not valid json", "encoded_data_len", len(payload.Data))
+    w.WriteHeader(http.StatusBadRequest)
+    return
+  }
+
+  data, err := hex.DecodeString(payload.Data)
+  if err != nil {
+    log.Info("batch is not valid hex", "encoded_data_len", len(payload.Data))
+    w.WriteHeader(http.StatusBadRequest)
+    return
+  }
+  if m.maxBatchSize > 0 && len(data) > m.maxBatchSize {
+    log.Info("batch is too large", "data_len", len(data), "limit", m.maxBatchSize)
+    w.WriteHeader(http.StatusRequestEntityTooLarge)
+    return
+  }
+
+  if !m.supportsScheme(payload.Version) {
+    log.Info("unsupported signature scheme", "version", payload.Version)
+    w.WriteHeader(http.StatusBadRequest)
+    return
+  }
+
+  dataHash := crypto.Keccak256(data)
+  dataHashHex := hex.EncodeToString(dataHash)
+
+  if !m.isAuthorized(dataHash, payload.Signature) {
+    w.WriteHeader(http.StatusUnauthorized)
+    return
+  }
+
+  if !m.limiter.AllowBytes(req, len(data)) {
+    log.Info("client is rate limited", "remote_addr", req.RemoteAddr, "data_len", len(data))
+    w.WriteHeader(http.StatusTooManyRequests)
+    return
+  }
+  if err := m.storage.Store(dataHashHex, bytes.NewReader(data)); err != nil {
+    log.Error("could not store batch", "err", err, "data_len", len(data))
+    w.WriteHeader(http.StatusInternalServerError)
+    return
+  }
+  m.metrics.RecordBatchStored(len(data))
+
+  signature, err := m.sign(dac.BatchDomain(payload.Version, m.chainID), dataHash)
+  if err != nil {
+    log.Error("could not sign batch", "err", err)
+    w.WriteHeader(http.StatusInternalServerError)
+    return
+  }
+
+  type response struct {
+    DataHash string `json:"data_hash"`
+    Signature string `json:"signature"`
+    // PublicKey is purely informational. It should not serve as truth, it is just a convenience
+    // for the aggregator to build a bitmap. The aggregator has to check a proof of ownership of
+    // the private keys
+    PublicKey string `json:"public_key"` 
+  }
+
+  json.NewEncoder(w).Encode(response{
+    DataHash: dataHashHex,
+    Signature: hex.EncodeToString(signature.ToBytes()),
+    PublicKey: hex.EncodeToString(m.publicKey.ToBytes()),
+  })
+}
+
+
+// handleGetChunk serves the chunk stored under a chunk commitment root
+func (m *Server) handleGetChunk(w http.ResponseWriter, req *http.Request) {
+  root := mux.Vars(req)["root"]
+  id := chunkIdPrefix + root
+
+  data, err := m.storage.Fetch(id)
+  if err != nil {
+    if err == ErrNotFound {
+      w.WriteHeader(http.StatusNotFound)
+      return
+    }
+    log.Warn("could not fetch chunk", "err", err, "root", root)
+    w.WriteHeader(http.StatusInternalServerError)
+    return
+  }
+  defer data.Close()
+
+  // chunks are stored in the format expected by dac clients
+  w.Header().Set("Content-Type", "application/json")
+  if written, err := io.Copy(w, data); err != nil {
+    log.Warn("could not write chunk", "err", err, "written", written)
+  }
+}
+
+// handlePostChunk stores the chunk of an erasure coded batch and signs its chunk commitment
+// root along with its index, which must be the position of the member in the keyset. The member
+// cannot check the encoding of the batch from a single chunk, only that the chunk is included in
+// the root: readers reconstructing the batch of an invalid encoding treat its ref as invalid and
+// skip it.
+func (m *Server) handlePostChunk(w http.ResponseWriter, req *http.Request) {
+  defer req.Body.Close()
+
+  if !m.limiter.AllowRequest(req) {
+    log.Info("client is rate limited", "remote_addr", req.RemoteAddr)
+    w.WriteHeader(http.StatusTooManyRequests)
+    return
+  }
+
+  body := io.Reader(req.Body)
+  if m.maxBatchSize > 0 {
+    // a chunk is at most as large as the batch, leave room for the proof
+    body = http.MaxBytesReader(w, req.Body, int64(2 * m.maxBatchSize + 8192))
+  }
+
+  payload := &dac.ChunkPayload{}
+  if err := json.NewDecoder(body).Decode(payload); err != nil {
+    var maxBytesErr *http.MaxBytesError
+    if errors.As(err, &maxBytesErr) {
+      log.Info("payload is too large", "limit", maxBytesErr.Limit)
+      w.WriteHeader(http.StatusRequestEntityTooLarge)
+      return
+    }
+    log.Info("payload is not valid json", "err", err)
+    w.WriteHeader(http.StatusBadRequest)
+    return
+  }
+  if m.maxBatchSize > 0 && len(payload.Data) > m.maxBatchSize {
+    log.Info("chunk is too large", "data_len", len(payload.Data), "limit", m.maxBatchSize)
+    w.WriteHeader(http.StatusRequestEntityTooLarge)
+    return
+  }
+  if !m.supportsScheme(payload.Version) {
+    log.Info("unsupported signature scheme", "version", payload.Version)
+    w.WriteHeader(http.StatusBadRequest)
+    return
+  }
+  if !m.holdsIndex(payload.Index) {
+    // a certificate proves that the signers store distinct chunks
+    log.Info("chunk is not at the keyset position of the member", "root", payload.Root, "index", payload.Index)
+    w.WriteHeader(http.StatusBadRequest)
+    return
+  }
+  if !payload.Chunk.Verify(payload.Root) {
+    log.Info("chunk is not included in the root", "root", payload.Root, "index", payload.Index)
+    w.WriteHeader(http.StatusBadRequest)
+    return
+  }
+
+  if !m.isAuthorized(payload.Root.Bytes(), payload.Signature) {
+    w.WriteHeader(http.StatusUnauthorized)
+    return
+  }
+
+  encoded, err := json.Marshal(payload.Chunk)
+  if err != nil {
+    log.Error("could not encode chunk", "err", err)
+    w.WriteHeader(http.StatusInternalServerError)
+    return
+  }
+  if !m.limiter.AllowBytes(req, len(payload.Data)) {
+    log.Info("client is rate limited", "remote_addr", req.RemoteAddr, "data_len", len(payload.Data))
+    w.WriteHeader(http.StatusTooManyRequests)
+    return
+  }
+  if err := m.storage.Store(chunkId(payload.Root), bytes.NewReader(encoded)); err != nil {
+    log.Error("could not store chunk", "err", err, "data_len", len(payload.Data))
+    w.WriteHeader(http.StatusInternalServerError)
+    return
+  }
+  m.metrics.RecordBatchStored(len(payload.Data))
+
+  signature, err := m.sign(dac.ChunkDomain(payload.Version, m.chainID), dac.ChunkMessage(payload.Root, payload.Index))
+  if err != nil {
+    log.Error("could not sign chunk", "err", err)
+    w.WriteHeader(http.StatusInternalServerError)
+    return
+  }
+
+  type response struct {
+    Root common.Hash `json:"root"`
+    Index uint64 `json:"index"`
+    Signature string `json:"signature"`
+    PublicKey string `json:"public_key"`
+  }
+
+  json.NewEncoder(w).Encode(response{
+    Root: payload.Root,
+    Index: payload.Index,
+    Signature: hex.EncodeToString(signature.ToBytes()),
+    PublicKey: hex.EncodeToString(m.publicKey.ToBytes()),
+  })
+}
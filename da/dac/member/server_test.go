@@ -0,0 +1,247 @@
+package member
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/da/dac"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const testMemberKey = "0x39bfcae8591588ef01774d3a5003d3a5b5c95a00b2142b20b217eedaeb124f63"
+
+func postBatch(m *Server, payload dac.BatchPayload) int {
+  return postBatchResponse(m, payload).Code
+}
+
+func postBatchResponse(m *Server, payload dac.BatchPayload) *httptest.ResponseRecorder {
+  body, _ := json.Marshal(payload)
+  req := httptest.NewRequest(http.MethodPost, "/batch", bytes.NewReader(body))
+  rec := httptest.NewRecorder()
+  m.handlePost(rec, req)
+  return rec
+}
+
+func authenticatedPayload(t *testing.T, key *ecdsa.PrivateKey, chainID *big.Int, data []byte) dac.BatchPayload {
+  signature, err := dac.PrivateKeyBatchAuth(key, chainID)(crypto.Keccak256(data))
+  if err != nil {
+    t.Fatal(err)
+  }
+  return dac.BatchPayload{Data: hex.EncodeToString(data), Signature: hex.EncodeToString(signature)}
+}
+
+func TestHandlePostAuthentication(t *testing.T) {
+  data := []byte("some batch data")
+  chainID := big.NewInt(901)
+  batcherKey, _ := crypto.GenerateKey()
+  otherKey, _ := crypto.GenerateKey()
+  batcher := authenticatedPayload(t, batcherKey, chainID, data)
+  other := authenticatedPayload(t, otherKey, chainID, data)
+
+  signature, _ := hex.DecodeString(batcher.Signature)
+  batcherAddr, err := dac.RecoverBatchSigner(chainID, crypto.Keccak256(data), signature)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if batcherAddr != crypto.PubkeyToAddress(batcherKey.PublicKey) {
+    t.Fatalf("expected batcher %v, recovered %v", crypto.PubkeyToAddress(batcherKey.PublicKey), batcherAddr)
+  }
+
+  // a signature of the batcher key over the bare data hash, as any other protocol could
+  // produce, is not a batch authentication
+  bareSignature, err := crypto.Sign(crypto.Keccak256(data), batcherKey)
+  if err != nil {
+    t.Fatal(err)
+  }
+  bare := dac.BatchPayload{Data: hex.EncodeToString(data), Signature: hex.EncodeToString(bareSignature)}
+
+  m, err := NewServer(NewFileStorage(t.TempDir()), testMemberKey, NewAllowlist([]string{batcherAddr.Hex()}), NewClientLimiter(0, 0, 0, 0), 100, chainID, nil, NoopMetrics{})
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  cases := map[string]struct {
+    payload dac.BatchPayload
+    code int
+  }{
+    "unauthenticated": {dac.BatchPayload{Data: hex.EncodeToString(data)}, http.StatusUnauthorized},
+    "unauthorized signer": {other, http.StatusUnauthorized},
+    "bare digest signature": {bare, http.StatusUnauthorized},
+    "other chain": {authenticatedPayload(t, batcherKey, big.NewInt(902), data), http.StatusUnauthorized},
+    "authorized signer": {batcher, http.StatusOK},
+    "too large": {authenticatedPayload(t, batcherKey, chainID, make([]byte, 101)), http.StatusRequestEntityTooLarge},
+    "too large body": {dac.BatchPayload{Data: hex.EncodeToString(make([]byte, 1000))}, http.StatusRequestEntityTooLarge},
+  }
+  for name, c := range cases {
+    if code := postBatch(m, c.payload); code != c.code {
+      t.Errorf("%v: expected status %v, got %v", name, c.code, code)
+    }
+  }
+}
+
+func TestHandlePostRateLimit(t *testing.T) {
+  data := []byte("some batch data")
+  payload := dac.BatchPayload{Data: hex.EncodeToString(data)}
+
+  m, err := NewServer(NewFileStorage(t.TempDir()), testMemberKey, nil, NewClientLimiter(0.001, 2, 0, 0), 0, nil, nil, NoopMetrics{})
+  if err != nil {
+    t.Fatal(err)
+  }
+  for i, code := range []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests} {
+    if got := postBatch(m, payload); got != code {
+      t.Errorf("request %v: expected status %v, got %v", i, code, got)
+    }
+  }
+
+  m.limiter = NewClientLimiter(0, 0, 0.001, 2 * len(data))
+  for i, code := range []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests} {
+    if got := postBatch(m, payload); got != code {
+      t.Errorf("bytes request %v: expected status %v, got %v", i, code, got)
+    }
+  }
+}
+
+func TestHandlePostScheme(t *testing.T) {
+  data := []byte("some batch data")
+  chainID := big.NewInt(901)
+
+  m, err := NewServer(NewFileStorage(t.TempDir()), testMemberKey, nil, NewClientLimiter(0, 0, 0, 0), 0, chainID, nil, NoopMetrics{})
+  if err != nil {
+    t.Fatal(err)
+  }
+  for _, version := range []dac.SchemeVersion{dac.SchemeLegacy, dac.SchemeV1} {
+    rec := postBatchResponse(m, dac.BatchPayload{Data: hex.EncodeToString(data), Version: version})
+    if rec.Code != http.StatusOK {
+      t.Fatalf("version %v: expected status %v, got %v", version, http.StatusOK, rec.Code)
+    }
+    var response struct {
+      Signature string `json:"signature"`
+    }
+    json.NewDecoder(rec.Body).Decode(&response)
+    signature, _ := hex.DecodeString(response.Signature)
+    isValid, err := m.publicKey.VerifyMessage(dac.BatchDomain(version, chainID), crypto.Keccak256(data), signature)
+    if err != nil || !isValid {
+      t.Errorf("version %v: invalid signature: %v", version, err)
+    }
+  }
+
+  if code := postBatch(m, dac.BatchPayload{Data: hex.EncodeToString(data), Version: dac.SchemeV1 + 1}); code != http.StatusBadRequest {
+    t.Errorf("unknown version: expected status %v, got %v", http.StatusBadRequest, code)
+  }
+  m.chainID = nil
+  if code := postBatch(m, dac.BatchPayload{Data: hex.EncodeToString(data), Version: dac.SchemeV1}); code != http.StatusBadRequest {
+    t.Errorf("no chain ID: expected status %v, got %v", http.StatusBadRequest, code)
+  }
+}
+
+type recordingMetrics struct {
+  NoopMetrics
+  requests map[string]int
+}
+
+func (m *recordingMetrics) RecordRequest(route string, code int, _ time.Duration) {
+  m.requests[route + " " + strconv.Itoa(code)]++
+}
+
+func TestHealthAndRequestMetrics(t *testing.T) {
+  metrics := &recordingMetrics{requests: make(map[string]int)}
+  m, err := NewServer(NewFileStorage(t.TempDir()), testMemberKey, nil, NewClientLimiter(0, 0, 0, 0), 0, nil, nil, metrics)
+  if err != nil {
+    t.Fatal(err)
+  }
+  router := m.Router()
+
+  for path, code := range map[string]int{
+    "/healthz": http.StatusOK,
+    "/batch/" + hex.EncodeToString(crypto.Keccak256([]byte("missing"))): http.StatusNotFound,
+  } {
+    rec := httptest.NewRecorder()
+    router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+    if rec.Code != code {
+      t.Errorf("%v: expected status %v, got %v", path, code, rec.Code)
+    }
+  }
+
+  for _, key := range []string{"/healthz 200", "/batch/{dataHash} 404"} {
+    if metrics.requests[key] != 1 {
+      t.Errorf("expected one request recorded as %v, got %v", key, metrics.requests)
+    }
+  }
+}
+
+func TestHandleChunk(t *testing.T) {
+  data := []byte("some batch data")
+  root, chunks, err := dac.EncodeChunks(data, 2, 3)
+  if err != nil {
+    t.Fatal(err)
+  }
+  storage := NewVerifiedStorage(NewFileStorage(t.TempDir()), 0)
+  m, err := NewServer(storage, testMemberKey, nil, NewClientLimiter(0, 0, 0, 0), 100, nil, []uint64{1}, NoopMetrics{})
+  if err != nil {
+    t.Fatal(err)
+  }
+  srv := httptest.NewServer(m.Router())
+  defer srv.Close()
+
+  post := func(payload dac.ChunkPayload) *http.Response {
+    body, _ := json.Marshal(payload)
+    resp, err := http.Post(srv.URL + "/chunk", "application/json", bytes.NewReader(body))
+    if err != nil {
+      t.Fatal(err)
+    }
+    return resp
+  }
+
+  tampered := chunks[0]
+  tampered.Index = 1
+  if resp := post(dac.ChunkPayload{Chunk: tampered, Root: root}); resp.StatusCode != http.StatusBadRequest {
+    t.Errorf("chunk not in root: expected status %v, got %v", http.StatusBadRequest, resp.StatusCode)
+  }
+
+  if resp := post(dac.ChunkPayload{Chunk: chunks[0], Root: root}); resp.StatusCode != http.StatusBadRequest {
+    t.Errorf("chunk of another member: expected status %v, got %v", http.StatusBadRequest, resp.StatusCode)
+  }
+
+  resp := post(dac.ChunkPayload{Chunk: chunks[1], Root: root})
+  if resp.StatusCode != http.StatusOK {
+    t.Fatalf("expected status %v, got %v", http.StatusOK, resp.StatusCode)
+  }
+  var response struct {
+    Index uint64 `json:"index"`
+    Signature string `json:"signature"`
+  }
+  json.NewDecoder(resp.Body).Decode(&response)
+  if response.Index != 1 {
+    t.Errorf("expected the chunk index 1, got %v", response.Index)
+  }
+  signature, _ := hex.DecodeString(response.Signature)
+  isValid, err := m.publicKey.VerifyMessage(dac.ChunkDomain(dac.SchemeLegacy, nil), dac.ChunkMessage(root, 1), signature)
+  if err != nil || !isValid {
+    t.Errorf("the chunk commitment root must be signed along with the chunk index: %v", err)
+  }
+
+  baseUrl, _ := url.Parse(srv.URL)
+  chunk, err := dac.FetchChunk(context.Background(), http.DefaultClient, baseUrl, root, 3)
+  if err != nil {
+    t.Fatalf("fetch chunk: got an error: %v", err)
+  }
+  if chunk.Index != 1 || !bytes.Equal(chunk.Data, chunks[1].Data) {
+    t.Errorf("fetch chunk: got chunk %v %x, want chunk 1 %x", chunk.Index, chunk.Data, chunks[1].Data)
+  }
+
+  // chunks are not synced between members
+  batches, _, err := listBatches(storage, rangeQuery{})
+  if err != nil || len(batches) != 0 {
+    t.Errorf("expected no listed batch, got %v %v", batches, err)
+  }
+}
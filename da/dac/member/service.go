@@ -0,0 +1,159 @@
+package member
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+
+	"github.com/urfave/cli"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/da/dac"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+func newStorage(ctx *cli.Context) (PrunableStorage, error) {
+  switch kind := ctx.String("storage"); kind {
+  case "file":
+    return NewFileStorage(ctx.String("directory")), nil
+  case "leveldb":
+    return newLevelDBStorage(ctx.String("directory"))
+  case "s3":
+    return newS3Storage(S3Config{
+      Endpoint: ctx.String("s3-endpoint"),
+      Region: ctx.String("s3-region"),
+      Bucket: ctx.String("s3-bucket"),
+      Prefix: ctx.String("s3-prefix"),
+      AccessKey: ctx.String("s3-access-key"),
+      SecretKey: ctx.String("s3-secret-key"),
+    })
+  default:
+    return nil, fmt.Errorf("unknown storage %q", kind)
+  }
+}
+
+// newAuthorizer creates the batch authorizer from the static allowlist and the
+// SystemConfig batcher address. It returns nil if neither is configured.
+func newAuthorizer(ctx *cli.Context) (Authorizer, error) {
+  if addrs := ctx.StringSlice("batcher-addresses"); len(addrs) > 0 {
+    return NewAllowlist(addrs), nil
+  }
+  if ctx.String("system-config-address") == "" {
+    return nil, nil
+  }
+
+  client, err := ethclient.Dial(ctx.String("l1-eth-rpc"))
+  if err != nil {
+    return nil, fmt.Errorf("could not dial L1: %w", err)
+  }
+  authorizer, err := newSystemConfigAuthorizer(context.Background(), client, common.HexToAddress(ctx.String("system-config-address")))
+  if err != nil {
+    return nil, fmt.Errorf("could not read SystemConfig batcher: %w", err)
+  }
+  go authorizer.Run(context.Background(), ctx.Duration("system-config-refresh"))
+  return authorizer, nil
+}
+
+// Main runs the member server until it fails
+func Main(ctx *cli.Context) error {
+  port := ctx.Int("port")
+
+  backend, err := newStorage(ctx)
+  if err != nil {
+    return fmt.Errorf("could not create storage: %w", err)
+  }
+  storage := NewVerifiedStorage(backend, ctx.Duration("retention"))
+  go storage.RunGC(context.Background(), ctx.Duration("gc-interval"))
+
+  authorizer, err := newAuthorizer(ctx)
+  if err != nil {
+    return err
+  }
+  if authorizer == nil {
+    // a member signing the batches of anyone lets anyone certify data on the chain
+    if !ctx.Bool("insecure-accept-unauthenticated") {
+      return errors.New("no batcher to authenticate batch submissions: set batcher-addresses or system-config-address")
+    }
+    log.Warn("batch submissions are not authenticated")
+  }
+
+  maxBatchSize := ctx.Int("max-batch-size")
+  // a client must always be able to submit a batch of the maximum size
+  byteBurst := maxBatchSize
+  if byteBurst == 0 {
+    byteBurst = math.MaxInt32
+  }
+  limiter := NewClientLimiter(ctx.Float64("rate-limit"), ctx.Int("rate-limit-burst"), ctx.Float64("rate-limit-bytes"), byteBurst)
+
+  var chainID *big.Int
+  if id := ctx.Uint64("chain-id"); id != 0 {
+    chainID = new(big.Int).SetUint64(id)
+  } else if authorizer != nil {
+    // batch authentication signatures are bound to the chain
+    return errors.New("chain-id is required to authenticate batch submissions")
+  } else {
+    log.Warn("no chain ID, only legacy batch signatures are supported")
+  }
+
+  var keysetIndexes []uint64
+  for _, index := range ctx.Int64Slice("keyset-indexes") {
+    if index < 0 || index >= dac.MaxKeySetSize {
+      return fmt.Errorf("invalid keyset index %v", index)
+    }
+    keysetIndexes = append(keysetIndexes, uint64(index))
+  }
+
+  var metrics Metricer = NoopMetrics{}
+  if metricsCfg := opmetrics.ReadLocalCLIConfig(ctx); metricsCfg.Enabled {
+    if err := metricsCfg.Check(); err != nil {
+      return err
+    }
+    m := NewMetrics()
+    metrics = m
+    log.Info("starting metrics server", "addr", metricsCfg.ListenAddr, "port", metricsCfg.ListenPort)
+    go func() {
+      if err := m.Serve(context.Background(), metricsCfg.ListenAddr, metricsCfg.ListenPort); err != nil {
+        log.Error("metrics server failed", "err", err)
+      }
+    }()
+    go runStorageMetrics(context.Background(), storage, m, ctx.Duration("metrics.storage-interval"))
+  }
+
+  server, err := NewServer(storage, ctx.String("private-key"), authorizer, limiter, maxBatchSize, chainID, keysetIndexes, metrics)
+  if err != nil {
+    return err
+  }
+
+  srv := &http.Server{
+    Addr:    fmt.Sprintf(":%v", port),
+    Handler: server.Router(),
+  }
+
+  log.Info("HTTP server start", "port", port, "public_key", hex.EncodeToString(server.PublicKey().ToBytes()))
+  return srv.ListenAndServe()
+}
+
+// Sync backfills the storage with the batches of the peers
+func Sync(ctx *cli.Context) error {
+  peers := ctx.StringSlice("peers")
+  if len(peers) == 0 {
+    return fmt.Errorf("no peers to sync from")
+  }
+  backend, err := newStorage(ctx)
+  if err != nil {
+    return fmt.Errorf("could not create storage: %w", err)
+  }
+  storage := NewVerifiedStorage(backend, 0)
+
+  q := rangeQuery{from: ctx.Int64("from"), to: ctx.Int64("to")}
+  stats, err := syncFromPeers(context.Background(), &http.Client{}, peers, storage, q)
+  log.Info("sync done", "stored", stats.stored, "skipped", stats.skipped, "invalid", stats.invalid)
+  return err
+}
@@ -0,0 +1,109 @@
+package member
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type Storage interface {
+  Store(id string, r io.Reader) error
+  Fetch(id string) (io.ReadCloser, error)
+}
+
+// PrunableStorage is a Storage whose entries can be enumerated and deleted
+type PrunableStorage interface {
+  Storage
+  Delete(id string) error
+  // List calls fn with every stored id and the time it was stored at, stopping at the first error
+  List(fn func(id string, storedAt time.Time) error) error
+}
+
+var ErrNotFound = errors.New("id not found")
+
+const tmpFilePrefix = ".tmp-"
+
+// FileStorage stores each batch in a file of a directory, named after its id
+type FileStorage struct {
+  Directory string
+}
+
+func NewFileStorage(dir string) FileStorage {
+  if dir == "" {
+    // so that temporary files are created next to the batches
+    dir = "."
+  }
+  return FileStorage{dir}
+}
+
+func (s FileStorage) computePath(id string) string {
+  return filepath.Join(s.Directory, id)
+}
+
+// Store writes to a temporary file first and renames it once synced, so that
+// a crash never leaves a partially written batch behind
+func (s FileStorage) Store(id string, r io.Reader) error {
+  file, err := os.CreateTemp(s.Directory, tmpFilePrefix + id)
+  if err != nil {
+    return err
+  }
+  defer os.Remove(file.Name())
+  defer file.Close()
+
+  if _, err := io.Copy(file, r); err != nil {
+    return err
+  }
+  if err := file.Sync(); err != nil {
+    return err
+  }
+  if err := file.Close(); err != nil {
+    return err
+  }
+  return os.Rename(file.Name(), s.computePath(id))
+}
+
+func (s FileStorage) Fetch(id string) (io.ReadCloser, error) {
+  path := s.computePath(id)
+  file, err := os.Open(path)
+  if err != nil {
+    if errors.Is(err, os.ErrNotExist) {
+      return nil, ErrNotFound
+    }
+    return nil, err
+  }
+
+  return file, nil
+}
+
+func (s FileStorage) Delete(id string) error {
+  err := os.Remove(s.computePath(id))
+  if errors.Is(err, os.ErrNotExist) {
+    return ErrNotFound
+  }
+  return err
+}
+
+func (s FileStorage) List(fn func(id string, storedAt time.Time) error) error {
+  entries, err := os.ReadDir(s.Directory)
+  if err != nil {
+    return err
+  }
+  for _, entry := range entries {
+    if entry.IsDir() || strings.HasPrefix(entry.Name(), tmpFilePrefix) {
+      continue
+    }
+    info, err := entry.Info()
+    if errors.Is(err, os.ErrNotExist) {
+      continue // deleted in the meantime
+    } else if err != nil {
+      return err
+    }
+    if err := fn(entry.Name(), info.ModTime()); err != nil {
+      return err
+    }
+  }
+  return nil
+}
@@ -0,0 +1,98 @@
+package member
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+)
+
+const (
+  // batch data is stored under batchPrefix + id
+  batchPrefix = "b"
+  // storage unix time is stored under storedAtPrefix + id
+  storedAtPrefix = "t"
+)
+
+func batchKey(id string) []byte {
+  return []byte(batchPrefix + id)
+}
+
+func storedAtKey(id string) []byte {
+  return []byte(storedAtPrefix + id)
+}
+
+type levelDBStorage struct {
+  db ethdb.KeyValueStore
+}
+
+func newLevelDBStorage(path string) (*levelDBStorage, error) {
+  db, err := leveldb.New(path, 16, 16, "dac", false)
+  if err != nil {
+    return nil, err
+  }
+  return &levelDBStorage{db}, nil
+}
+
+func (s *levelDBStorage) Store(id string, r io.Reader) error {
+  data, err := io.ReadAll(r)
+  if err != nil {
+    return err
+  }
+
+  batch := s.db.NewBatch()
+  if err := batch.Put(batchKey(id), data); err != nil {
+    return err
+  }
+  storedAt := binary.BigEndian.AppendUint64(nil, uint64(time.Now().Unix()))
+  if err := batch.Put(storedAtKey(id), storedAt); err != nil {
+    return err
+  }
+  return batch.Write()
+}
+
+func (s *levelDBStorage) Fetch(id string) (io.ReadCloser, error) {
+  key := batchKey(id)
+  if has, err := s.db.Has(key); err != nil {
+    return nil, err
+  } else if !has {
+    return nil, ErrNotFound
+  }
+  data, err := s.db.Get(key)
+  if err != nil {
+    return nil, err
+  }
+  return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *levelDBStorage) Delete(id string) error {
+  batch := s.db.NewBatch()
+  if err := batch.Delete(batchKey(id)); err != nil {
+    return err
+  }
+  if err := batch.Delete(storedAtKey(id)); err != nil {
+    return err
+  }
+  return batch.Write()
+}
+
+func (s *levelDBStorage) List(fn func(id string, storedAt time.Time) error) error {
+  it := s.db.NewIterator([]byte(storedAtPrefix), nil)
+  defer it.Release()
+
+  for it.Next() {
+    id := string(it.Key()[len(storedAtPrefix):])
+    storedAt := time.Unix(int64(binary.BigEndian.Uint64(it.Value())), 0)
+    if err := fn(id, storedAt); err != nil {
+      return err
+    }
+  }
+  return it.Error()
+}
+
+func (s *levelDBStorage) Close() error {
+  return s.db.Close()
+}
@@ -0,0 +1,246 @@
+package member
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+type S3Config struct {
+  // Endpoint is the base URL of the S3-compatible service, e.g. http://localhost:9000
+  Endpoint string
+  Region string
+  Bucket string
+  // Prefix is prepended to every object key
+  Prefix string
+  AccessKey string
+  SecretKey string
+}
+
+// s3Storage stores batches as objects of an S3-compatible bucket, using path-style
+// requests signed with AWS signature V4
+type s3Storage struct {
+  cfg S3Config
+  endpoint *url.URL
+  httpClient *http.Client
+  now func() time.Time
+}
+
+func newS3Storage(cfg S3Config) (*s3Storage, error) {
+  endpoint, err := url.Parse(cfg.Endpoint)
+  if err != nil {
+    return nil, fmt.Errorf("invalid S3 endpoint: %w", err)
+  }
+  if cfg.Bucket == "" {
+    return nil, fmt.Errorf("missing S3 bucket")
+  }
+  if cfg.Region == "" {
+    cfg.Region = "us-east-1"
+  }
+  return &s3Storage{
+    cfg: cfg,
+    endpoint: endpoint,
+    httpClient: &http.Client{Timeout: time.Minute},
+    now: time.Now,
+  }, nil
+}
+
+func (s *s3Storage) objectPath(id string) string {
+  return "/" + s.cfg.Bucket + "/" + s.cfg.Prefix + id
+}
+
+func (s *s3Storage) Store(id string, r io.Reader) error {
+  data, err := io.ReadAll(r)
+  if err != nil {
+    return err
+  }
+  resp, err := s.do(http.MethodPut, s.objectPath(id), nil, data)
+  if err != nil {
+    return err
+  }
+  defer resp.Body.Close()
+  if resp.StatusCode != http.StatusOK {
+    return fmt.Errorf("could not put object: %v", resp.Status)
+  }
+  return nil
+}
+
+func (s *s3Storage) Fetch(id string) (io.ReadCloser, error) {
+  resp, err := s.do(http.MethodGet, s.objectPath(id), nil, nil)
+  if err != nil {
+    return nil, err
+  }
+  switch resp.StatusCode {
+  case http.StatusOK:
+    return resp.Body, nil
+  case http.StatusNotFound:
+    resp.Body.Close()
+    return nil, ErrNotFound
+  default:
+    resp.Body.Close()
+    return nil, fmt.Errorf("could not get object: %v", resp.Status)
+  }
+}
+
+func (s *s3Storage) Delete(id string) error {
+  resp, err := s.do(http.MethodDelete, s.objectPath(id), nil, nil)
+  if err != nil {
+    return err
+  }
+  defer resp.Body.Close()
+  if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+    return fmt.Errorf("could not delete object: %v", resp.Status)
+  }
+  return nil
+}
+
+func (s *s3Storage) List(fn func(id string, storedAt time.Time) error) error {
+  type listBucketResult struct {
+    Contents []struct {
+      Key string
+      LastModified time.Time
+    }
+    IsTruncated bool
+    NextContinuationToken string
+  }
+
+  token := ""
+  for {
+    query := url.Values{"list-type": {"2"}, "prefix": {s.cfg.Prefix}}
+    if token != "" {
+      query.Set("continuation-token", token)
+    }
+    resp, err := s.do(http.MethodGet, "/" + s.cfg.Bucket, query, nil)
+    if err != nil {
+      return err
+    }
+    result := listBucketResult{}
+    err = xml.NewDecoder(resp.Body).Decode(&result)
+    resp.Body.Close()
+    if resp.StatusCode != http.StatusOK {
+      return fmt.Errorf("could not list objects: %v", resp.Status)
+    }
+    if err != nil {
+      return fmt.Errorf("invalid list objects response: %w", err)
+    }
+
+    for _, object := range result.Contents {
+      if err := fn(strings.TrimPrefix(object.Key, s.cfg.Prefix), object.LastModified); err != nil {
+        return err
+      }
+    }
+    if !result.IsTruncated {
+      return nil
+    }
+    token = result.NextContinuationToken
+  }
+}
+
+// do sends a request signed with AWS signature V4
+func (s *s3Storage) do(method string, path string, query url.Values, body []byte) (*http.Response, error) {
+  u := *s.endpoint
+  u.Path = path
+  u.RawQuery = canonicalQuery(query)
+
+  req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+  if err != nil {
+    return nil, err
+  }
+  s.sign(req, body)
+  return s.httpClient.Do(req)
+}
+
+func (s *s3Storage) sign(req *http.Request, body []byte) {
+  now := s.now().UTC()
+  amzDate := now.Format("20060102T150405Z")
+  date := now.Format("20060102")
+  payloadHash := sha256Hex(body)
+
+  req.Header.Set("x-amz-date", amzDate)
+  req.Header.Set("x-amz-content-sha256", payloadHash)
+
+  signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+  canonicalRequest := strings.Join([]string{
+    req.Method,
+    uriEncode(req.URL.Path, false),
+    req.URL.RawQuery,
+    "host:" + req.URL.Host,
+    "x-amz-content-sha256:" + payloadHash,
+    "x-amz-date:" + amzDate,
+    "",
+    signedHeaders,
+    payloadHash,
+  }, "\n")
+
+  scope := date + "/" + s.cfg.Region + "/s3/aws4_request"
+  stringToSign := strings.Join([]string{
+    "AWS4-HMAC-SHA256",
+    amzDate,
+    scope,
+    sha256Hex([]byte(canonicalRequest)),
+  }, "\n")
+
+  key := hmacSHA256([]byte("AWS4" + s.cfg.SecretKey), date)
+  key = hmacSHA256(key, s.cfg.Region)
+  key = hmacSHA256(key, "s3")
+  key = hmacSHA256(key, "aws4_request")
+  signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+  req.Header.Set("Authorization", fmt.Sprintf(
+    "AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+    s.cfg.AccessKey, scope, signedHeaders, signature,
+  ))
+}
+
+func sha256Hex(data []byte) string {
+  h := sha256.Sum256(data)
+  return hex.EncodeToString(h[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+  h := hmac.New(sha256.New, key)
+  h.Write([]byte(data))
+  return h.Sum(nil)
+}
+
+// canonicalQuery encodes the query sorted by key, as required by signature V4
+func canonicalQuery(query url.Values) string {
+  keys := make([]string, 0, len(query))
+  for k := range query {
+    keys = append(keys, k)
+  }
+  sort.Strings(keys)
+
+  parts := make([]string, 0, len(keys))
+  for _, k := range keys {
+    for _, v := range query[k] {
+      parts = append(parts, uriEncode(k, true) + "=" + uriEncode(v, true))
+    }
+  }
+  return strings.Join(parts, "&")
+}
+
+// uriEncode percent-encodes every byte but the unreserved characters, and '/' unless encodeSlash is set
+func uriEncode(s string, encodeSlash bool) string {
+  var b strings.Builder
+  for i := 0; i < len(s); i++ {
+    c := s[i]
+    isUnreserved := (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+      c == '-' || c == '_' || c == '.' || c == '~'
+    if isUnreserved || (c == '/' && !encodeSlash) {
+      b.WriteByte(c)
+    } else {
+      fmt.Fprintf(&b, "%%%02X", c)
+    }
+  }
+  return b.String()
+}
@@ -0,0 +1,182 @@
+package member
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// fakeS3 is a minimal in-memory stand-in for an S3-compatible service such as MinIO
+type fakeS3 struct {
+  mu sync.Mutex
+  objects map[string][]byte
+  modified map[string]time.Time
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+  if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/") {
+    w.WriteHeader(http.StatusForbidden)
+    return
+  }
+  f.mu.Lock()
+  defer f.mu.Unlock()
+
+  if r.URL.Query().Get("list-type") == "2" {
+    type content struct {
+      Key string
+      LastModified time.Time
+    }
+    result := struct {
+      XMLName xml.Name `xml:"ListBucketResult"`
+      Contents []content
+      IsTruncated bool
+    }{}
+    bucket := strings.TrimPrefix(r.URL.Path, "/")
+    prefix := bucket + "/" + r.URL.Query().Get("prefix")
+    for key := range f.objects {
+      if strings.HasPrefix(key, prefix) {
+        result.Contents = append(result.Contents, content{strings.TrimPrefix(key, bucket + "/"), f.modified[key]})
+      }
+    }
+    sort.Slice(result.Contents, func(i, j int) bool { return result.Contents[i].Key < result.Contents[j].Key })
+    xml.NewEncoder(w).Encode(result)
+    return
+  }
+
+  key := strings.TrimPrefix(r.URL.Path, "/")
+  switch r.Method {
+  case http.MethodPut:
+    data, _ := io.ReadAll(r.Body)
+    f.objects[key] = data
+    f.modified[key] = time.Now()
+  case http.MethodGet:
+    data, ok := f.objects[key]
+    if !ok {
+      w.WriteHeader(http.StatusNotFound)
+      return
+    }
+    w.Write(data)
+  case http.MethodDelete:
+    delete(f.objects, key)
+    delete(f.modified, key)
+    w.WriteHeader(http.StatusNoContent)
+  }
+}
+
+func newTestBackends(t *testing.T) map[string]PrunableStorage {
+  leveldb, err := newLevelDBStorage(filepath.Join(t.TempDir(), "db"))
+  if err != nil {
+    t.Fatal(err)
+  }
+  t.Cleanup(func() { leveldb.Close() })
+
+  srv := httptest.NewServer(&fakeS3{objects: map[string][]byte{}, modified: map[string]time.Time{}})
+  t.Cleanup(srv.Close)
+  s3, err := newS3Storage(S3Config{
+    Endpoint: srv.URL,
+    Bucket: "batches",
+    Prefix: "dac/",
+    AccessKey: "access",
+    SecretKey: "secret",
+  })
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  return map[string]PrunableStorage{
+    "file": NewFileStorage(t.TempDir()),
+    "leveldb": leveldb,
+    "s3": s3,
+  }
+}
+
+func storeBatch(t *testing.T, s Storage, data []byte) string {
+  id := hex.EncodeToString(crypto.Keccak256(data))
+  if err := s.Store(id, bytes.NewReader(data)); err != nil {
+    t.Fatalf("could not store batch: %v", err)
+  }
+  return id
+}
+
+func TestStorageBackends(t *testing.T) {
+  for name, backend := range newTestBackends(t) {
+    t.Run(name, func(t *testing.T) {
+      data := []byte("some batch data")
+      id := storeBatch(t, backend, data)
+
+      r, err := backend.Fetch(id)
+      if err != nil {
+        t.Fatalf("fetch: got an error: %v", err)
+      }
+      got, _ := io.ReadAll(r)
+      r.Close()
+      if !bytes.Equal(got, data) {
+        t.Fatalf("fetch: got %x, want %x", got, data)
+      }
+
+      var listed []string
+      if err := backend.List(func(id string, _ time.Time) error {
+        listed = append(listed, id)
+        return nil
+      }); err != nil {
+        t.Fatalf("list: got an error: %v", err)
+      }
+      if len(listed) != 1 || listed[0] != id {
+        t.Fatalf("list: got %v, want [%v]", listed, id)
+      }
+
+      if err := backend.Delete(id); err != nil {
+        t.Fatalf("delete: got an error: %v", err)
+      }
+      if _, err := backend.Fetch(id); !errors.Is(err, ErrNotFound) {
+        t.Fatalf("fetch deleted: expected not found, got %v", err)
+      }
+    })
+  }
+}
+
+func TestVerifiedStorage(t *testing.T) {
+  backend := NewFileStorage(t.TempDir())
+  s := NewVerifiedStorage(backend, time.Hour)
+
+  if err := s.Store("00", bytes.NewReader([]byte("data"))); !errors.Is(err, ErrIdMismatch) {
+    t.Errorf("store: expected an id mismatch, got %v", err)
+  }
+
+  id := storeBatch(t, s, []byte("data"))
+  other := storeBatch(t, s, []byte("other data"))
+
+  // corrupt the stored batch behind the wrapper's back
+  if err := backend.Store(id, bytes.NewReader([]byte("corrupted"))); err != nil {
+    t.Fatal(err)
+  }
+  if _, err := s.Fetch(id); !errors.Is(err, ErrCorrupted) {
+    t.Errorf("fetch: expected corrupted batch, got %v", err)
+  }
+  if _, err := backend.Fetch(id); !errors.Is(err, ErrNotFound) {
+    t.Errorf("fetch: expected corrupted batch to be deleted, got %v", err)
+  }
+
+  if pruned, err := s.Prune(); err != nil || pruned != 0 {
+    t.Errorf("prune: expected nothing to be pruned, got %v, %v", pruned, err)
+  }
+  s.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+  if pruned, err := s.Prune(); err != nil || pruned != 1 {
+    t.Errorf("prune: expected one batch to be pruned, got %v, %v", pruned, err)
+  }
+  if _, err := s.Fetch(other); !errors.Is(err, ErrNotFound) {
+    t.Errorf("fetch: expected pruned batch not to be found, got %v", err)
+  }
+}
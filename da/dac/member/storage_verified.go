@@ -0,0 +1,151 @@
+package member
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/da/dac"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+  ErrIdMismatch = errors.New("batch does not match its id")
+  ErrCorrupted = errors.New("stored batch does not match its id")
+)
+
+// VerifiedStorage wraps a backend so that only complete batches hashing to their id, or chunks
+// included in the root of their id, are handed to it, batches are checked against their id when read, and batches
+// older than the retention window are garbage collected
+type VerifiedStorage struct {
+  backend PrunableStorage
+  // zero keeps batches forever
+  retention time.Duration
+  now func() time.Time
+}
+
+func NewVerifiedStorage(backend PrunableStorage, retention time.Duration) *VerifiedStorage {
+  return &VerifiedStorage{backend, retention, time.Now}
+}
+
+// chunkIdPrefix prefixes the ids of the chunks of erasure coded batches, which are stored as JSON
+// under the hex encoded chunk commitment root. A member stores a single chunk of each batch.
+const chunkIdPrefix = "chunk-"
+
+func chunkId(root common.Hash) string {
+  return chunkIdPrefix + hex.EncodeToString(root.Bytes())
+}
+
+func isChunkId(id string) bool {
+  return strings.HasPrefix(id, chunkIdPrefix)
+}
+
+// verifyId checks that a batch hashes to its id, or that a chunk is included in the root of its id
+func verifyId(id string, data []byte) bool {
+  if isChunkId(id) {
+    root, err := hex.DecodeString(strings.TrimPrefix(id, chunkIdPrefix))
+    if err != nil || len(root) != common.HashLength {
+      return false
+    }
+    var chunk dac.Chunk
+    return json.Unmarshal(data, &chunk) == nil && chunk.Verify(common.BytesToHash(root))
+  }
+  return hex.EncodeToString(crypto.Keccak256(data)) == id
+}
+
+func (s *VerifiedStorage) Store(id string, r io.Reader) error {
+  data, err := io.ReadAll(r)
+  if err != nil {
+    return fmt.Errorf("could not read batch: %w", err)
+  }
+  if !verifyId(id, data) {
+    return ErrIdMismatch
+  }
+  return s.backend.Store(id, bytes.NewReader(data))
+}
+
+func (s *VerifiedStorage) Fetch(id string) (io.ReadCloser, error) {
+  r, err := s.backend.Fetch(id)
+  if err != nil {
+    return nil, err
+  }
+  defer r.Close()
+
+  data, err := io.ReadAll(r)
+  if err != nil {
+    return nil, fmt.Errorf("could not read batch: %w", err)
+  }
+  if !verifyId(id, data) {
+    // drop it so that the batch can be stored again
+    if err := s.backend.Delete(id); err != nil {
+      log.Error("could not delete corrupted batch", "err", err, "data_hash", id)
+    }
+    return nil, ErrCorrupted
+  }
+  return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *VerifiedStorage) Delete(id string) error {
+  return s.backend.Delete(id)
+}
+
+func (s *VerifiedStorage) List(fn func(id string, storedAt time.Time) error) error {
+  return s.backend.List(fn)
+}
+
+// Prune deletes the batches stored before the retention window and returns how many were deleted
+func (s *VerifiedStorage) Prune() (int, error) {
+  if s.retention == 0 {
+    return 0, nil
+  }
+  limit := s.now().Add(-s.retention)
+
+  var expired []string
+  err := s.backend.List(func(id string, storedAt time.Time) error {
+    if storedAt.Before(limit) {
+      expired = append(expired, id)
+    }
+    return nil
+  })
+  if err != nil {
+    return 0, fmt.Errorf("could not list batches: %w", err)
+  }
+
+  for i, id := range expired {
+    if err := s.backend.Delete(id); err != nil && !errors.Is(err, ErrNotFound) {
+      return i, fmt.Errorf("could not delete batch %v: %w", id, err)
+    }
+  }
+  return len(expired), nil
+}
+
+// RunGC prunes the storage every interval until the context is done
+func (s *VerifiedStorage) RunGC(ctx context.Context, interval time.Duration) {
+  if s.retention == 0 {
+    return
+  }
+  ticker := time.NewTicker(interval)
+  defer ticker.Stop()
+
+  for {
+    select {
+    case <-ctx.Done():
+      return
+    case <-ticker.C:
+      pruned, err := s.Prune()
+      if err != nil {
+        log.Error("could not prune storage", "err", err, "pruned", pruned)
+        continue
+      }
+      log.Info("pruned storage", "pruned", pruned, "retention", s.retention)
+    }
+  }
+}
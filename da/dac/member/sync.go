@@ -0,0 +1,311 @@
+package member
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+  defaultListLimit = 1000
+  maxListLimit = 10_000
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// batchInfo describes a stored batch in the list and stream responses
+type batchInfo struct {
+  DataHash string `json:"data_hash"`
+  // unix timestamp in seconds
+  StoredAt int64 `json:"stored_at"`
+  // hex encoded batch, only set in stream responses
+  Data string `json:"data,omitempty"`
+}
+
+// cursor returns the position right after the batch in insertion order
+func (b batchInfo) cursor() string {
+  return fmt.Sprintf("%d:%s", b.StoredAt, b.DataHash)
+}
+
+func (b batchInfo) after(cursor string) bool {
+  storedAt, dataHash, _ := strings.Cut(cursor, ":")
+  at, _ := strconv.ParseInt(storedAt, 10, 64)
+  return b.StoredAt > at || (b.StoredAt == at && b.DataHash > dataHash)
+}
+
+// rangeQuery selects the batches stored in [from, to), after the cursor, in insertion order
+type rangeQuery struct {
+  // zero for no bound
+  from int64
+  to int64
+  cursor string
+  // zero for no limit
+  limit int
+}
+
+func parseRangeQuery(req *http.Request, defaultLimit int) (rangeQuery, error) {
+  values := req.URL.Query()
+  q := rangeQuery{cursor: values.Get("cursor"), limit: defaultLimit}
+
+  var err error
+  if from := values.Get("from"); from != "" {
+    if q.from, err = strconv.ParseInt(from, 10, 64); err != nil {
+      return q, fmt.Errorf("invalid from: %w", err)
+    }
+  }
+  if to := values.Get("to"); to != "" {
+    if q.to, err = strconv.ParseInt(to, 10, 64); err != nil {
+      return q, fmt.Errorf("invalid to: %w", err)
+    }
+  }
+  if limit := values.Get("limit"); limit != "" {
+    if q.limit, err = strconv.Atoi(limit); err != nil || q.limit <= 0 || q.limit > maxListLimit {
+      return q, fmt.Errorf("invalid limit %q", limit)
+    }
+  }
+  if q.cursor != "" && !strings.Contains(q.cursor, ":") {
+    return q, ErrInvalidCursor
+  }
+  return q, nil
+}
+
+func (q rangeQuery) values() url.Values {
+  values := url.Values{}
+  if q.from != 0 {
+    values.Set("from", strconv.FormatInt(q.from, 10))
+  }
+  if q.to != 0 {
+    values.Set("to", strconv.FormatInt(q.to, 10))
+  }
+  if q.cursor != "" {
+    values.Set("cursor", q.cursor)
+  }
+  return values
+}
+
+// listBatches returns the batches matching the query in insertion order. It also returns
+// whether more batches match past the limit. Chunks are not listed: each member stores a
+// different chunk of a batch, so they cannot be synced from peers.
+func listBatches(s PrunableStorage, q rangeQuery) ([]batchInfo, bool, error) {
+  var batches []batchInfo
+  err := s.List(func(id string, storedAt time.Time) error {
+    if isChunkId(id) {
+      return nil
+    }
+    b := batchInfo{DataHash: id, StoredAt: storedAt.Unix()}
+    if (q.from != 0 && b.StoredAt < q.from) || (q.to != 0 && b.StoredAt >= q.to) {
+      return nil
+    }
+    if q.cursor != "" && !b.after(q.cursor) {
+      return nil
+    }
+    batches = append(batches, b)
+    return nil
+  })
+  if err != nil {
+    return nil, false, err
+  }
+
+  sort.Slice(batches, func(i, j int) bool {
+    if batches[i].StoredAt != batches[j].StoredAt {
+      return batches[i].StoredAt < batches[j].StoredAt
+    }
+    return batches[i].DataHash < batches[j].DataHash
+  })
+  if q.limit > 0 && len(batches) > q.limit {
+    return batches[:q.limit], true, nil
+  }
+  return batches, false, nil
+}
+
+// handleList serves a page of the stored batch hashes, the next page starts at the
+// returned cursor
+func (m *Server) handleList(w http.ResponseWriter, req *http.Request) {
+  if !m.limiter.AllowRequest(req) {
+    w.WriteHeader(http.StatusTooManyRequests)
+    return
+  }
+  q, err := parseRangeQuery(req, defaultListLimit)
+  if err != nil {
+    http.Error(w, err.Error(), http.StatusBadRequest)
+    return
+  }
+
+  batches, more, err := listBatches(m.storage, q)
+  if err != nil {
+    log.Error("could not list batches", "err", err)
+    w.WriteHeader(http.StatusInternalServerError)
+    return
+  }
+
+  type response struct {
+    Batches []batchInfo `json:"batches"`
+    // omitted on the last page
+    Next string `json:"next,omitempty"`
+  }
+  r := response{Batches: batches}
+  if r.Batches == nil {
+    r.Batches = []batchInfo{}
+  }
+  if more {
+    r.Next = batches[len(batches) - 1].cursor()
+  }
+  w.Header().Set("Content-Type", "application/json")
+  json.NewEncoder(w).Encode(r)
+}
+
+// handleStream streams the stored batches with their data as newline delimited JSON,
+// in insertion order
+func (m *Server) handleStream(w http.ResponseWriter, req *http.Request) {
+  if !m.limiter.AllowRequest(req) {
+    w.WriteHeader(http.StatusTooManyRequests)
+    return
+  }
+  q, err := parseRangeQuery(req, 0)
+  if err != nil {
+    http.Error(w, err.Error(), http.StatusBadRequest)
+    return
+  }
+
+  batches, _, err := listBatches(m.storage, q)
+  if err != nil {
+    log.Error("could not list batches", "err", err)
+    w.WriteHeader(http.StatusInternalServerError)
+    return
+  }
+
+  w.Header().Set("Content-Type", "application/x-ndjson")
+  flusher, _ := w.(http.Flusher)
+  encoder := json.NewEncoder(w)
+  for _, b := range batches {
+    data, err := m.storage.Fetch(b.DataHash)
+    if errors.Is(err, ErrNotFound) || errors.Is(err, ErrCorrupted) {
+      continue // pruned or dropped in the meantime
+    } else if err != nil {
+      log.Warn("could not fetch batch", "err", err, "data_hash", b.DataHash)
+      return
+    }
+    raw, err := io.ReadAll(data)
+    data.Close()
+    if err != nil {
+      log.Warn("could not read batch", "err", err, "data_hash", b.DataHash)
+      return
+    }
+
+    b.Data = hex.EncodeToString(raw)
+    if err := encoder.Encode(b); err != nil {
+      log.Debug("stream interrupted", "err", err)
+      return
+    }
+    if flusher != nil {
+      flusher.Flush()
+    }
+  }
+}
+
+// syncStats counts the outcome of the batches received from the peers
+type syncStats struct {
+  stored int
+  skipped int
+  invalid int
+}
+
+// syncFromPeer backfills the storage with the batches streamed by a peer. Batches already
+// stored are skipped, and storage must reject the batches not hashing to their id.
+func syncFromPeer(ctx context.Context, httpClient *http.Client, peer string, storage Storage, q rangeQuery, known map[string]struct{}, stats *syncStats) error {
+  apiUrl, err := url.Parse(peer)
+  if err != nil {
+    return fmt.Errorf("invalid peer url: %w", err)
+  }
+  apiUrl.Path = "batches/stream"
+  apiUrl.RawQuery = q.values().Encode()
+
+  req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiUrl.String(), nil)
+  if err != nil {
+    return fmt.Errorf("could not create request: %w", err)
+  }
+  resp, err := httpClient.Do(req)
+  if err != nil {
+    return fmt.Errorf("could not stream batches: %w", err)
+  }
+  defer resp.Body.Close()
+
+  if resp.StatusCode != 200 {
+    return fmt.Errorf("invalid stream response code: %v", resp.StatusCode)
+  }
+
+  decoder := json.NewDecoder(resp.Body)
+  for {
+    var b batchInfo
+    if err := decoder.Decode(&b); err == io.EOF {
+      return nil
+    } else if err != nil {
+      return fmt.Errorf("could not decode streamed batch: %w", err)
+    }
+    if _, ok := known[b.DataHash]; ok {
+      stats.skipped++
+      continue
+    }
+
+    if isChunkId(b.DataHash) {
+      // the chunks of the peer are not the chunks this member signed
+      log.Warn("peer sent a chunk", "peer", peer, "data_hash", b.DataHash)
+      stats.invalid++
+      continue
+    }
+    data, err := hex.DecodeString(b.Data)
+    if err != nil {
+      log.Warn("peer sent invalid batch hex", "peer", peer, "data_hash", b.DataHash)
+      stats.invalid++
+      continue
+    }
+    if err := storage.Store(b.DataHash, bytes.NewReader(data)); errors.Is(err, ErrIdMismatch) {
+      log.Warn("peer sent batch not matching its hash", "peer", peer, "data_hash", b.DataHash)
+      stats.invalid++
+      continue
+    } else if err != nil {
+      return fmt.Errorf("could not store batch %v: %w", b.DataHash, err)
+    }
+    known[b.DataHash] = struct{}{}
+    stats.stored++
+  }
+}
+
+// syncFromPeers backfills the storage from every peer in turn, so that a batch missing from
+// one peer is still retrieved from another. It errors if any peer could not be fully synced.
+func syncFromPeers(ctx context.Context, httpClient *http.Client, peers []string, storage *VerifiedStorage, q rangeQuery) (syncStats, error) {
+  stats := syncStats{}
+  known := map[string]struct{}{}
+  if err := storage.List(func(id string, _ time.Time) error {
+    known[id] = struct{}{}
+    return nil
+  }); err != nil {
+    return stats, fmt.Errorf("could not list stored batches: %w", err)
+  }
+
+  var failed []string
+  for _, peer := range peers {
+    if err := syncFromPeer(ctx, httpClient, peer, storage, q, known, &stats); err != nil {
+      log.Error("could not sync from peer", "peer", peer, "err", err)
+      failed = append(failed, peer)
+      continue
+    }
+    log.Info("synced from peer", "peer", peer, "stored", stats.stored, "skipped", stats.skipped, "invalid", stats.invalid)
+  }
+  if len(failed) > 0 {
+    return stats, fmt.Errorf("could not sync from peers %v", failed)
+  }
+  return stats, nil
+}
@@ -0,0 +1,127 @@
+package member
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestPeer(t *testing.T, storage PrunableStorage) *httptest.Server {
+  m, err := NewServer(storage, testMemberKey, nil, NewClientLimiter(0, 0, 0, 0), 0, nil, nil, NoopMetrics{})
+  if err != nil {
+    t.Fatal(err)
+  }
+  srv := httptest.NewServer(m.Router())
+  t.Cleanup(srv.Close)
+  return srv
+}
+
+func TestListBatches(t *testing.T) {
+  storage := NewFileStorage(t.TempDir())
+  start := time.Unix(1_700_000_000, 0)
+  var ids []string
+  for i := 0; i < 3; i++ {
+    id := storeBatch(t, storage, []byte(fmt.Sprintf("batch %v", i)))
+    storedAt := start.Add(time.Duration(i) * 10 * time.Second)
+    if err := os.Chtimes(filepath.Join(storage.Directory, id), storedAt, storedAt); err != nil {
+      t.Fatal(err)
+    }
+    ids = append(ids, id)
+  }
+  srv := newTestPeer(t, storage)
+
+  type page struct {
+    Batches []batchInfo `json:"batches"`
+    Next string `json:"next"`
+  }
+  list := func(query string) page {
+    resp, err := http.Get(srv.URL + "/batches?" + query)
+    if err != nil {
+      t.Fatal(err)
+    }
+    defer resp.Body.Close()
+    if resp.StatusCode != http.StatusOK {
+      t.Fatalf("%v: unexpected status %v", query, resp.StatusCode)
+    }
+    var p page
+    json.NewDecoder(resp.Body).Decode(&p)
+    return p
+  }
+  hashes := func(p page) []string {
+    var hashes []string
+    for _, b := range p.Batches {
+      hashes = append(hashes, b.DataHash)
+    }
+    return hashes
+  }
+
+  first := list("limit=2")
+  if got := hashes(first); fmt.Sprint(got) != fmt.Sprint(ids[:2]) || first.Next == "" {
+    t.Fatalf("first page: got %v next %q, want %v", got, first.Next, ids[:2])
+  }
+  last := list("limit=2&cursor=" + first.Next)
+  if got := hashes(last); fmt.Sprint(got) != fmt.Sprint(ids[2:]) || last.Next != "" {
+    t.Fatalf("last page: got %v next %q, want %v", got, last.Next, ids[2:])
+  }
+  ranged := list(fmt.Sprintf("from=%v&to=%v", start.Unix() + 10, start.Unix() + 20))
+  if got := hashes(ranged); fmt.Sprint(got) != fmt.Sprint(ids[1:2]) {
+    t.Fatalf("time range: got %v, want %v", got, ids[1:2])
+  }
+
+  resp, err := http.Get(srv.URL + "/batches?limit=0")
+  if err != nil {
+    t.Fatal(err)
+  }
+  resp.Body.Close()
+  if resp.StatusCode != http.StatusBadRequest {
+    t.Errorf("invalid limit: expected status %v, got %v", http.StatusBadRequest, resp.StatusCode)
+  }
+}
+
+func TestSync(t *testing.T) {
+  // the first peer serves a batch not matching its id, the second one has a batch the first one lacks
+  first := NewFileStorage(t.TempDir())
+  shared := storeBatch(t, first, []byte("shared batch"))
+  forged := storeBatch(t, first, []byte("forged batch"))
+  if err := first.Store(forged, bytes.NewReader([]byte("something else"))); err != nil {
+    t.Fatal(err)
+  }
+  second := NewFileStorage(t.TempDir())
+  storeBatch(t, second, []byte("shared batch"))
+  missing := storeBatch(t, second, []byte("missing batch"))
+
+  down := httptest.NewServer(http.NotFoundHandler())
+  down.Close()
+  peers := []string{newTestPeer(t, first).URL, down.URL, newTestPeer(t, second).URL}
+
+  local := NewVerifiedStorage(NewFileStorage(t.TempDir()), 0)
+  stats, err := syncFromPeers(context.Background(), &http.Client{}, peers, local, rangeQuery{})
+  if err == nil {
+    t.Errorf("expected an error for the unreachable peer")
+  }
+  if stats != (syncStats{stored: 2, skipped: 1, invalid: 1}) {
+    t.Errorf("unexpected sync stats %+v", stats)
+  }
+  for _, id := range []string{shared, missing} {
+    r, err := local.Fetch(id)
+    if err != nil {
+      t.Fatalf("batch %v was not synced: %v", id, err)
+    }
+    io.Copy(io.Discard, r)
+    r.Close()
+  }
+
+  // syncing again only skips
+  stats, err = syncFromPeers(context.Background(), &http.Client{}, peers[2:], local, rangeQuery{})
+  if err != nil || stats != (syncStats{skipped: 2}) {
+    t.Errorf("resync: unexpected stats %+v, err %v", stats, err)
+  }
+}
@@ -0,0 +1,164 @@
+package dac
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ethereum-optimism/optimism/da"
+	"github.com/ethereum-optimism/optimism/op-service/backoff"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+  // defaultGetBatchAttempts is the number of passes over the signers of a batch
+  defaultGetBatchAttempts = 3
+)
+
+var (
+  ErrReadOnlyClient = fmt.Errorf("%w: DAC reader cannot post batches", da.ErrMisconfigured)
+)
+
+// reader is a read-only da.Client retrieving batches from the DAC members that
+// signed them, failing over from one member to another
+type reader struct {
+  log log.Logger
+  members memberEndpoints
+  committees CommitteeSchedule
+  schemes SchemeConfig
+  httpClient *http.Client
+
+  maxAttempts int
+  strategy backoff.Strategy
+}
+
+// NewReader creates a read-only da.Client fetching batches from the DAC members.
+// memberUrls maps the hex public key of every member of the scheduled committees to its endpoint.
+func NewReader(logger log.Logger, memberUrls map[string]string, committees CommitteeSchedule, schemes SchemeConfig, httpClient *http.Client) (da.Client, error) {
+  return newReader(logger, memberUrls, committees, schemes, httpClient)
+}
+
+func newReader(logger log.Logger, memberUrls map[string]string, committees CommitteeSchedule, schemes SchemeConfig, httpClient *http.Client) (*reader, error) {
+  members, err := newMemberEndpoints(memberUrls, committees)
+  if err != nil {
+    return nil, err
+  }
+  return &reader{
+    log: logger,
+    members: members,
+    committees: committees,
+    schemes: schemes,
+    httpClient: httpClient,
+    maxAttempts: defaultGetBatchAttempts,
+    strategy: backoff.Exponential(),
+  }, nil
+}
+
+func (r *reader) PostBatch(ctx context.Context, data []byte, l1Time uint64) (da.BatchRef, error) {
+  return nil, ErrReadOnlyClient
+}
+
+// GetBatch verifies the ref against the committee active at l1Time and retrieves the batch
+// from one of the members that signed it. Members are tried in keyset order, and the whole
+// set is retried with a backoff until one of them serves data matching the certified hash.
+// The batch is only reported as not found when no signer failed for another reason.
+func (r *reader) GetBatch(ctx context.Context, dataRef []byte, l1Time uint64) ([]byte, error) {
+  ref, err := parseBatchRef(dataRef)
+  if err != nil {
+    return nil, err
+  }
+  committee := r.committees.At(l1Time)
+  if err := verifyBatchRef(committee, r.schemes, l1Time, ref); err != nil {
+    return nil, err
+  }
+
+  // only the members that signed are expected to store the batch
+  signers := committee.Keyset.Signers(ref.mask)
+  if committee.ErasureCoded() {
+    return r.getChunks(ctx, committee, signers, common.BytesToHash(ref.dataHash))
+  }
+
+  var data []byte
+  err = backoff.DoCtx(ctx, r.maxAttempts, r.strategy, func() error {
+    var lastErr error
+    for _, member := range signers {
+      var err error
+      data, err = FetchBatch(ctx, r.httpClient, r.members.of(committee.Keyset[member]), ref.dataHash)
+      if err == nil {
+        return nil
+      }
+      r.log.Warn("could not get batch from DAC member", "member", member, "err", err)
+      if lastErr == nil || !errors.Is(err, da.ErrBatchNotFound) {
+        lastErr = err
+      }
+    }
+    return lastErr
+  })
+  if err != nil {
+    return nil, fmt.Errorf("could not get batch from any DAC member: %w", err)
+  }
+  return data, nil
+}
+
+// getChunks retrieves chunks of an erasure coded batch from the members that signed its chunk
+// commitment root until enough of them are collected to reconstruct it. Chunks already retrieved
+// are kept across the backoff attempts. A certified root of an invalid encoding is not retried.
+func (r *reader) getChunks(ctx context.Context, committee Committee, signers []int, root common.Hash) ([]byte, error) {
+  dataShards := uint64(committee.DataShards)
+  total := uint64(len(committee.Keyset))
+  chunks := make(map[int]Chunk)
+
+  var data []byte
+  var invalidErr error
+  err := backoff.DoCtx(ctx, r.maxAttempts, r.strategy, func() error {
+    var lastErr error
+    for _, member := range signers {
+      if uint64(len(chunks)) >= dataShards {
+        break
+      }
+      if _, ok := chunks[member]; ok {
+        continue
+      }
+      chunk, err := FetchChunk(ctx, r.httpClient, r.members.of(committee.Keyset[member]), root, total)
+      if err == nil && chunk.Index != uint64(member) {
+        // a member serving the chunk of another one does not help reconstructing the batch
+        err = fmt.Errorf("%w: got chunk %v from member %v", ErrInvalidChunk, chunk.Index, member)
+      }
+      if err != nil {
+        r.log.Warn("could not get chunk from DAC member", "member", member, "err", err)
+        if lastErr == nil || !errors.Is(err, da.ErrBatchNotFound) {
+          lastErr = err
+        }
+        continue
+      }
+      chunks[member] = chunk
+    }
+    if uint64(len(chunks)) < dataShards {
+      if lastErr == nil {
+        lastErr = ErrNotEnoughChunks
+      }
+      return lastErr
+    }
+
+    collected := make([]Chunk, 0, len(chunks))
+    for _, chunk := range chunks {
+      collected = append(collected, chunk)
+    }
+    var err error
+    data, err = ReconstructBatch(collected, dataShards, total, root)
+    if errors.Is(err, da.ErrInvalidBatchRef) {
+      invalidErr = err
+      return nil
+    }
+    return err
+  })
+  if invalidErr != nil {
+    return nil, fmt.Errorf("could not reconstruct batch from DAC members: %w", invalidErr)
+  }
+  if err != nil {
+    return nil, fmt.Errorf("could not reconstruct batch from DAC members: %w", err)
+  }
+  return data, nil
+}
@@ -0,0 +1,106 @@
+package dac
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/da"
+	"github.com/ethereum-optimism/optimism/op-service/backoff"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+func TestReaderFailover(t *testing.T) {
+  signers, keyset := newTestCommittee(t, 3)
+  members, urls := startTestMembers(t, signers)
+  data := []byte("some batch data")
+  dataHash := crypto.Keccak256(data)
+
+  c, err := NewReader(log.New(), urls, SingleCommittee(keyset, 2), SchemeConfig{}, http.DefaultClient)
+  if err != nil {
+    t.Fatal(err)
+  }
+  r := c.(*reader)
+  r.strategy = backoff.Fixed(0)
+
+  // members 0 and 2 signed, member 0 is down and member 2 serves corrupted data
+  signature, mask := certify(t, BatchDomain(SchemeLegacy, nil), []Signer{signers[0], {}, signers[2]}, dataHash)
+  tx, _ := (&batchRef{dataHash: dataHash, signature: signature, mask: mask}).ToTx()
+  members[0].down = true
+  members[1].batches[hex.EncodeToString(dataHash)] = data
+  members[2].batches[hex.EncodeToString(dataHash)] = []byte("corrupted")
+
+  if _, err := r.GetBatch(context.Background(), tx.Data, 0); !errors.Is(err, da.ErrUnavailable) {
+    t.Fatalf("unavailable batch: expected an unavailable error, got %v", err)
+  }
+  if members[0].gets != r.maxAttempts || members[2].gets != r.maxAttempts {
+    t.Errorf("unavailable batch: expected %v attempts per signer, got %v and %v", r.maxAttempts, members[0].gets, members[2].gets)
+  }
+  if members[1].gets != 0 {
+    t.Errorf("unavailable batch: member 1 did not sign but was queried")
+  }
+
+  // the batch is only missing when no signer failed otherwise
+  members[0].down = false
+  delete(members[2].batches, hex.EncodeToString(dataHash))
+  if _, err := r.GetBatch(context.Background(), tx.Data, 0); !errors.Is(err, da.ErrBatchNotFound) {
+    t.Fatalf("missing batch: expected a not found error, got %v", err)
+  }
+
+  ctx, cancel := context.WithCancel(context.Background())
+  cancel()
+  members[2].batches[hex.EncodeToString(dataHash)] = data
+  if _, err := r.GetBatch(ctx, tx.Data, 0); !errors.Is(err, context.Canceled) {
+    t.Fatalf("cancelled: expected a cancelled error, got %v", err)
+  }
+
+  got, err := r.GetBatch(context.Background(), tx.Data, 0)
+  if err != nil {
+    t.Fatalf("failover: got an error: %v", err)
+  }
+  if !bytes.Equal(got, data) {
+    t.Fatalf("failover: got %x, want %x", got, data)
+  }
+
+  if _, err := r.PostBatch(context.Background(), data, 0); !errors.Is(err, ErrReadOnlyClient) || !errors.Is(err, da.ErrMisconfigured) {
+    t.Errorf("expected reader to be read-only, got %v", err)
+  }
+}
+
+func TestReaderInvalidEncoding(t *testing.T) {
+  signers, keyset := newTestCommittee(t, 4)
+  members, urls := startTestMembers(t, signers)
+  committees := CommitteeSchedule{{Committee: Committee{Keyset: keyset, Threshold: 3, DataShards: 2}}}
+
+  c, err := NewReader(log.New(), urls, committees, SchemeConfig{}, http.DefaultClient)
+  if err != nil {
+    t.Fatal(err)
+  }
+  r := c.(*reader)
+  r.strategy = backoff.Fixed(0)
+
+  // the committee certified the root of parity chunks not matching the data chunks
+  shards, err := encodeShards([]byte("some batch data"), 2, 4)
+  if err != nil {
+    t.Fatal(err)
+  }
+  shards[3] = bytes.Repeat([]byte{0xff}, len(shards[3]))
+  root, chunks := commitChunks(shards)
+  for i, member := range members {
+    member.chunks[hex.EncodeToString(root.Bytes())] = chunks[i]
+  }
+  signature, mask := certifyChunks(t, ChunkDomain(SchemeLegacy, nil), signers, root)
+  tx, _ := (&batchRef{dataHash: root.Bytes(), signature: signature, mask: mask}).ToTx()
+
+  _, err = r.GetBatch(context.Background(), tx.Data, 0)
+  if !errors.Is(err, da.ErrInvalidBatchRef) || errors.Is(err, da.ErrUnavailable) {
+    t.Fatalf("expected an invalid batch ref, got %v", err)
+  }
+  if members[0].gets != 1 || members[1].gets != 1 {
+    t.Errorf("expected the invalid encoding not to be retried, got %v and %v chunk requests", members[0].gets, members[1].gets)
+  }
+}
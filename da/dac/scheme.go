@@ -0,0 +1,233 @@
+package dac
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	bls "github.com/ethereum/go-ethereum/crypto/bls12381"
+)
+
+var (
+  ErrUnknownScheme = errors.New("unknown signature scheme")
+  ErrMissingChainID = errors.New("signature scheme requires a chain ID")
+)
+
+// SchemeVersion identifies how messages are hashed to G2 before being signed
+type SchemeVersion uint8
+
+const (
+  // SchemeLegacy maps a zero padded keccak hash of the message to G2, with no domain separation
+  SchemeLegacy SchemeVersion = 0
+  // SchemeV1 is the RFC 9380 BLS12381G2_XMD:SHA-256_SSWU_RO_ hash to G2, with a domain
+  // separation tag made of the chain ID and the purpose of the signature
+  SchemeV1 SchemeVersion = 1
+)
+
+// Purposes of a signature, so that a signature made for one can never be used for another
+const (
+  PurposeBatch = "BATCH"
+  // PurposeChunk signs the chunk commitment root of an erasure coded batch along with the index
+  // of the chunk the signer stores, so that a certificate proves distinct chunks are stored
+  PurposeChunk = "CHUNK"
+  PurposeProofOfPossession = "POP"
+)
+
+// chunkDomainTag separates legacy chunk signatures from legacy batch signatures, so that a
+// certified chunk commitment root can never be taken for a certified data hash
+var chunkDomainTag = []byte("DAC_CHUNK_V1")
+
+// Domain determines how a message is hashed to G2. A signature is only valid in the
+// domain it was made in.
+type Domain struct {
+  Version SchemeVersion
+  // ChainID is the L2 chain ID, ignored by SchemeLegacy
+  ChainID *big.Int
+  Purpose string
+}
+
+// BatchDomain is the domain of the batch data hash signatures of the given chain
+func BatchDomain(version SchemeVersion, chainID *big.Int) Domain {
+  return Domain{version, chainID, PurposeBatch}
+}
+
+// ChunkDomain is the domain of the chunk signatures of the erasure coded batches of the given chain
+func ChunkDomain(version SchemeVersion, chainID *big.Int) Domain {
+  return Domain{version, chainID, PurposeChunk}
+}
+
+// ChunkMessage is the message signed by the member storing the chunk at the given index
+func ChunkMessage(root common.Hash, index uint64) []byte {
+  return binary.BigEndian.AppendUint64(root.Bytes(), index)
+}
+
+// proofOfPossessionDomain is the domain of the proofs of possession. They are tied to a key
+// rather than to a chain, so they stay in the legacy scheme and existing proofs remain valid.
+var proofOfPossessionDomain = Domain{Version: SchemeLegacy, Purpose: PurposeProofOfPossession}
+
+// dst returns the RFC 9380 domain separation tag of the domain
+func (d Domain) dst() []byte {
+  return []byte(fmt.Sprintf("OP-DAC-V%02d-CHAIN%v-%s-with-BLS12381G2_XMD:SHA-256_SSWU_RO_", d.Version, d.ChainID, d.Purpose))
+}
+
+func (d Domain) hashToG2(message []byte) (*bls.PointG2, error) {
+  switch d.Version {
+  case SchemeLegacy:
+    // batch signatures were made with no domain at all
+    var prefix []byte
+    switch d.Purpose {
+    case PurposeProofOfPossession:
+      prefix = popDomainTag
+    case PurposeChunk:
+      prefix = chunkDomainTag
+    }
+    return legacyHashToG2(prefix, message)
+  case SchemeV1:
+    if d.ChainID == nil {
+      return nil, ErrMissingChainID
+    }
+    return hashToCurveG2(message, d.dst())
+  default:
+    return nil, fmt.Errorf("%w: %v", ErrUnknownScheme, d.Version)
+  }
+}
+
+// legacyHashToG2 maps the prefixed message to G2
+func legacyHashToG2(prefix []byte, message []byte) (*bls.PointG2, error) {
+  // hash so that we don't sign an arbitrary value
+  hashed := crypto.Keccak256(prefix, message)
+
+  g2 := bls.NewG2()
+  padding := [64]byte{}
+  msgPoint, err := g2.MapToCurve(append(padding[:], hashed...))
+  if err != nil {
+    return nil, fmt.Errorf("could not map message to curve: %w", err)
+  }
+  return msgPoint, nil
+}
+
+// fieldModulus is the modulus p of the BLS12-381 base field
+var fieldModulus, _ = new(big.Int).SetString("1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab", 16)
+
+const (
+  // hashToFieldLength is L of RFC 9380 for BLS12-381: ceil((ceil(log2(p)) + k) / 8) with k = 128
+  hashToFieldLength = 64
+  fieldElementLength = 48
+)
+
+// hashToCurveG2 implements the hash_to_curve function of the BLS12381G2_XMD:SHA-256_SSWU_RO_
+// suite of RFC 9380
+func hashToCurveG2(message []byte, dst []byte) (*bls.PointG2, error) {
+  // two elements of Fp2, each made of two elements of Fp
+  uniformBytes, err := expandMessageXMD(message, dst, 2 * 2 * hashToFieldLength)
+  if err != nil {
+    return nil, err
+  }
+
+  g2 := bls.NewG2()
+  result := g2.Zero()
+  for i := 0; i < 2; i++ {
+    // MapToCurve expects the c1 coefficient first
+    var u [2 * fieldElementLength]byte
+    for j := 0; j < 2; j++ {
+      offset := hashToFieldLength * (j + i * 2)
+      e := new(big.Int).SetBytes(uniformBytes[offset:offset+hashToFieldLength])
+      e.Mod(e, fieldModulus)
+      e.FillBytes(u[(1 - j) * fieldElementLength:(2 - j) * fieldElementLength])
+    }
+
+    // MapToCurve clears the cofactor of each point, which is the same as clearing
+    // the cofactor of their sum as it is a scalar multiplication
+    q, err := g2.MapToCurve(u[:])
+    if err != nil {
+      return nil, fmt.Errorf("could not map field element to curve: %w", err)
+    }
+    g2.Add(result, result, q)
+  }
+  return g2.Affine(result), nil
+}
+
+// expandMessageXMD implements expand_message_xmd of RFC 9380 with SHA-256
+func expandMessageXMD(message []byte, dst []byte, length int) ([]byte, error) {
+  ell := (length + sha256.Size - 1) / sha256.Size
+  if ell > 255 || length > 65535 || len(dst) > 255 {
+    return nil, errors.New("invalid expand_message_xmd parameters")
+  }
+  dstPrime := append(append([]byte{}, dst...), byte(len(dst)))
+
+  h := sha256.New()
+  h.Write(make([]byte, sha256.BlockSize))
+  h.Write(message)
+  h.Write([]byte{byte(length >> 8), byte(length), 0})
+  h.Write(dstPrime)
+  b0 := h.Sum(nil)
+
+  h.Reset()
+  h.Write(b0)
+  h.Write([]byte{1})
+  h.Write(dstPrime)
+  bi := h.Sum(nil)
+
+  uniformBytes := make([]byte, 0, ell * sha256.Size)
+  uniformBytes = append(uniformBytes, bi...)
+  for i := 2; i <= ell; i++ {
+    xored := make([]byte, sha256.Size)
+    for j := range xored {
+      xored[j] = b0[j] ^ bi[j]
+    }
+    h.Reset()
+    h.Write(xored)
+    h.Write([]byte{byte(i)})
+    h.Write(dstPrime)
+    bi = h.Sum(nil)
+    uniformBytes = append(uniformBytes, bi...)
+  }
+  return uniformBytes[:length], nil
+}
+
+// SchemeConfig selects the signature scheme of batch certificates across network upgrades
+type SchemeConfig struct {
+  // ChainID is the L2 chain ID, required once SchemeV1 is active
+  ChainID *big.Int
+  // V1Time is the L1 timestamp from which SchemeV1 certificates are accepted and produced,
+  // nil if never. Legacy certificates are rejected from then on, as they are not bound to the chain.
+  V1Time *uint64
+}
+
+// IsV1 returns true if SchemeV1 is active at or past the given L1 timestamp
+func (c SchemeConfig) IsV1(l1Time uint64) bool {
+  return c.V1Time != nil && l1Time >= *c.V1Time
+}
+
+// versionAt returns the scheme version new certificates are made with at the given L1 timestamp
+func (c SchemeConfig) versionAt(l1Time uint64) SchemeVersion {
+  if c.IsV1(l1Time) {
+    return SchemeV1
+  }
+  return SchemeLegacy
+}
+
+// accepts reports whether a certificate of the given version is valid in an L1 block
+// of the given timestamp
+func (c SchemeConfig) accepts(version SchemeVersion, l1Time uint64) bool {
+  switch version {
+  case SchemeLegacy:
+    return !c.IsV1(l1Time)
+  case SchemeV1:
+    return c.IsV1(l1Time)
+  default:
+    return false
+  }
+}
+
+func (c SchemeConfig) batchDomain(version SchemeVersion) Domain {
+  return BatchDomain(version, c.ChainID)
+}
+
+func (c SchemeConfig) chunkDomain(version SchemeVersion) Domain {
+  return ChunkDomain(version, c.ChainID)
+}
@@ -0,0 +1,75 @@
+package dac
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	bls "github.com/ethereum/go-ethereum/crypto/bls12381"
+)
+
+// test vectors of RFC 9380, appendix K.1
+func TestExpandMessageXMD(t *testing.T) {
+  dst := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+  vectors := map[string]string{
+    "": "68a985b87eb6b46952128911f2a4412bbc302a9d759667f87f7a21d803f07235",
+    "abc": "d8ccab23b5985ccea865c6c97b6e5b8350e794e603b4b97902f53a8a0d605615",
+  }
+  for msg, expected := range vectors {
+    got, err := expandMessageXMD([]byte(msg), dst, 32)
+    if err != nil {
+      t.Fatal(err)
+    }
+    if hex.EncodeToString(got) != expected {
+      t.Errorf("%q: got %x, want %v", msg, got, expected)
+    }
+  }
+}
+
+// test vector of RFC 9380, appendix J.10.1
+func TestHashToCurveG2(t *testing.T) {
+  dst := []byte("QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_")
+  // x.c1 || x.c0 || y.c1 || y.c0
+  expected := "05cb8437535e20ecffaef7752baddf98034139c38452458baeefab379ba13dff5bf5dd71b72418717047f5b0f37da03d" +
+    "0141ebfbdca40eb85b87142e130ab689c673cf60f1a3e98d69335266f30d9b8d4ac44c1038e9dcdd5393faf5c41fb78a" +
+    "12424ac32561493f3fe3c260708a12b7c620e7be00099a974e259ddc7d1f6395c3c811cdd19f1e8dbf3e9ecfdcbab8d6" +
+    "0503921d7f6a12805e72940b963c0cf3471c7b2a524950ca195d11062ee75ec076daf2d4bc358c4b190c0c98064fdd92"
+
+  p, err := hashToCurveG2([]byte(""), dst)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if got := hex.EncodeToString(bls.NewG2().ToBytes(p)); got != expected {
+    t.Errorf("got %v, want %v", got, expected)
+  }
+}
+
+func TestDomainSeparation(t *testing.T) {
+  signers, _ := newTestCommittee(t, 1)
+  message := []byte("message")
+  domains := []Domain{
+    BatchDomain(SchemeLegacy, nil),
+    BatchDomain(SchemeV1, big.NewInt(1)),
+    BatchDomain(SchemeV1, big.NewInt(2)),
+    {SchemeV1, big.NewInt(1), PurposeProofOfPossession},
+  }
+  for i, signing := range domains {
+    signature, err := signers[0].Sign(signing, message)
+    if err != nil {
+      t.Fatal(err)
+    }
+    for j, verifying := range domains {
+      isValid, err := signers[0].GetPublicKey().VerifyMessage(verifying, message, signature.ToBytes())
+      if err != nil {
+        t.Fatal(err)
+      }
+      if isValid != (i == j) {
+        t.Errorf("signature of domain %v verified in domain %v: %v", i, j, isValid)
+      }
+    }
+  }
+
+  if _, err := signers[0].Sign(BatchDomain(SchemeV1, nil), message); err != ErrMissingChainID {
+    t.Errorf("expected a missing chain ID error, got %v", err)
+  }
+}
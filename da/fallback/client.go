@@ -0,0 +1,145 @@
+package fallback
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/da"
+)
+
+const (
+  // CalldataHeaderID prefixes the batches posted directly to the batch inbox, it is distinct
+  // from the headers of the refs of the other DA clients
+  CalldataHeaderID uint8 = 0xca
+)
+
+var ErrPostTimeout = fmt.Errorf("%w: DA post timed out", da.ErrUnavailable)
+
+// IsActive returns true if calldata batches are accepted at or past the given L1 timestamp,
+// given the activation time of the fallback: nil never activates it
+func IsActive(activationTime *uint64, l1Time uint64) bool {
+  return activationTime != nil && l1Time >= *activationTime
+}
+
+type calldataRef struct {
+  to common.Address
+  data []byte
+}
+
+// ToTx encodes the batch data as:
+// <        1         ><  n   >
+// < CalldataHeaderID >< data >
+func (r *calldataRef) ToTx() (da.Tx, error) {
+  data := make([]byte, 0, 1 + len(r.data))
+  data = append(data, CalldataHeaderID)
+  data = append(data, r.data...)
+  return da.Tx{To: &r.to, Data: data}, nil
+}
+
+// client posts batches to another DA client, and falls back to posting them directly to the
+// batch inbox once that DA has been unavailable for the fallback timeout. The batches are then
+// posted to L1 for another timeout before the DA is tried again.
+type client struct {
+  da da.Client
+  addr common.Address
+  activationTime *uint64
+  timeout time.Duration
+  log log.Logger
+  now func() time.Time
+
+  mu sync.Mutex
+  // zero while the DA is available
+  unavailableSince time.Time
+  fallbackUntil time.Time
+}
+
+// NewClient wraps a DA client so that its refs and raw L1 calldata batches can be used on the
+// same chain, from the activation time of the fallback. A zero timeout never falls back to L1
+// calldata, but calldata batches are still read.
+func NewClient(logger log.Logger, daClient da.Client, addr common.Address, activationTime *uint64, timeout time.Duration) da.Client {
+  return &client{
+    da: daClient,
+    addr: addr,
+    activationTime: activationTime,
+    timeout: timeout,
+    log: logger,
+    now: time.Now,
+  }
+}
+
+func (c *client) PostBatch(ctx context.Context, data []byte, l1Time uint64) (da.BatchRef, error) {
+  if c.timeout == 0 {
+    return c.da.PostBatch(ctx, data, l1Time)
+  }
+
+  // the lock is not held while posting, so that concurrent posts are not serialized
+  c.mu.Lock()
+  start := c.now()
+  // the DA availability is tracked before the activation, but batches are only posted to L1
+  // calldata once they are valid there
+  active := IsActive(c.activationTime, l1Time)
+  fallback := active && start.Before(c.fallbackUntil)
+  c.mu.Unlock()
+  if fallback {
+    return &calldataRef{c.addr, data}, nil
+  }
+
+  ref, err := c.post(ctx, data, l1Time)
+  if err != nil && ctx.Err() != nil {
+    // the caller giving up tells nothing of the DA availability
+    return nil, err
+  }
+
+  c.mu.Lock()
+  defer c.mu.Unlock()
+  if err == nil {
+    if !c.unavailableSince.IsZero() {
+      c.log.Info("DA available again", "unavailable_for", start.Sub(c.unavailableSince))
+    }
+    c.unavailableSince = time.Time{}
+    return ref, nil
+  }
+
+  if c.unavailableSince.IsZero() {
+    c.unavailableSince = start
+  }
+  now := c.now()
+  if active && now.Before(c.fallbackUntil) {
+    // a concurrent post already fell back
+    return &calldataRef{c.addr, data}, nil
+  }
+  unavailableFor := now.Sub(c.unavailableSince)
+  if unavailableFor < c.timeout || !active {
+    return nil, err
+  }
+  c.log.Warn("DA unavailable, posting batches to L1 calldata", "err", err, "unavailable_for", unavailableFor, "retry_in", c.timeout)
+  c.fallbackUntil = now.Add(c.timeout)
+  return &calldataRef{c.addr, data}, nil
+}
+
+// post posts the batch to the DA, giving up after the fallback timeout
+func (c *client) post(ctx context.Context, data []byte, l1Time uint64) (da.BatchRef, error) {
+  postCtx, cancel := context.WithTimeout(ctx, c.timeout)
+  defer cancel()
+  ref, err := c.da.PostBatch(postCtx, data, l1Time)
+  // the caller giving up is not a DA timeout
+  if err != nil && ctx.Err() == nil && postCtx.Err() != nil {
+    return nil, fmt.Errorf("%w after %v: %v", ErrPostTimeout, c.timeout, err)
+  }
+  return ref, err
+}
+
+func (c *client) GetBatch(ctx context.Context, ref []byte, l1Time uint64) ([]byte, error) {
+  if len(ref) > 0 && ref[0] == CalldataHeaderID {
+    if !IsActive(c.activationTime, l1Time) {
+      return nil, fmt.Errorf("%w: calldata batch before the fallback activation", da.ErrInvalidBatchRef)
+    }
+    return ref[1:], nil
+  }
+  return c.da.GetBatch(ctx, ref, l1Time)
+}
@@ -0,0 +1,233 @@
+package fallback
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/da"
+)
+
+var errUnavailable = errors.New("unavailable")
+
+type testRef []byte
+
+func (r testRef) ToTx() (da.Tx, error) {
+  return da.Tx{Data: r}, nil
+}
+
+// testDA refs are the batch data prefixed by 0x01
+type testDA struct {
+  err error
+  hang chan struct{}
+  // started is notified of the posts before they hang
+  started chan struct{}
+
+  mu sync.Mutex
+  posts int
+}
+
+func (d *testDA) PostBatch(ctx context.Context, data []byte, l1Time uint64) (da.BatchRef, error) {
+  d.mu.Lock()
+  d.posts++
+  d.mu.Unlock()
+  if d.started != nil {
+    d.started <- struct{}{}
+  }
+  if d.hang != nil {
+    select {
+    case <-d.hang:
+    case <-ctx.Done():
+      return nil, ctx.Err()
+    }
+  }
+  if d.err != nil {
+    return nil, d.err
+  }
+  return testRef(append([]byte{1}, data...)), nil
+}
+
+func (d *testDA) GetBatch(ctx context.Context, ref []byte, l1Time uint64) ([]byte, error) {
+  if len(ref) == 0 || ref[0] != 1 {
+    return nil, da.ErrInvalidBatchRef
+  }
+  return ref[1:], nil
+}
+
+func postTx(t *testing.T, c da.Client, data []byte) ([]byte, error) {
+  ref, err := c.PostBatch(context.Background(), data, 0)
+  if err != nil {
+    return nil, err
+  }
+  tx, err := ref.ToTx()
+  if err != nil {
+    t.Fatal(err)
+  }
+  return tx.Data, nil
+}
+
+func TestFallback(t *testing.T) {
+  data := []byte("some batch data")
+  primary := &testDA{}
+  c := NewClient(log.New(), primary, common.Address{}, new(uint64), time.Minute).(*client)
+  now := time.Unix(1000, 0)
+  c.now = func() time.Time { return now }
+
+  ref, err := postTx(t, c, data)
+  if err != nil || ref[0] != 1 {
+    t.Fatalf("available DA: expected a DA ref, got %x, %v", ref, err)
+  }
+
+  primary.err = errUnavailable
+  if _, err := postTx(t, c, data); !errors.Is(err, errUnavailable) {
+    t.Fatalf("unavailable DA: expected the DA error, got %v", err)
+  }
+  now = now.Add(59 * time.Second)
+  if _, err := postTx(t, c, data); !errors.Is(err, errUnavailable) {
+    t.Fatalf("before timeout: expected the DA error, got %v", err)
+  }
+
+  now = now.Add(time.Second)
+  ref, err = postTx(t, c, data)
+  if err != nil {
+    t.Fatalf("after timeout: got an error: %v", err)
+  }
+  if !bytes.Equal(ref, append([]byte{CalldataHeaderID}, data...)) {
+    t.Fatalf("after timeout: expected a calldata ref, got %x", ref)
+  }
+
+  // the DA is not tried while falling back
+  primary.err = nil
+  posts := primary.posts
+  if ref, _ := postTx(t, c, data); ref[0] != CalldataHeaderID || primary.posts != posts {
+    t.Errorf("falling back: expected a calldata ref without posting to the DA, got %x", ref)
+  }
+  now = now.Add(time.Minute)
+  if ref, _ := postTx(t, c, data); ref[0] != 1 {
+    t.Errorf("after fallback: expected a DA ref, got %x", ref)
+  }
+
+  // the DA is tried again with its availability clock reset
+  primary.err = errUnavailable
+  if _, err := postTx(t, c, data); !errors.Is(err, errUnavailable) {
+    t.Errorf("unavailable again: expected the DA error, got %v", err)
+  }
+}
+
+func TestFallbackPostTimeout(t *testing.T) {
+  data := []byte("some batch data")
+  primary := &testDA{hang: make(chan struct{})}
+  defer close(primary.hang)
+  c := NewClient(log.New(), primary, common.Address{}, new(uint64), 10 * time.Millisecond)
+
+  ref, err := postTx(t, c, data)
+  if err != nil || ref[0] != CalldataHeaderID {
+    t.Fatalf("expected a calldata ref, got %x, %v", ref, err)
+  }
+}
+
+func TestFallbackConcurrentPosts(t *testing.T) {
+  data := []byte("some batch data")
+  primary := &testDA{hang: make(chan struct{}), started: make(chan struct{})}
+  c := NewClient(log.New(), primary, common.Address{}, new(uint64), time.Minute).(*client)
+
+  // the posts reach the DA together, none waits on another
+  errs := make(chan error, 4)
+  for i := 0; i < 4; i++ {
+    go func() {
+      ref, err := postTx(t, c, data)
+      if err == nil && ref[0] != 1 {
+        err = errors.New("expected a DA ref")
+      }
+      errs <- err
+    }()
+  }
+  for i := 0; i < 4; i++ {
+    select {
+    case <-primary.started:
+    case <-time.After(time.Second):
+      t.Fatalf("expected 4 concurrent posts, got %v", i)
+    }
+  }
+  close(primary.hang)
+  for i := 0; i < 4; i++ {
+    if err := <-errs; err != nil {
+      t.Errorf("concurrent post: got an error: %v", err)
+    }
+  }
+
+  // a post given up by the caller does not count as the DA being unavailable
+  primary.started = nil
+  primary.hang = make(chan struct{})
+  defer close(primary.hang)
+  ctx, cancel := context.WithTimeout(context.Background(), 10 * time.Millisecond)
+  defer cancel()
+  if _, err := c.PostBatch(ctx, data, 0); !errors.Is(err, context.DeadlineExceeded) {
+    t.Fatalf("expired context: expected a deadline exceeded error, got %v", err)
+  }
+  if !c.unavailableSince.IsZero() {
+    t.Errorf("expired context: expected the DA to remain available, unavailable since %v", c.unavailableSince)
+  }
+}
+
+func TestGetBatch(t *testing.T) {
+  data := []byte("some batch data")
+  activationTime := uint64(100)
+  c := NewClient(log.New(), &testDA{}, common.Address{}, &activationTime, 0)
+
+  for name, ref := range map[string][]byte{
+    "calldata": append([]byte{CalldataHeaderID}, data...),
+    "DA": append([]byte{1}, data...),
+  } {
+    got, err := c.GetBatch(context.Background(), ref, activationTime)
+    if err != nil {
+      t.Fatalf("%v: got an error: %v", name, err)
+    }
+    if !bytes.Equal(got, data) {
+      t.Fatalf("%v: got %x, want %x", name, got, data)
+    }
+  }
+  if _, err := c.GetBatch(context.Background(), []byte{0}, 0); !errors.Is(err, da.ErrInvalidBatchRef) {
+    t.Errorf("unknown header: expected an invalid batch ref error, got %v", err)
+  }
+
+  // calldata batches are invalid before the activation, or on chains that never activate it
+  calldata := append([]byte{CalldataHeaderID}, data...)
+  if _, err := c.GetBatch(context.Background(), calldata, activationTime - 1); !errors.Is(err, da.ErrInvalidBatchRef) {
+    t.Errorf("before activation: expected an invalid batch ref error, got %v", err)
+  }
+  inactive := NewClient(log.New(), &testDA{}, common.Address{}, nil, 0)
+  if _, err := inactive.GetBatch(context.Background(), calldata, activationTime); !errors.Is(err, da.ErrInvalidBatchRef) {
+    t.Errorf("never active: expected an invalid batch ref error, got %v", err)
+  }
+}
+
+func TestFallbackActivation(t *testing.T) {
+  primary := &testDA{err: errUnavailable}
+  activationTime := uint64(100)
+  c := NewClient(log.New(), primary, common.Address{}, &activationTime, time.Minute).(*client)
+  now := time.Unix(1000, 0)
+  c.now = func() time.Time { return now }
+
+  // the DA is unavailable for longer than the timeout, but calldata batches are not valid yet
+  if _, err := c.PostBatch(context.Background(), []byte("some batch data"), 0); !errors.Is(err, errUnavailable) {
+    t.Fatalf("expected the DA error, got %v", err)
+  }
+  now = now.Add(2 * time.Minute)
+  if _, err := c.PostBatch(context.Background(), []byte("some batch data"), activationTime - 1); !errors.Is(err, errUnavailable) {
+    t.Fatalf("before activation: expected the DA error, got %v", err)
+  }
+  ref, err := c.PostBatch(context.Background(), []byte("some batch data"), activationTime)
+  if err != nil {
+    t.Fatalf("after activation: got an error: %v", err)
+  }
+  if tx, _ := ref.ToTx(); tx.Data[0] != CalldataHeaderID {
+    t.Errorf("after activation: expected a calldata ref, got %x", tx.Data)
+  }
+}
@@ -0,0 +1,63 @@
+package da
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Metricer records the calls to a DA client
+type Metricer interface {
+  // RecordDAPostBatch records a batch post of size bytes, failed if err is not nil
+  RecordDAPostBatch(size int, duration time.Duration, err error)
+  // RecordDAGetBatch records a batch retrieval, failed if err is not nil
+  RecordDAGetBatch(duration time.Duration, err error)
+}
+
+type NoopMetrics struct{}
+
+func (NoopMetrics) RecordDAPostBatch(int, time.Duration, error) {}
+func (NoopMetrics) RecordDAGetBatch(time.Duration, error)       {}
+
+// ErrorClass labels the result of a call by the sentinel error it wraps, "ok" if it succeeded
+func ErrorClass(err error) string {
+  switch {
+  case err == nil:
+    return "ok"
+  case errors.Is(err, ErrInvalidBatchRef):
+    return "invalid_ref"
+  case errors.Is(err, ErrBatchNotFound):
+    return "not_found"
+  case errors.Is(err, ErrMisconfigured):
+    return "misconfigured"
+  case errors.Is(err, context.Canceled):
+    return "canceled"
+  default:
+    // ErrUnavailable, and the errors of clients classifying none of their failures
+    return "unavailable"
+  }
+}
+
+type meteredClient struct {
+  Client
+  metrics Metricer
+}
+
+// NewMeteredClient wraps a client so that its calls are recorded
+func NewMeteredClient(client Client, m Metricer) Client {
+  return &meteredClient{client, m}
+}
+
+func (c *meteredClient) PostBatch(ctx context.Context, data []byte, l1Time uint64) (BatchRef, error) {
+  start := time.Now()
+  ref, err := c.Client.PostBatch(ctx, data, l1Time)
+  c.metrics.RecordDAPostBatch(len(data), time.Since(start), err)
+  return ref, err
+}
+
+func (c *meteredClient) GetBatch(ctx context.Context, ref []byte, l1Time uint64) ([]byte, error) {
+  start := time.Now()
+  data, err := c.Client.GetBatch(ctx, ref, l1Time)
+  c.metrics.RecordDAGetBatch(time.Since(start), err)
+  return data, err
+}
@@ -1,5 +1,6 @@
 package rollupda
 
+import "context"
 import "github.com/ethereum/go-ethereum/common"
 import "github.com/ethereum-optimism/optimism/da"
 
@@ -24,10 +25,10 @@ func NewClient(addr common.Address) da.Client {
   return &client{}
 }
 
-func (c *client) PostBatch(data []byte) (da.BatchRef, error) {
+func (c *client) PostBatch(ctx context.Context, data []byte, l1Time uint64) (da.BatchRef, error) {
   return &batchRef{c.addr, data}, nil
 }
 
-func (c *client) GetBatch(data []byte) ([]byte, error) {
+func (c *client) GetBatch(ctx context.Context, data []byte, l1Time uint64) ([]byte, error) {
   return data, nil
 }
@@ -1,6 +1,31 @@
 package da
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The errors of the clients wrap one of these sentinels, so that their callers can tell whether
+// a failed call should be retried, skipped or reported. Errors wrapping none of them are
+// treated as ErrUnavailable.
+var (
+  // ErrInvalidBatchRef is returned by Client.GetBatch when the ref can never resolve to valid
+  // batch data, e.g. a malformed ref or an invalid certificate.
+  // The derivation pipeline skips such refs instead of retrying them.
+  ErrInvalidBatchRef = errors.New("invalid batch ref")
+  // ErrUnavailable is returned when the DA could not be reached or did not answer properly in
+  // time, e.g. a network error, a timeout or a server error. The call may succeed later.
+  ErrUnavailable = errors.New("DA unavailable")
+  // ErrBatchNotFound is returned by Client.GetBatch when the DA answered that it does not store
+  // the batch of a valid ref, e.g. a batch pruned after its retention period.
+  // The derivation pipeline retries such batches, as they may be served by another DA node.
+  ErrBatchNotFound = errors.New("batch not found")
+  // ErrMisconfigured is returned when the client cannot serve the call whatever the DA state,
+  // e.g. a read-only client asked to post a batch.
+  ErrMisconfigured = errors.New("DA client misconfigured")
+)
 
 type Tx struct {
   To *common.Address
@@ -12,6 +37,10 @@ type BatchRef interface {
 }
 
 type Client interface {
-  PostBatch(data []byte) (BatchRef, error)
-  GetBatch(ref []byte) ([]byte, error)
+  // PostBatch makes the batch data available and returns its ref. l1Time is the timestamp of the
+  // current L1 origin, which selects the network upgrades the ref is made for.
+  PostBatch(ctx context.Context, data []byte, l1Time uint64) (BatchRef, error)
+  // GetBatch returns the batch data of a ref included in an L1 block of the given timestamp.
+  // The timestamp selects the network upgrades the ref is validated against.
+  GetBatch(ctx context.Context, ref []byte, l1Time uint64) ([]byte, error)
 }
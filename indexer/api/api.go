@@ -2,13 +2,24 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
 	"net/http"
+	"strconv"
 
 	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/op-node/withdrawals"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/go-chi/chi/v5"
 )
 
+const (
+	defaultPageLimit = 10
+	maxPageLimit     = 100
+)
+
 type PaginationResponse struct {
 	// TODO type this better
 	Data        interface{} `json:"data"`
@@ -16,29 +27,72 @@ type PaginationResponse struct {
 	HasNextPage bool        `json:"hasNextPage"`
 }
 
+// WithdrawalResponse is a withdrawal along with its status in the withdrawal lifecycle
+type WithdrawalResponse struct {
+	*database.WithdrawalWithTransactionHashes
+	Status database.WithdrawalStatus `json:"status"`
+
+	// Only set when looking up a single withdrawal
+	ProofInputs *WithdrawalProofInputs `json:"proofInputs,omitempty"`
+}
+
+// WithdrawalTransaction is the withdrawal as passed to `OptimismPortal.proveWithdrawalTransaction`
+type WithdrawalTransaction struct {
+	Nonce    *big.Int       `json:"nonce"`
+	Sender   common.Address `json:"sender"`
+	Target   common.Address `json:"target"`
+	Value    *big.Int       `json:"value"`
+	GasLimit *big.Int       `json:"gasLimit"`
+	Data     hexutil.Bytes  `json:"data"`
+}
+
+// WithdrawalProofInputs are the inputs needed to prove a withdrawal on L1. The storage proof of
+// the withdrawal is retrieved with `eth_getProof` for the storage slot of the L2ToL1MessagePasser,
+// at the L2 block of the output the withdrawal is proven against.
+type WithdrawalProofInputs struct {
+	WithdrawalTransaction WithdrawalTransaction `json:"withdrawalTransaction"`
+	L2BlockNumber         *big.Int              `json:"l2BlockNumber"`
+	StorageSlot           common.Hash           `json:"storageSlot"`
+
+	// Not set until an output has been proposed for the withdrawal's L2 block
+	L2Output *L2Output `json:"l2Output"`
+}
+
 func (a *Api) DepositsHandler(w http.ResponseWriter, r *http.Request) {
 	bv := a.bridgeView
 
 	address := common.HexToAddress(chi.URLParam(r, "address"))
 
-	// limit := getIntFromQuery(r, "limit", 10)
-	// cursor := r.URL.Query().Get("cursor")
-	// sortDirection := r.URL.Query().Get("sortDirection")
+	page, err := pageFromQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
-	deposits, err := bv.DepositsByAddress(address)
+	token, err := tokenFromQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
+	// query one over the limit to know if there is a next page
+	limit := page.Limit
+	page.Limit = limit + 1
+	deposits, err := bv.DepositsByAddress(address, database.DepositFilter{Token: token}, page)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	// This is not the shape of the response we want!!!
-	// will add in the individual features in future prs 1 by 1
-	response := PaginationResponse{
-		Data: deposits,
-		// Cursor:      nextCursor,
-		HasNextPage: false,
+	response := PaginationResponse{}
+	if len(deposits) > limit {
+		deposits = deposits[:limit]
+		response.HasNextPage = true
+	}
+	if len(deposits) > 0 {
+		response.Cursor = deposits[len(deposits)-1].Deposit.GUID
 	}
+	response.Data = deposits
 
 	jsonResponse(w, response, http.StatusOK)
 }
@@ -48,28 +102,148 @@ func (a *Api) WithdrawalsHandler(w http.ResponseWriter, r *http.Request) {
 
 	address := common.HexToAddress(chi.URLParam(r, "address"))
 
-	// limit := getIntFromQuery(r, "limit", 10)
-	// cursor := r.URL.Query().Get("cursor")
-	// sortDirection := r.URL.Query().Get("sortDirection")
+	page, err := pageFromQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	token, err := tokenFromQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	status := database.WithdrawalStatus(r.URL.Query().Get("status"))
+	switch status {
+	case "", database.WithdrawalStatusInitiated, database.WithdrawalStatusReadyToProve, database.WithdrawalStatusProven,
+		database.WithdrawalStatusReadyToFinalize, database.WithdrawalStatusFinalized:
+	default:
+		http.Error(w, fmt.Sprintf("invalid status %q", status), http.StatusBadRequest)
+		return
+	}
 
-	withdrawals, err := bv.WithdrawalsByAddress(address)
+	lifecycle, err := a.l2OutputOracleView.WithdrawalLifecycle()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 
+	// query one over the limit to know if there is a next page
+	limit := page.Limit
+	page.Limit = limit + 1
+	filter := database.WithdrawalFilter{Token: token, Status: status, Lifecycle: lifecycle}
+	withdrawals, err := bv.WithdrawalsByAddress(address, filter, page)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	// This is not the shape of the response we want!!!
-	// will add in the individual features in future prs 1 by 1
-	response := PaginationResponse{
-		Data: withdrawals,
-		// Cursor:      nextCursor,
-		HasNextPage: false,
+	response := PaginationResponse{}
+	if len(withdrawals) > limit {
+		withdrawals = withdrawals[:limit]
+		response.HasNextPage = true
+	}
+	if len(withdrawals) > 0 {
+		response.Cursor = withdrawals[len(withdrawals)-1].Withdrawal.GUID
+	}
+
+	withdrawalResponses := make([]*WithdrawalResponse, len(withdrawals))
+	for i, withdrawal := range withdrawals {
+		withdrawalResponses[i] = &WithdrawalResponse{WithdrawalWithTransactionHashes: withdrawal, Status: lifecycle.Status(withdrawal)}
 	}
+	response.Data = withdrawalResponses
 
 	jsonResponse(w, response, http.StatusOK)
 }
 
+func (a *Api) WithdrawalHandler(w http.ResponseWriter, r *http.Request) {
+	bv := a.bridgeView
+
+	withdrawalHash := common.HexToHash(chi.URLParam(r, "hash"))
+	withdrawal, err := bv.WithdrawalWithTransactionHashesByHash(withdrawalHash)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	} else if withdrawal == nil {
+		http.Error(w, "withdrawal not found", http.StatusNotFound)
+		return
+	}
+
+	lifecycle, err := a.l2OutputOracleView.WithdrawalLifecycle()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	message := withdrawal.Withdrawal.Message
+	proofInputs := &WithdrawalProofInputs{
+		WithdrawalTransaction: WithdrawalTransaction{
+			Nonce:    message.Nonce.Int,
+			Sender:   message.Sender,
+			Target:   message.Target,
+			Value:    message.Value.Int,
+			GasLimit: message.GasLimit.Int,
+			Data:     message.Data,
+		},
+		L2BlockNumber: withdrawal.L2BlockNumber.Int,
+		StorageSlot:   withdrawals.StorageSlotOfWithdrawalHash(withdrawalHash),
+	}
+
+	status := lifecycle.Status(withdrawal)
+	if status == database.WithdrawalStatusReadyToProve {
+		proofInputs.L2Output, err = a.l2OutputOracleView.L2OutputAfter(withdrawal.L2BlockNumber.Int)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	response := WithdrawalResponse{WithdrawalWithTransactionHashes: withdrawal, Status: status, ProofInputs: proofInputs}
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// OutputHandler returns the first output proposed for the L2 block or a later one, which
+// covers the L2 block
+func (a *Api) OutputHandler(w http.ResponseWriter, r *http.Request) {
+	l2BlockNumber, ok := new(big.Int).SetString(chi.URLParam(r, "l2BlockNumber"), 10)
+	if !ok || l2BlockNumber.Sign() < 0 {
+		http.Error(w, "invalid l2 block number", http.StatusBadRequest)
+		return
+	}
+
+	output, err := a.outputProposalsView.OutputProposalForL2Block(l2BlockNumber)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	} else if output == nil {
+		http.Error(w, "output not found", http.StatusNotFound)
+		return
+	}
+
+	jsonResponse(w, output, http.StatusOK)
+}
+
+// BatchSubmissionHandler returns the batch inbox transaction that made the L2 block safe
+func (a *Api) BatchSubmissionHandler(w http.ResponseWriter, r *http.Request) {
+	l2BlockNumber, ok := new(big.Int).SetString(chi.URLParam(r, "l2BlockNumber"), 10)
+	if !ok || l2BlockNumber.Sign() < 0 {
+		http.Error(w, "invalid l2 block number", http.StatusBadRequest)
+		return
+	}
+
+	submission, err := a.batchesView.BatchSubmissionForL2Block(l2BlockNumber)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	} else if submission == nil {
+		http.Error(w, "batch submission not found", http.StatusNotFound)
+		return
+	}
+
+	jsonResponse(w, submission, http.StatusOK)
+}
+
 func (a *Api) HealthzHandler(w http.ResponseWriter, r *http.Request) {
 	jsonResponse(w, "ok", http.StatusOK)
 }
@@ -83,22 +257,31 @@ func jsonResponse(w http.ResponseWriter, data interface{}, statusCode int) {
 }
 
 type Api struct {
-	Router     *chi.Mux
-	bridgeView database.BridgeView
+	Router              *chi.Mux
+	bridgeView          database.BridgeView
+	l2OutputOracleView  L2OutputOracleView
+	outputProposalsView database.OutputProposalsView
+	batchesView         database.BatchesView
 }
 
-func NewApi(bv database.BridgeView) *Api {
+func NewApi(bv database.BridgeView, ov L2OutputOracleView, opv database.OutputProposalsView, btv database.BatchesView) *Api {
 	r := chi.NewRouter()
 
 	api := &Api{
-		Router:     r,
-		bridgeView: bv,
+		Router:              r,
+		bridgeView:          bv,
+		l2OutputOracleView:  ov,
+		outputProposalsView: opv,
+		batchesView:         btv,
 	}
 	// these regex are .+ because I wasn't sure what they should be
 	// don't want a regex for addresses because would prefer to validate the address
 	// with go-ethereum and throw a friendly error message
 	r.Get("/api/v0/deposits/{address:.+}", api.DepositsHandler)
 	r.Get("/api/v0/withdrawals/{address:.+}", api.WithdrawalsHandler)
+	r.Get("/api/v0/withdrawal/{hash:.+}", api.WithdrawalHandler)
+	r.Get("/api/v0/output/{l2BlockNumber}", api.OutputHandler)
+	r.Get("/api/v0/batch-submission/{l2BlockNumber}", api.BatchSubmissionHandler)
 	r.Get("/healthz", api.HealthzHandler)
 
 	return api
@@ -108,3 +291,40 @@ func NewApi(bv database.BridgeView) *Api {
 func (a *Api) Listen(port string) error {
 	return http.ListenAndServe(port, a.Router)
 }
+
+// pageFromQuery parses the `limit`, `cursor` and `sortDirection` query parameters
+func pageFromQuery(r *http.Request) (database.Page, error) {
+	query := r.URL.Query()
+	page := database.Page{Cursor: query.Get("cursor"), Limit: defaultPageLimit}
+
+	if limit := query.Get("limit"); limit != "" {
+		parsedLimit, err := strconv.Atoi(limit)
+		if err != nil || parsedLimit <= 0 || parsedLimit > maxPageLimit {
+			return page, fmt.Errorf("limit must be between 1 and %d", maxPageLimit)
+		}
+		page.Limit = parsedLimit
+	}
+
+	switch query.Get("sortDirection") {
+	case "", "desc":
+	case "asc":
+		page.Ascending = true
+	default:
+		return page, errors.New("sortDirection must be either asc or desc")
+	}
+
+	return page, nil
+}
+
+// tokenFromQuery parses the optional `token` query parameter
+func tokenFromQuery(r *http.Request) (*common.Address, error) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		return nil, nil
+	} else if !common.IsHexAddress(token) {
+		return nil, fmt.Errorf("invalid token address %q", token)
+	}
+
+	address := common.HexToAddress(token)
+	return &address, nil
+}
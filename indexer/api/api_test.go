@@ -1,6 +1,8 @@
 package api
 
 import (
+	"encoding/json"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -14,7 +16,7 @@ import (
 type MockBridgeView struct{}
 
 // DepositsByAddress mocks returning deposits by an address
-func (mbv *MockBridgeView) DepositsByAddress(address common.Address) ([]*database.DepositWithTransactionHash, error) {
+func (mbv *MockBridgeView) DepositsByAddress(address common.Address, filter database.DepositFilter, page database.Page) ([]*database.DepositWithTransactionHash, error) {
 	return []*database.DepositWithTransactionHash{
 		{
 			Deposit: database.Deposit{
@@ -29,23 +31,94 @@ func (mbv *MockBridgeView) DepositsByAddress(address common.Address) ([]*databas
 }
 
 // WithdrawalsByAddress mocks returning withdrawals by an address
-func (mbv *MockBridgeView) WithdrawalsByAddress(address common.Address) ([]*database.WithdrawalWithTransactionHashes, error) {
-	return []*database.WithdrawalWithTransactionHashes{
-		{
-			Withdrawal: database.Withdrawal{
-				GUID:                 "mockGUID2",
-				InitiatedL2EventGUID: "mockEventGUID2",
-				WithdrawalHash:       common.HexToHash("0x456"),
-				Tx:                   database.Transaction{},
-				TokenPair:            database.TokenPair{},
+func (mbv *MockBridgeView) WithdrawalsByAddress(address common.Address, filter database.WithdrawalFilter, page database.Page) ([]*database.WithdrawalWithTransactionHashes, error) {
+	return []*database.WithdrawalWithTransactionHashes{mockWithdrawal("mockGUID2", 5), mockWithdrawal("mockGUID3", 15)}, nil
+}
+
+// WithdrawalByHash mocks returning a withdrawal by its withdrawal hash
+func (mbv *MockBridgeView) WithdrawalByHash(withdrawalHash common.Hash) (*database.Withdrawal, error) {
+	return &mockWithdrawal("mockGUID2", 5).Withdrawal, nil
+}
+
+// WithdrawalWithTransactionHashesByHash mocks returning a withdrawal by its withdrawal hash
+func (mbv *MockBridgeView) WithdrawalWithTransactionHashesByHash(withdrawalHash common.Hash) (*database.WithdrawalWithTransactionHashes, error) {
+	if withdrawalHash != common.HexToHash("0x456") {
+		return nil, nil
+	}
+	return mockWithdrawal("mockGUID2", 5), nil
+}
+
+func mockWithdrawal(guid string, l2BlockNumber int64) *database.WithdrawalWithTransactionHashes {
+	return &database.WithdrawalWithTransactionHashes{
+		Withdrawal: database.Withdrawal{
+			GUID:                 guid,
+			InitiatedL2EventGUID: "mockEventGUID2",
+			WithdrawalHash:       common.HexToHash("0x456"),
+			Tx:                   database.Transaction{},
+			TokenPair:            database.TokenPair{},
+			Message: database.WithdrawalMessage{
+				Nonce:    database.U256{Int: big.NewInt(1)},
+				Value:    database.U256{Int: big.NewInt(0)},
+				GasLimit: database.U256{Int: big.NewInt(100_000)},
 			},
-			L2TransactionHash: common.HexToHash("0x789"),
 		},
+		L2TransactionHash: common.HexToHash("0x789"),
+		L2BlockNumber:     database.U256{Int: big.NewInt(l2BlockNumber)},
+	}
+}
+
+// MockL2OutputOracleView mocks the L2OutputOracleView interface with outputs
+// proposed up to L2 block 10, every 10 blocks
+type MockL2OutputOracleView struct{}
+
+// WithdrawalLifecycle mocks returning the lifecycle of withdrawals
+func (mov *MockL2OutputOracleView) WithdrawalLifecycle() (database.WithdrawalLifecycle, error) {
+	return database.WithdrawalLifecycle{LatestOutputL2BlockNumber: big.NewInt(10), FinalizationPeriodSeconds: 12, L1Timestamp: 100}, nil
+}
+
+// L2OutputAfter mocks returning the output proposed for an L2 block
+func (mov *MockL2OutputOracleView) L2OutputAfter(l2BlockNumber *big.Int) (*L2Output, error) {
+	if l2BlockNumber.Cmp(big.NewInt(10)) > 0 {
+		return nil, nil
+	}
+	return &L2Output{Index: big.NewInt(0), L2BlockNumber: big.NewInt(10)}, nil
+}
+
+// MockOutputProposalsView mocks the OutputProposalsView interface with
+// a single output proposed for L2 block 10
+type MockOutputProposalsView struct{}
+
+// OutputProposalForL2Block mocks returning the output covering an L2 block
+func (mopv *MockOutputProposalsView) OutputProposalForL2Block(l2BlockNumber *big.Int) (*database.OutputProposalWithTransactionHash, error) {
+	if l2BlockNumber.Cmp(big.NewInt(10)) > 0 {
+		return nil, nil
+	}
+	return &database.OutputProposalWithTransactionHash{
+		OutputProposal: database.OutputProposal{
+			GUID:                "mockGUID4",
+			ProposedL1EventGUID: "mockEventGUID4",
+			OutputRoot:          common.HexToHash("0xabc"),
+			L2OutputIndex:       database.U256{Int: big.NewInt(0)},
+			L2BlockNumber:       database.U256{Int: big.NewInt(10)},
+		},
+		L1TransactionHash: common.HexToHash("0xdef"),
 	}, nil
 }
 
+// MockBatchesView mocks the BatchesView interface with L2 blocks
+// made safe up to L2 block 10
+type MockBatchesView struct{}
+
+// BatchSubmissionForL2Block mocks returning the submission making an L2 block safe
+func (mbtv *MockBatchesView) BatchSubmissionForL2Block(l2BlockNumber *big.Int) (*database.BatchSubmission, error) {
+	if l2BlockNumber.Cmp(big.NewInt(10)) > 0 {
+		return nil, nil
+	}
+	return &database.BatchSubmission{TransactionHash: common.HexToHash("0xfed"), DataType: 1}, nil
+}
+
 func TestHealthz(t *testing.T) {
-	api := NewApi(&MockBridgeView{})
+	api := NewApi(&MockBridgeView{}, &MockL2OutputOracleView{}, &MockOutputProposalsView{}, &MockBatchesView{})
 	request, err := http.NewRequest("GET", "/healthz", nil)
 	assert.Nil(t, err)
 
@@ -56,7 +129,7 @@ func TestHealthz(t *testing.T) {
 }
 
 func TestDepositsHandler(t *testing.T) {
-	api := NewApi(&MockBridgeView{})
+	api := NewApi(&MockBridgeView{}, &MockL2OutputOracleView{}, &MockOutputProposalsView{}, &MockBatchesView{})
 	request, err := http.NewRequest("GET", "/api/v0/deposits/0x123", nil)
 	assert.Nil(t, err)
 
@@ -67,7 +140,7 @@ func TestDepositsHandler(t *testing.T) {
 }
 
 func TestWithdrawalsHandler(t *testing.T) {
-	api := NewApi(&MockBridgeView{})
+	api := NewApi(&MockBridgeView{}, &MockL2OutputOracleView{}, &MockOutputProposalsView{}, &MockBatchesView{})
 	request, err := http.NewRequest("GET", "/api/v0/withdrawals/0x123", nil)
 	assert.Nil(t, err)
 
@@ -76,3 +149,115 @@ func TestWithdrawalsHandler(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, responseRecorder.Code)
 }
+
+func TestDepositsHandlerInvalidQuery(t *testing.T) {
+	api := NewApi(&MockBridgeView{}, &MockL2OutputOracleView{}, &MockOutputProposalsView{}, &MockBatchesView{})
+	for _, query := range []string{"limit=0", "limit=101", "limit=ten", "sortDirection=up", "token=0x123"} {
+		request, err := http.NewRequest("GET", "/api/v0/deposits/0x123?"+query, nil)
+		assert.Nil(t, err)
+
+		responseRecorder := httptest.NewRecorder()
+		api.Router.ServeHTTP(responseRecorder, request)
+
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code, query)
+	}
+}
+
+func TestWithdrawalsHandlerPagination(t *testing.T) {
+	api := NewApi(&MockBridgeView{}, &MockL2OutputOracleView{}, &MockOutputProposalsView{}, &MockBatchesView{})
+	request, err := http.NewRequest("GET", "/api/v0/withdrawals/0x123?limit=1&status=ready-to-prove", nil)
+	assert.Nil(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	api.Router.ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var response struct {
+		Data []struct {
+			Status string `json:"status"`
+		} `json:"data"`
+		Cursor      string `json:"cursor"`
+		HasNextPage bool   `json:"hasNextPage"`
+	}
+	assert.Nil(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+	assert.Len(t, response.Data, 1)
+	assert.Equal(t, "ready-to-prove", response.Data[0].Status)
+	assert.Equal(t, "mockGUID2", response.Cursor)
+	assert.True(t, response.HasNextPage)
+
+	request, err = http.NewRequest("GET", "/api/v0/withdrawals/0x123?status=unknown", nil)
+	assert.Nil(t, err)
+
+	responseRecorder = httptest.NewRecorder()
+	api.Router.ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+}
+
+func TestWithdrawalHandler(t *testing.T) {
+	api := NewApi(&MockBridgeView{}, &MockL2OutputOracleView{}, &MockOutputProposalsView{}, &MockBatchesView{})
+	request, err := http.NewRequest("GET", "/api/v0/withdrawal/0x456", nil)
+	assert.Nil(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	api.Router.ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var response WithdrawalResponse
+	assert.Nil(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+	assert.Equal(t, database.WithdrawalStatusReadyToProve, response.Status)
+	assert.Equal(t, big.NewInt(1), response.ProofInputs.WithdrawalTransaction.Nonce)
+	assert.Equal(t, big.NewInt(5), response.ProofInputs.L2BlockNumber)
+	assert.Equal(t, &L2Output{Index: big.NewInt(0), L2BlockNumber: big.NewInt(10)}, response.ProofInputs.L2Output)
+
+	request, err = http.NewRequest("GET", "/api/v0/withdrawal/0x789", nil)
+	assert.Nil(t, err)
+
+	responseRecorder = httptest.NewRecorder()
+	api.Router.ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+}
+
+func TestOutputHandler(t *testing.T) {
+	api := NewApi(&MockBridgeView{}, &MockL2OutputOracleView{}, &MockOutputProposalsView{}, &MockBatchesView{})
+	request, err := http.NewRequest("GET", "/api/v0/output/5", nil)
+	assert.Nil(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	api.Router.ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var output database.OutputProposalWithTransactionHash
+	assert.Nil(t, json.Unmarshal(responseRecorder.Body.Bytes(), &output))
+	assert.Equal(t, common.HexToHash("0xabc"), output.OutputProposal.OutputRoot)
+	assert.Equal(t, common.HexToHash("0xdef"), output.L1TransactionHash)
+
+	for query, code := range map[string]int{"11": http.StatusNotFound, "0x5": http.StatusBadRequest, "-1": http.StatusBadRequest} {
+		request, err := http.NewRequest("GET", "/api/v0/output/"+query, nil)
+		assert.Nil(t, err)
+
+		responseRecorder := httptest.NewRecorder()
+		api.Router.ServeHTTP(responseRecorder, request)
+		assert.Equal(t, code, responseRecorder.Code, query)
+	}
+}
+
+func TestBatchSubmissionHandler(t *testing.T) {
+	api := NewApi(&MockBridgeView{}, &MockL2OutputOracleView{}, &MockOutputProposalsView{}, &MockBatchesView{})
+	request, err := http.NewRequest("GET", "/api/v0/batch-submission/10", nil)
+	assert.Nil(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	api.Router.ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var submission database.BatchSubmission
+	assert.Nil(t, json.Unmarshal(responseRecorder.Body.Bytes(), &submission))
+	assert.Equal(t, common.HexToHash("0xfed"), submission.TransactionHash)
+
+	request, err = http.NewRequest("GET", "/api/v0/batch-submission/11", nil)
+	assert.Nil(t, err)
+
+	responseRecorder = httptest.NewRecorder()
+	api.Router.ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+}
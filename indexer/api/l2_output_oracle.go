@@ -0,0 +1,116 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// defaultRequestTimeout is the duration to wait on the L1 node for a request to be fulfilled
+const defaultRequestTimeout = 10 * time.Second
+
+// L2Output identifies the output proposal a withdrawal is proven against
+type L2Output struct {
+	Index         *big.Int `json:"index"`
+	L2BlockNumber *big.Int `json:"l2BlockNumber"`
+}
+
+// L2OutputOracleView reads the L1 state of the L2OutputOracle, from which the
+// lifecycle of withdrawals is derived
+type L2OutputOracleView interface {
+	WithdrawalLifecycle() (database.WithdrawalLifecycle, error)
+
+	// L2OutputAfter returns the first output proposed for the supplied L2 block or
+	// a later one, nil if no such output has been proposed yet
+	L2OutputAfter(l2BlockNumber *big.Int) (*L2Output, error)
+}
+
+type l2OutputOracleView struct {
+	l1Client       *ethclient.Client
+	l2OutputOracle *bindings.L2OutputOracleCaller
+}
+
+func NewL2OutputOracleView(l1Client *ethclient.Client, l2OutputOracleAddress common.Address) (L2OutputOracleView, error) {
+	l2OutputOracle, err := bindings.NewL2OutputOracleCaller(l2OutputOracleAddress, l1Client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &l2OutputOracleView{l1Client: l1Client, l2OutputOracle: l2OutputOracle}, nil
+}
+
+func (v *l2OutputOracleView) WithdrawalLifecycle() (database.WithdrawalLifecycle, error) {
+	ctxwt, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
+	defer cancel()
+
+	// read the oracle state as of the latest L1 block
+	l1Header, err := v.l1Client.HeaderByNumber(ctxwt, nil)
+	if err != nil {
+		return database.WithdrawalLifecycle{}, err
+	}
+
+	opts := &bind.CallOpts{Context: ctxwt, BlockNumber: l1Header.Number}
+	finalizationPeriod, err := v.l2OutputOracle.FINALIZATIONPERIODSECONDS(opts)
+	if err != nil {
+		return database.WithdrawalLifecycle{}, err
+	}
+
+	lifecycle := database.WithdrawalLifecycle{
+		FinalizationPeriodSeconds: finalizationPeriod.Uint64(),
+		L1Timestamp:               l1Header.Time,
+	}
+
+	// the latest block number is the starting block until an output is proposed
+	nextOutputIndex, err := v.l2OutputOracle.NextOutputIndex(opts)
+	if err != nil {
+		return database.WithdrawalLifecycle{}, err
+	} else if nextOutputIndex.Sign() > 0 {
+		lifecycle.LatestOutputL2BlockNumber, err = v.l2OutputOracle.LatestBlockNumber(opts)
+		if err != nil {
+			return database.WithdrawalLifecycle{}, err
+		}
+	}
+
+	return lifecycle, nil
+}
+
+func (v *l2OutputOracleView) L2OutputAfter(l2BlockNumber *big.Int) (*L2Output, error) {
+	ctxwt, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
+	defer cancel()
+
+	opts := &bind.CallOpts{Context: ctxwt}
+	latestBlockNumber, err := v.l2OutputOracle.LatestBlockNumber(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// the oracle reverts when querying past the latest output
+	nextOutputIndex, err := v.l2OutputOracle.NextOutputIndex(opts)
+	if err != nil {
+		return nil, err
+	} else if nextOutputIndex.Sign() == 0 || latestBlockNumber.Cmp(l2BlockNumber) < 0 {
+		return nil, nil
+	}
+
+	index, err := v.l2OutputOracle.GetL2OutputIndexAfter(opts, l2BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := v.l2OutputOracle.GetL2Output(opts, index)
+	if err != nil {
+		return nil, err
+	} else if output.L2BlockNumber.Cmp(l2BlockNumber) < 0 {
+		return nil, errors.New("output proposed prior to the l2 block")
+	}
+
+	return &L2Output{Index: index, L2BlockNumber: output.L2BlockNumber}, nil
+}
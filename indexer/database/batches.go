@@ -0,0 +1,193 @@
+package database
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"gorm.io/gorm"
+)
+
+/**
+ * Types
+ */
+
+// BatchSubmission is a transaction sent by the batcher to the batch inbox
+type BatchSubmission struct {
+	TransactionHash  common.Hash `gorm:"primaryKey;serializer:json"`
+	L1BlockHash      common.Hash `gorm:"serializer:json"`
+	TransactionIndex uint64
+	Timestamp        uint64
+
+	// DataType is the first byte of the calldata, either the derivation version of
+	// the frames or the header of the DA batch ref carried by the transaction
+	DataType uint8
+
+	// The unverified certificate of a DAC batch ref, only set for DAC submissions
+	DACVersion    *uint8
+	DACDataHash   *common.Hash    `gorm:"serializer:json"`
+	DACSignerMask *hexutil.Uint64 `gorm:"serializer:json"`
+}
+
+// BatchFrame is a frame of a channel that is not yet complete, or whose completing
+// submission is not yet finalized
+type BatchFrame struct {
+	GUID            string      `gorm:"primaryKey"`
+	TransactionHash common.Hash `gorm:"serializer:json"`
+
+	ChannelID   hexutil.Bytes `gorm:"serializer:json"`
+	FrameNumber uint16
+	Data        hexutil.Bytes `gorm:"serializer:json"`
+	IsLast      bool
+}
+
+// BatchChannel is a complete channel. The L2 blocks with a timestamp within
+// [FirstL2Timestamp, LastL2Timestamp] are made safe by the submission completing it
+type BatchChannel struct {
+	GUID                     string        `gorm:"primaryKey"`
+	ChannelID                hexutil.Bytes `gorm:"serializer:json"`
+	CompletedTransactionHash common.Hash   `gorm:"serializer:json"`
+
+	FirstL2Timestamp uint64
+	LastL2Timestamp  uint64
+}
+
+type BatchesView interface {
+	// BatchSubmissionForL2Block returns the submission completing the channel
+	// of the supplied L2 block, nil if the block is not known to be safe
+	BatchSubmissionForL2Block(*big.Int) (*BatchSubmission, error)
+}
+
+type BatchesDB interface {
+	BatchesView
+
+	StoreBatchSubmissions([]*BatchSubmission) error
+
+	StoreBatchFrames([]*BatchFrame) error
+	BatchFramesByChannelID([]byte) ([]*BatchFrame, error)
+	DeleteBatchFramesCompletedUntil(*big.Int) error
+
+	StoreBatchChannel(*BatchChannel) error
+	BatchChannelByID([]byte) (*BatchChannel, error)
+
+	RollbackL1BlocksAfter(*big.Int) error
+}
+
+/**
+ * Implementation
+ */
+
+type batchesDB struct {
+	gorm *gorm.DB
+}
+
+func newBatchesDB(db *gorm.DB) BatchesDB {
+	return &batchesDB{gorm: db}
+}
+
+// Submissions
+
+func (db *batchesDB) StoreBatchSubmissions(submissions []*BatchSubmission) error {
+	result := db.gorm.Create(&submissions)
+	return result.Error
+}
+
+func (db *batchesDB) BatchSubmissionForL2Block(l2BlockNumber *big.Int) (*BatchSubmission, error) {
+	l2Header := db.gorm.Model(&L2BlockHeader{}).Select("timestamp").Where("number = ?", U256{Int: l2BlockNumber})
+
+	channelsQuery := db.gorm.Table("batch_channels").Select("batch_submissions.*")
+	submissionsJoinQuery := channelsQuery.Joins("INNER JOIN batch_submissions ON batch_channels.completed_transaction_hash = batch_submissions.transaction_hash")
+	filteredQuery := submissionsJoinQuery.Where("(?) BETWEEN batch_channels.first_l2_timestamp AND batch_channels.last_l2_timestamp", l2Header)
+
+	// a block batched more than once is made safe by the first submission
+	var submission BatchSubmission
+	result := filteredQuery.Order("batch_submissions.timestamp ASC, batch_submissions.transaction_index ASC").Take(&submission)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, result.Error
+	}
+
+	return &submission, nil
+}
+
+// Frames
+
+func (db *batchesDB) StoreBatchFrames(frames []*BatchFrame) error {
+	result := db.gorm.Create(&frames)
+	return result.Error
+}
+
+// BatchFramesByChannelID returns the stored frames of the channel, in the order they were submitted
+func (db *batchesDB) BatchFramesByChannelID(channelID []byte) ([]*BatchFrame, error) {
+	framesQuery := db.gorm.Table("batch_frames").Select("batch_frames.*")
+	submissionsJoinQuery := framesQuery.Joins("INNER JOIN batch_submissions ON batch_frames.transaction_hash = batch_submissions.transaction_hash")
+
+	var frames []*BatchFrame
+	filteredQuery := submissionsJoinQuery.Where(&BatchFrame{ChannelID: channelID})
+	result := filteredQuery.Order("batch_submissions.timestamp ASC, batch_submissions.transaction_index ASC").Scan(&frames)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return frames, nil
+}
+
+// DeleteBatchFramesCompletedUntil removes the frames of the channels completed by a submission
+// included in the L1 blocks up to the supplied height
+func (db *batchesDB) DeleteBatchFramesCompletedUntil(number *big.Int) error {
+	l1BlockHashes := db.gorm.Model(&L1BlockHeader{}).Select("hash").Where("number <= ?", U256{Int: number})
+	submissions := db.gorm.Model(&BatchSubmission{}).Select("transaction_hash").Where("l1_block_hash IN (?)", l1BlockHashes)
+	channels := db.gorm.Model(&BatchChannel{}).Select("channel_id").Where("completed_transaction_hash IN (?)", submissions)
+
+	result := db.gorm.Where("channel_id IN (?)", channels).Delete(&BatchFrame{})
+	return result.Error
+}
+
+// Channels
+
+func (db *batchesDB) StoreBatchChannel(channel *BatchChannel) error {
+	result := db.gorm.Create(channel)
+	return result.Error
+}
+
+// BatchChannelByID returns the complete channel with the supplied id, nil otherwise
+func (db *batchesDB) BatchChannelByID(channelID []byte) (*BatchChannel, error) {
+	var channel BatchChannel
+	result := db.gorm.Where(&BatchChannel{ChannelID: channelID}).Take(&channel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, result.Error
+	}
+
+	return &channel, nil
+}
+
+// Rollbacks
+
+// RollbackL1BlocksAfter removes the submissions, and the frames & channels they carried,
+// included in the L1 blocks above the supplied height. The frames of a channel completed by a
+// removed submission are kept until its completing submission is finalized, so the channel is
+// completed again by the next submission of its removed frames.
+func (db *batchesDB) RollbackL1BlocksAfter(number *big.Int) error {
+	submissions := db.gorm.Model(&BatchSubmission{}).Select("transaction_hash").Where("l1_block_hash IN (?)", l1BlockHashesAfter(db.gorm, number))
+
+	result := db.gorm.Where("completed_transaction_hash IN (?)", submissions).Delete(&BatchChannel{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	result = db.gorm.Where("transaction_hash IN (?)", submissions).Delete(&BatchFrame{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	result = db.gorm.Where("l1_block_hash IN (?)", l1BlockHashesAfter(db.gorm, number)).Delete(&BatchSubmission{})
+	return result.Error
+}
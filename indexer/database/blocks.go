@@ -47,7 +47,10 @@ type LegacyStateBatch struct {
 
 type BlocksView interface {
 	FinalizedL1BlockHeader() (*L1BlockHeader, error)
+	L1BlockHeaderByNumber(*big.Int) (*L1BlockHeader, error)
+
 	FinalizedL2BlockHeader() (*L2BlockHeader, error)
+	L2BlockHeaderByNumber(*big.Int) (*L2BlockHeader, error)
 }
 
 type BlocksDB interface {
@@ -55,9 +58,11 @@ type BlocksDB interface {
 
 	StoreL1BlockHeaders([]*L1BlockHeader) error
 	StoreLegacyStateBatch(*LegacyStateBatch) error
+	DeleteL1BlockHeadersAfter(*big.Int) error
 
 	StoreL2BlockHeaders([]*L2BlockHeader) error
 	MarkFinalizedL1RootForL2Block(common.Hash, common.Hash) error
+	DeleteL2BlockHeadersAfter(*big.Int) error
 }
 
 /**
@@ -123,6 +128,28 @@ func (db *blocksDB) FinalizedL1BlockHeader() (*L1BlockHeader, error) {
 	return &l1Header, nil
 }
 
+// L1BlockHeaderByNumber returns the L1 block header stored at the supplied height, nil otherwise
+func (db *blocksDB) L1BlockHeaderByNumber(number *big.Int) (*L1BlockHeader, error) {
+	var l1Header L1BlockHeader
+	result := db.gorm.Where("number = ?", U256{Int: number}).Take(&l1Header)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, result.Error
+	}
+
+	return &l1Header, nil
+}
+
+// DeleteL1BlockHeadersAfter removes the L1 block headers stored above the supplied height. Any
+// rows referencing these headers must be removed beforehand
+func (db *blocksDB) DeleteL1BlockHeadersAfter(number *big.Int) error {
+	result := db.gorm.Where("number > ?", U256{Int: number}).Delete(&L1BlockHeader{})
+	return result.Error
+}
+
 // L2
 
 func (db *blocksDB) StoreL2BlockHeaders(headers []*L2BlockHeader) error {
@@ -161,3 +188,25 @@ func (db *blocksDB) MarkFinalizedL1RootForL2Block(l2Root, l1Root common.Hash) er
 	result = db.gorm.Save(&l2Header)
 	return result.Error
 }
+
+// L2BlockHeaderByNumber returns the L2 block header stored at the supplied height, nil otherwise
+func (db *blocksDB) L2BlockHeaderByNumber(number *big.Int) (*L2BlockHeader, error) {
+	var l2Header L2BlockHeader
+	result := db.gorm.Where("number = ?", U256{Int: number}).Take(&l2Header)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, result.Error
+	}
+
+	return &l2Header, nil
+}
+
+// DeleteL2BlockHeadersAfter removes the L2 block headers stored above the supplied height. Any
+// rows referencing these headers must be removed beforehand
+func (db *blocksDB) DeleteL2BlockHeadersAfter(number *big.Int) error {
+	result := db.gorm.Where("number > ?", U256{Int: number}).Delete(&L2BlockHeader{})
+	return result.Error
+}
@@ -2,6 +2,8 @@ package database
 
 import (
 	"errors"
+	"fmt"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -38,6 +40,17 @@ type DepositWithTransactionHash struct {
 	L1TransactionHash common.Hash `gorm:"serializer:json"`
 }
 
+// WithdrawalMessage is the message passed through the L2ToL1MessagePasser,
+// which is proven and finalized on L1
+type WithdrawalMessage struct {
+	Nonce    U256
+	Sender   common.Address `gorm:"serializer:json"`
+	Target   common.Address `gorm:"serializer:json"`
+	Value    U256
+	GasLimit U256
+	Data     hexutil.Bytes `gorm:"serializer:json"`
+}
+
 type Withdrawal struct {
 	GUID                 string `gorm:"primaryKey"`
 	InitiatedL2EventGUID string
@@ -46,21 +59,96 @@ type Withdrawal struct {
 	ProvenL1EventGUID    *string
 	FinalizedL1EventGUID *string
 
-	Tx        Transaction `gorm:"embedded"`
-	TokenPair TokenPair   `gorm:"embedded"`
+	Tx        Transaction       `gorm:"embedded"`
+	TokenPair TokenPair         `gorm:"embedded"`
+	Message   WithdrawalMessage `gorm:"embedded;embeddedPrefix:message_"`
 }
 
 type WithdrawalWithTransactionHashes struct {
 	Withdrawal        Withdrawal  `gorm:"embedded"`
 	L2TransactionHash common.Hash `gorm:"serializer:json"`
+	L2BlockNumber     U256
 
 	ProvenL1TransactionHash    *common.Hash `gorm:"serializer:json"`
+	ProvenL1Timestamp          *uint64
 	FinalizedL1TransactionHash *common.Hash `gorm:"serializer:json"`
 }
 
+// WithdrawalStatus is the stage of a withdrawal in its lifecycle
+type WithdrawalStatus string
+
+const (
+	WithdrawalStatusInitiated       WithdrawalStatus = "initiated"
+	WithdrawalStatusReadyToProve    WithdrawalStatus = "ready-to-prove"
+	WithdrawalStatusProven          WithdrawalStatus = "proven"
+	WithdrawalStatusReadyToFinalize WithdrawalStatus = "ready-to-finalize"
+	WithdrawalStatusFinalized       WithdrawalStatus = "finalized"
+)
+
+// WithdrawalLifecycle is the L1 state the status of a withdrawal is derived from
+type WithdrawalLifecycle struct {
+	// LatestOutputL2BlockNumber is the L2 block of the latest output proposed to the
+	// L2OutputOracle. Withdrawals initiated up to this block can be proven.
+	LatestOutputL2BlockNumber *big.Int
+
+	// Proven withdrawals can be finalized once the finalization period has passed
+	// by the latest L1 block timestamp
+	FinalizationPeriodSeconds uint64
+	L1Timestamp               uint64
+}
+
+// Status returns the status of the withdrawal
+func (l WithdrawalLifecycle) Status(withdrawal *WithdrawalWithTransactionHashes) WithdrawalStatus {
+	switch {
+	case withdrawal.Withdrawal.FinalizedL1EventGUID != nil:
+		return WithdrawalStatusFinalized
+	case withdrawal.Withdrawal.ProvenL1EventGUID != nil:
+		if withdrawal.ProvenL1Timestamp != nil && *withdrawal.ProvenL1Timestamp <= l.finalizableTimestamp() {
+			return WithdrawalStatusReadyToFinalize
+		}
+		return WithdrawalStatusProven
+	case withdrawal.L2BlockNumber.Int != nil && l.LatestOutputL2BlockNumber != nil && withdrawal.L2BlockNumber.Int.Cmp(l.LatestOutputL2BlockNumber) <= 0:
+		return WithdrawalStatusReadyToProve
+	default:
+		return WithdrawalStatusInitiated
+	}
+}
+
+// finalizableTimestamp is the latest proven timestamp for which the finalization period has passed
+func (l WithdrawalLifecycle) finalizableTimestamp() uint64 {
+	if l.L1Timestamp < l.FinalizationPeriodSeconds {
+		return 0
+	}
+	return l.L1Timestamp - l.FinalizationPeriodSeconds
+}
+
+// Page selects a page of deposits or withdrawals, ordered by timestamp
+type Page struct {
+	// Cursor is the GUID of the last entry of the previous page, empty for the first page
+	Cursor    string
+	Limit     int
+	Ascending bool
+}
+
+type DepositFilter struct {
+	// Token matches either the L1 or L2 token address when set
+	Token *common.Address
+}
+
+type WithdrawalFilter struct {
+	// Token matches either the L1 or L2 token address when set
+	Token *common.Address
+
+	// Status matches the withdrawals at this stage of the lifecycle when set
+	Status    WithdrawalStatus
+	Lifecycle WithdrawalLifecycle
+}
+
 type BridgeView interface {
-	DepositsByAddress(address common.Address) ([]*DepositWithTransactionHash, error)
-	WithdrawalsByAddress(address common.Address) ([]*WithdrawalWithTransactionHashes, error)
+	DepositsByAddress(common.Address, DepositFilter, Page) ([]*DepositWithTransactionHash, error)
+	WithdrawalsByAddress(common.Address, WithdrawalFilter, Page) ([]*WithdrawalWithTransactionHashes, error)
+	WithdrawalByHash(common.Hash) (*Withdrawal, error)
+	WithdrawalWithTransactionHashesByHash(common.Hash) (*WithdrawalWithTransactionHashes, error)
 }
 
 type BridgeDB interface {
@@ -70,6 +158,9 @@ type BridgeDB interface {
 	StoreWithdrawals([]*Withdrawal) error
 	MarkProvenWithdrawalEvent(string, string) error
 	MarkFinalizedWithdrawalEvent(string, string) error
+
+	RollbackL1EventsAfter(*big.Int) error
+	RollbackL2EventsAfter(*big.Int) error
 }
 
 /**
@@ -91,15 +182,17 @@ func (db *bridgeDB) StoreDeposits(deposits []*Deposit) error {
 	return result.Error
 }
 
-func (db *bridgeDB) DepositsByAddress(address common.Address) ([]*DepositWithTransactionHash, error) {
+func (db *bridgeDB) DepositsByAddress(address common.Address, filter DepositFilter, page Page) ([]*DepositWithTransactionHash, error) {
 	depositsQuery := db.gorm.Table("deposits").Select("deposits.*, l1_contract_events.transaction_hash AS l1_transaction_hash")
 	eventsJoinQuery := depositsQuery.Joins("LEFT JOIN l1_contract_events ON deposits.initiated_l1_event_guid = l1_contract_events.guid")
 
-	// add in cursoring options
-	filteredQuery := eventsJoinQuery.Where(&Transaction{FromAddress: address}).Order("deposits.timestamp DESC").Limit(100)
+	filteredQuery := eventsJoinQuery.Where(&Transaction{FromAddress: address})
+	if filter.Token != nil {
+		filteredQuery = filteredQuery.Where(db.gorm.Where(&TokenPair{L1TokenAddress: *filter.Token}).Or(&TokenPair{L2TokenAddress: *filter.Token}))
+	}
 
-	deposits := make([]*DepositWithTransactionHash, 100)
-	result := filteredQuery.Scan(&deposits)
+	var deposits []*DepositWithTransactionHash
+	result := paginate(filteredQuery, "deposits", page).Scan(&deposits)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -118,6 +211,21 @@ func (db *bridgeDB) StoreWithdrawals(withdrawals []*Withdrawal) error {
 	return result.Error
 }
 
+// WithdrawalByHash returns the withdrawal initiated with the supplied withdrawal hash, nil otherwise
+func (db *bridgeDB) WithdrawalByHash(withdrawalHash common.Hash) (*Withdrawal, error) {
+	var withdrawal Withdrawal
+	result := db.gorm.Where(&Withdrawal{WithdrawalHash: withdrawalHash}).Take(&withdrawal)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, result.Error
+	}
+
+	return &withdrawal, nil
+}
+
 func (db *bridgeDB) MarkProvenWithdrawalEvent(guid, provenL1EventGuid string) error {
 	var withdrawal Withdrawal
 	result := db.gorm.First(&withdrawal, "guid = ?", guid)
@@ -142,18 +250,22 @@ func (db *bridgeDB) MarkFinalizedWithdrawalEvent(guid, finalizedL1EventGuid stri
 	return result.Error
 }
 
-func (db *bridgeDB) WithdrawalsByAddress(address common.Address) ([]*WithdrawalWithTransactionHashes, error) {
-	withdrawalsQuery := db.gorm.Table("withdrawals").Select("withdrawals.*, l2_contract_events.transaction_hash AS l2_transaction_hash, proven_l1_contract_events.transaction_hash AS proven_l1_transaction_hash, finalized_l1_contract_events.transaction_hash AS finalized_l1_transaction_hash")
-
-	eventsJoinQuery := withdrawalsQuery.Joins("LEFT JOIN l2_contract_events ON withdrawals.initiated_l2_event_guid = l2_contract_events.guid")
-	provenJoinQuery := eventsJoinQuery.Joins("LEFT JOIN l1_contract_events AS proven_l1_contract_events ON withdrawals.proven_l1_event_guid = proven_l1_contract_events.guid")
-	finalizedJoinQuery := provenJoinQuery.Joins("LEFT JOIN l1_contract_events AS finalized_l1_contract_events ON withdrawals.finalized_l1_event_guid = finalized_l1_contract_events.guid")
+func (db *bridgeDB) WithdrawalsByAddress(address common.Address, filter WithdrawalFilter, page Page) ([]*WithdrawalWithTransactionHashes, error) {
+	filteredQuery := db.withdrawalsWithTransactionHashes().Where(&Transaction{FromAddress: address})
+	if filter.Token != nil {
+		filteredQuery = filteredQuery.Where(db.gorm.Where(&TokenPair{L1TokenAddress: *filter.Token}).Or(&TokenPair{L2TokenAddress: *filter.Token}))
+	}
 
-	// add in cursoring options
-	filteredQuery := finalizedJoinQuery.Where(&Transaction{FromAddress: address}).Order("withdrawals.timestamp DESC").Limit(100)
+	if filter.Status != "" {
+		statusQuery, err := db.withdrawalStatusCondition(filter.Status, filter.Lifecycle)
+		if err != nil {
+			return nil, err
+		}
+		filteredQuery = filteredQuery.Where(statusQuery)
+	}
 
-	withdrawals := make([]*WithdrawalWithTransactionHashes, 100)
-	result := filteredQuery.Scan(&withdrawals)
+	var withdrawals []*WithdrawalWithTransactionHashes
+	result := paginate(filteredQuery, "withdrawals", page).Scan(&withdrawals)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -164,3 +276,101 @@ func (db *bridgeDB) WithdrawalsByAddress(address common.Address) ([]*WithdrawalW
 
 	return withdrawals, nil
 }
+
+// WithdrawalWithTransactionHashesByHash returns the withdrawal initiated with the supplied
+// withdrawal hash along with its transaction hashes, nil otherwise
+func (db *bridgeDB) WithdrawalWithTransactionHashesByHash(withdrawalHash common.Hash) (*WithdrawalWithTransactionHashes, error) {
+	var withdrawal WithdrawalWithTransactionHashes
+	result := db.withdrawalsWithTransactionHashes().Where(&Withdrawal{WithdrawalHash: withdrawalHash}).Take(&withdrawal)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, result.Error
+	}
+
+	return &withdrawal, nil
+}
+
+func (db *bridgeDB) withdrawalsWithTransactionHashes() *gorm.DB {
+	withdrawalsQuery := db.gorm.Table("withdrawals").Select("withdrawals.*, l2_contract_events.transaction_hash AS l2_transaction_hash, l2_block_headers.number AS l2_block_number, proven_l1_contract_events.transaction_hash AS proven_l1_transaction_hash, proven_l1_contract_events.timestamp AS proven_l1_timestamp, finalized_l1_contract_events.transaction_hash AS finalized_l1_transaction_hash")
+
+	eventsJoinQuery := withdrawalsQuery.Joins("LEFT JOIN l2_contract_events ON withdrawals.initiated_l2_event_guid = l2_contract_events.guid")
+	blocksJoinQuery := eventsJoinQuery.Joins("LEFT JOIN l2_block_headers ON l2_contract_events.block_hash = l2_block_headers.hash")
+	provenJoinQuery := blocksJoinQuery.Joins("LEFT JOIN l1_contract_events AS proven_l1_contract_events ON withdrawals.proven_l1_event_guid = proven_l1_contract_events.guid")
+	return provenJoinQuery.Joins("LEFT JOIN l1_contract_events AS finalized_l1_contract_events ON withdrawals.finalized_l1_event_guid = finalized_l1_contract_events.guid")
+}
+
+// withdrawalStatusCondition matches the withdrawals at the supplied status, mirroring `WithdrawalLifecycle.Status`
+func (db *bridgeDB) withdrawalStatusCondition(status WithdrawalStatus, lifecycle WithdrawalLifecycle) (*gorm.DB, error) {
+	unproven := db.gorm.Where("withdrawals.proven_l1_event_guid IS NULL AND withdrawals.finalized_l1_event_guid IS NULL")
+	unfinalized := db.gorm.Where("withdrawals.proven_l1_event_guid IS NOT NULL AND withdrawals.finalized_l1_event_guid IS NULL")
+	finalizableTimestamp := lifecycle.finalizableTimestamp()
+
+	switch status {
+	case WithdrawalStatusInitiated:
+		if lifecycle.LatestOutputL2BlockNumber == nil {
+			return unproven, nil
+		}
+		return unproven.Where("l2_block_headers.number > ?", U256{Int: lifecycle.LatestOutputL2BlockNumber}), nil
+	case WithdrawalStatusReadyToProve:
+		if lifecycle.LatestOutputL2BlockNumber == nil {
+			return db.gorm.Where("FALSE"), nil
+		}
+		return unproven.Where("l2_block_headers.number <= ?", U256{Int: lifecycle.LatestOutputL2BlockNumber}), nil
+	case WithdrawalStatusProven:
+		return unfinalized.Where("proven_l1_contract_events.timestamp > ?", finalizableTimestamp), nil
+	case WithdrawalStatusReadyToFinalize:
+		return unfinalized.Where("proven_l1_contract_events.timestamp <= ?", finalizableTimestamp), nil
+	case WithdrawalStatusFinalized:
+		return db.gorm.Where("withdrawals.finalized_l1_event_guid IS NOT NULL"), nil
+	default:
+		return nil, fmt.Errorf("unknown withdrawal status %q", status)
+	}
+}
+
+// paginate orders the query by timestamp, tie-broken by guid, and selects the page of
+// entries following the cursor
+func paginate(query *gorm.DB, table string, page Page) *gorm.DB {
+	direction, comparison := "DESC", "<"
+	if page.Ascending {
+		direction, comparison = "ASC", ">"
+	}
+
+	if page.Cursor != "" {
+		cursorQuery := fmt.Sprintf("(%[1]s.timestamp, %[1]s.guid) %[2]s (SELECT timestamp, guid FROM %[1]s WHERE guid = ?)", table, comparison)
+		query = query.Where(cursorQuery, page.Cursor)
+	}
+
+	order := fmt.Sprintf("%[1]s.timestamp %[2]s, %[1]s.guid %[2]s", table, direction)
+	return query.Order(order).Limit(page.Limit)
+}
+
+// Rollbacks
+
+// RollbackL1EventsAfter removes the deposits and withdrawal proofs & finalizations originating
+// from the events emitted in the L1 blocks above the supplied height
+func (db *bridgeDB) RollbackL1EventsAfter(number *big.Int) error {
+	l1Events := l1EventsAfter(db.gorm, number)
+
+	result := db.gorm.Where("initiated_l1_event_guid IN (?)", l1Events).Delete(&Deposit{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	result = db.gorm.Model(&Withdrawal{}).Where("proven_l1_event_guid IN (?)", l1Events).Update("proven_l1_event_guid", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	result = db.gorm.Model(&Withdrawal{}).Where("finalized_l1_event_guid IN (?)", l1Events).Update("finalized_l1_event_guid", nil)
+	return result.Error
+}
+
+// RollbackL2EventsAfter removes the withdrawals originating from the events emitted in the
+// L2 blocks above the supplied height
+func (db *bridgeDB) RollbackL2EventsAfter(number *big.Int) error {
+	result := db.gorm.Where("initiated_l2_event_guid IN (?)", l2EventsAfter(db.gorm, number)).Delete(&Withdrawal{})
+	return result.Error
+}
@@ -1,6 +1,8 @@
 package database
 
 import (
+	"math/big"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"gorm.io/gorm"
@@ -35,7 +37,10 @@ type ContractEventsDB interface {
 	ContractEventsView
 
 	StoreL1ContractEvents([]*L1ContractEvent) error
+	DeleteL1ContractEventsAfter(*big.Int) error
+
 	StoreL2ContractEvents([]*L2ContractEvent) error
+	DeleteL2ContractEventsAfter(*big.Int) error
 }
 
 /**
@@ -57,9 +62,43 @@ func (db *contractEventsDB) StoreL1ContractEvents(events []*L1ContractEvent) err
 	return result.Error
 }
 
+// DeleteL1ContractEventsAfter removes the events emitted in the L1 blocks above the supplied height
+func (db *contractEventsDB) DeleteL1ContractEventsAfter(number *big.Int) error {
+	result := db.gorm.Where("block_hash IN (?)", l1BlockHashesAfter(db.gorm, number)).Delete(&L1ContractEvent{})
+	return result.Error
+}
+
 // L2
 
 func (db *contractEventsDB) StoreL2ContractEvents(events []*L2ContractEvent) error {
 	result := db.gorm.Create(&events)
 	return result.Error
 }
+
+// DeleteL2ContractEventsAfter removes the events emitted in the L2 blocks above the supplied height
+func (db *contractEventsDB) DeleteL2ContractEventsAfter(number *big.Int) error {
+	result := db.gorm.Where("block_hash IN (?)", l2BlockHashesAfter(db.gorm, number)).Delete(&L2ContractEvent{})
+	return result.Error
+}
+
+// l1BlockHashesAfter and l2BlockHashesAfter are sub-queries selecting the hashes of the blocks
+// stored above the supplied height
+
+func l1BlockHashesAfter(db *gorm.DB, number *big.Int) *gorm.DB {
+	return db.Model(&L1BlockHeader{}).Select("hash").Where("number > ?", U256{Int: number})
+}
+
+func l2BlockHashesAfter(db *gorm.DB, number *big.Int) *gorm.DB {
+	return db.Model(&L2BlockHeader{}).Select("hash").Where("number > ?", U256{Int: number})
+}
+
+// l1EventsAfter and l2EventsAfter are sub-queries selecting the guids of the events
+// emitted in the blocks stored above the supplied height
+
+func l1EventsAfter(db *gorm.DB, number *big.Int) *gorm.DB {
+	return db.Model(&L1ContractEvent{}).Select("guid").Where("block_hash IN (?)", l1BlockHashesAfter(db, number))
+}
+
+func l2EventsAfter(db *gorm.DB, number *big.Int) *gorm.DB {
+	return db.Model(&L2ContractEvent{}).Select("guid").Where("block_hash IN (?)", l2BlockHashesAfter(db, number))
+}
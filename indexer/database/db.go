@@ -1,4 +1,4 @@
-// Database module defines the data DB struct which wraps specific DB interfaces for L1/L2 block headers, contract events, bridging schemas.
+// Database module defines the data DB struct which wraps specific DB interfaces for L1/L2 block headers, contract events, bridging, output proposal and batch schemas.
 package database
 
 import (
@@ -12,6 +12,8 @@ type DB struct {
 	Blocks         BlocksDB
 	ContractEvents ContractEventsDB
 	Bridge         BridgeDB
+	Outputs        OutputProposalsDB
+	Batches        BatchesDB
 }
 
 func NewDB(dsn string) (*DB, error) {
@@ -30,14 +32,21 @@ func NewDB(dsn string) (*DB, error) {
 		Blocks:         newBlocksDB(gorm),
 		ContractEvents: newContractEventsDB(gorm),
 		Bridge:         newBridgeDB(gorm),
+		Outputs:        newOutputProposalsDB(gorm),
+		Batches:        newBatchesDB(gorm),
 	}
 
 	return db, nil
 }
 
 // Transaction executes all operations conducted with the supplied database in a single
-// transaction. If the supplied function errors, the transaction is rolled back.
+// transaction. If the supplied function errors, the transaction is rolled back. A DB that
+// is not connected, assembled from its interfaces as in tests, runs the function as is.
 func (db *DB) Transaction(fn func(db *DB) error) error {
+	if db.gorm == nil {
+		return fn(db)
+	}
+
 	return db.gorm.Transaction(func(tx *gorm.DB) error {
 		return fn(dbFromGormTx(tx))
 	})
@@ -49,5 +58,7 @@ func dbFromGormTx(tx *gorm.DB) *DB {
 		Blocks:         newBlocksDB(tx),
 		ContractEvents: newContractEventsDB(tx),
 		Bridge:         newBridgeDB(tx),
+		Outputs:        newOutputProposalsDB(tx),
+		Batches:        newBatchesDB(tx),
 	}
 }
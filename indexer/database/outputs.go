@@ -0,0 +1,104 @@
+package database
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gorm.io/gorm"
+)
+
+/**
+ * Types
+ */
+
+type OutputProposal struct {
+	GUID                string `gorm:"primaryKey"`
+	ProposedL1EventGUID string
+
+	// Set when the output is deleted by the challenger
+	DeletedL1EventGUID *string
+
+	OutputRoot    common.Hash `gorm:"serializer:json"`
+	L2OutputIndex U256
+	L2BlockNumber U256
+	L1Timestamp   uint64
+}
+
+type OutputProposalWithTransactionHash struct {
+	OutputProposal    OutputProposal `gorm:"embedded"`
+	L1TransactionHash common.Hash    `gorm:"serializer:json"`
+}
+
+type OutputProposalsView interface {
+	// OutputProposalForL2Block returns the first output, not deleted, proposed for the
+	// supplied L2 block or a later one
+	OutputProposalForL2Block(*big.Int) (*OutputProposalWithTransactionHash, error)
+}
+
+type OutputProposalsDB interface {
+	OutputProposalsView
+
+	StoreOutputProposals([]*OutputProposal) error
+	MarkDeletedOutputProposals(*big.Int, string) error
+
+	RollbackL1EventsAfter(*big.Int) error
+}
+
+/**
+ * Implementation
+ */
+
+type outputProposalsDB struct {
+	gorm *gorm.DB
+}
+
+func newOutputProposalsDB(db *gorm.DB) OutputProposalsDB {
+	return &outputProposalsDB{gorm: db}
+}
+
+func (db *outputProposalsDB) StoreOutputProposals(outputs []*OutputProposal) error {
+	result := db.gorm.Create(&outputs)
+	return result.Error
+}
+
+// MarkDeletedOutputProposals marks the outputs from the supplied index onwards, which have not
+// already been deleted, as deleted by the supplied event
+func (db *outputProposalsDB) MarkDeletedOutputProposals(fromIndex *big.Int, deletedL1EventGuid string) error {
+	result := db.gorm.Model(&OutputProposal{}).
+		Where("deleted_l1_event_guid IS NULL AND l2_output_index >= ?", U256{Int: fromIndex}).
+		Update("deleted_l1_event_guid", deletedL1EventGuid)
+	return result.Error
+}
+
+func (db *outputProposalsDB) OutputProposalForL2Block(l2BlockNumber *big.Int) (*OutputProposalWithTransactionHash, error) {
+	outputsQuery := db.gorm.Table("output_proposals").Select("output_proposals.*, l1_contract_events.transaction_hash AS l1_transaction_hash")
+	eventsJoinQuery := outputsQuery.Joins("LEFT JOIN l1_contract_events ON output_proposals.proposed_l1_event_guid = l1_contract_events.guid")
+
+	var output OutputProposalWithTransactionHash
+	filteredQuery := eventsJoinQuery.Where("output_proposals.deleted_l1_event_guid IS NULL AND output_proposals.l2_block_number >= ?", U256{Int: l2BlockNumber})
+	result := filteredQuery.Order("output_proposals.l2_block_number ASC").Take(&output)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, result.Error
+	}
+
+	return &output, nil
+}
+
+// RollbackL1EventsAfter removes the outputs proposed, and restores the outputs deleted, by the
+// events emitted in the L1 blocks above the supplied height
+func (db *outputProposalsDB) RollbackL1EventsAfter(number *big.Int) error {
+	l1Events := l1EventsAfter(db.gorm, number)
+
+	result := db.gorm.Where("proposed_l1_event_guid IN (?)", l1Events).Delete(&OutputProposal{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	result = db.gorm.Model(&OutputProposal{}).Where("deleted_l1_event_guid IN (?)", l1Events).Update("deleted_l1_event_guid", nil)
+	return result.Error
+}
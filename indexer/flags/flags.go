@@ -93,6 +93,36 @@ var (
 		Usage:  "Address of the portal",
 		EnvVar: prefixEnvVar("BEDROCK_OPTIMISM_PORTAL"),
 	}
+	BedrockL1CrossDomainMessengerAddress = cli.StringFlag{
+		Name:   "bedrock.l1-cross-domain-messenger-address",
+		Usage:  "Address of the L1 cross domain messenger",
+		EnvVar: prefixEnvVar("BEDROCK_L1_CROSS_DOMAIN_MESSENGER"),
+	}
+	BedrockL2OutputOracleAddress = cli.StringFlag{
+		Name:   "bedrock.l2-output-oracle-address",
+		Usage:  "Address of the L2 output oracle",
+		EnvVar: prefixEnvVar("BEDROCK_L2_OUTPUT_ORACLE"),
+	}
+	BedrockBatchInboxAddress = cli.StringFlag{
+		Name:   "bedrock.batch-inbox-address",
+		Usage:  "Address of the batch inbox. Batch submissions are not indexed when unset",
+		EnvVar: prefixEnvVar("BEDROCK_BATCH_INBOX"),
+	}
+	BedrockBatcherAddress = cli.StringFlag{
+		Name:   "bedrock.batcher-address",
+		Usage:  "Address of the batcher submitting to the batch inbox",
+		EnvVar: prefixEnvVar("BEDROCK_BATCHER"),
+	}
+	BedrockDAURLFlag = cli.StringFlag{
+		Name:   "bedrock.da-url",
+		Usage:  "DA API URL to retrieve the DAC batches from, the channels of DAC submissions are not indexed when unset",
+		EnvVar: prefixEnvVar("BEDROCK_DA_URL"),
+	}
+	BedrockDACFallbackTimeFlag = cli.Uint64Flag{
+		Name:   "bedrock.dac-fallback-time",
+		Usage:  "L1 timestamp from which the batches posted to the batch inbox calldata while the DA is unavailable are accepted, they are not indexed when unset",
+		EnvVar: prefixEnvVar("BEDROCK_DAC_FALLBACK_TIME"),
+	}
 
 	/* Optional Flags */
 
@@ -102,6 +132,11 @@ var (
 		Required: false,
 		EnvVar:   prefixEnvVar("DISABLE_INDEXER"),
 	}
+	IndexUnsafeHeadsFlag = cli.BoolFlag{
+		Name:   "index-unsafe-heads",
+		Usage:  "Index blocks up to the latest block rather than the finalized block, rolling back indexed state on reorgs",
+		EnvVar: prefixEnvVar("INDEX_UNSAFE_HEADS"),
+	}
 	LogLevelFlag = cli.StringFlag{
 		Name:   "log-level",
 		Usage:  "The lowest log level that will be output",
@@ -203,7 +238,14 @@ var optionalFlags = []cli.Flag{
 	BedrockFlag,
 	BedrockL1StandardBridgeAddress,
 	BedrockOptimismPortalAddress,
+	BedrockL1CrossDomainMessengerAddress,
+	BedrockL2OutputOracleAddress,
+	BedrockBatchInboxAddress,
+	BedrockBatcherAddress,
+	BedrockDAURLFlag,
+	BedrockDACFallbackTimeFlag,
 	DisableIndexer,
+	IndexUnsafeHeadsFlag,
 	LogLevelFlag,
 	LogTerminalFlag,
 	SentryEnableFlag,
@@ -1,14 +1,19 @@
 package indexer
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 
 	"github.com/ethereum-optimism/optimism/indexer/database"
 	"github.com/ethereum-optimism/optimism/indexer/flags"
 	"github.com/ethereum-optimism/optimism/indexer/node"
 	"github.com/ethereum-optimism/optimism/indexer/processor"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/urfave/cli"
@@ -35,8 +40,11 @@ func Main(gitVersion string) func(ctx *cli.Context) error {
 		defer indexer.Stop()
 		log.Info("indexer started")
 
-		// Never terminate
-		<-(chan struct{})(nil)
+		interruptChannel := make(chan os.Signal, 1)
+		signal.Notify(interruptChannel, os.Interrupt, syscall.SIGTERM, syscall.SIGQUIT)
+		<-interruptChannel
+
+		log.Info("stopping indexer")
 		return nil
 	}
 }
@@ -48,6 +56,9 @@ type Indexer struct {
 
 	l1Processor *processor.L1Processor
 	l2Processor *processor.L2Processor
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
 }
 
 // NewIndexer initializes the Indexer, gathering any resources
@@ -77,7 +88,23 @@ func NewIndexer(ctx *cli.Context) (*Indexer, error) {
 	if err != nil {
 		return nil, err
 	}
-	l1Processor, err := processor.NewL1Processor(l1EthClient, db)
+	l1Contracts := processor.L1Contracts{
+		OptimismPortal:         common.HexToAddress(ctx.GlobalString(flags.BedrockOptimismPortalAddress.Name)),
+		L1CrossDomainMessenger: common.HexToAddress(ctx.GlobalString(flags.BedrockL1CrossDomainMessengerAddress.Name)),
+		L1StandardBridge:       common.HexToAddress(ctx.GlobalString(flags.BedrockL1StandardBridgeAddress.Name)),
+		L2OutputOracle:         common.HexToAddress(ctx.GlobalString(flags.BedrockL2OutputOracleAddress.Name)),
+	}
+	batchInbox := processor.BatchInbox{
+		Address: common.HexToAddress(ctx.GlobalString(flags.BedrockBatchInboxAddress.Name)),
+		Batcher: common.HexToAddress(ctx.GlobalString(flags.BedrockBatcherAddress.Name)),
+		DAURL:   ctx.GlobalString(flags.BedrockDAURLFlag.Name),
+	}
+	if ctx.GlobalIsSet(flags.BedrockDACFallbackTimeFlag.Name) {
+		fallbackTime := ctx.GlobalUint64(flags.BedrockDACFallbackTimeFlag.Name)
+		batchInbox.DACFallbackTime = &fallbackTime
+	}
+	indexUnsafeHeads := ctx.GlobalBool(flags.IndexUnsafeHeadsFlag.Name)
+	l1Processor, err := processor.NewL1Processor(l1EthClient, db, l1Contracts, batchInbox, indexUnsafeHeads)
 	if err != nil {
 		return nil, err
 	}
@@ -87,7 +114,7 @@ func NewIndexer(ctx *cli.Context) (*Indexer, error) {
 	if err != nil {
 		return nil, err
 	}
-	l2Processor, err := processor.NewL2Processor(l2EthClient, db)
+	l2Processor, err := processor.NewL2Processor(l2EthClient, db, indexUnsafeHeads)
 	if err != nil {
 		return nil, err
 	}
@@ -109,12 +136,28 @@ func (b *Indexer) Serve() error {
 // Start starts the starts the indexing service on L1 and L2 chains and also
 // starts the REST server.
 func (b *Indexer) Start() error {
-	go b.l1Processor.Start()
-	go b.l2Processor.Start()
+	ctx, cancel := context.WithCancel(context.Background())
+	b.cancel = cancel
+
+	b.wg.Add(2)
+	go func() {
+		defer b.wg.Done()
+		b.l1Processor.Start(ctx)
+	}()
+	go func() {
+		defer b.wg.Done()
+		b.l2Processor.Start(ctx)
+	}()
 
 	return nil
 }
 
-// Stop stops the indexing service on L1 and L2 chains.
+// Stop stops the indexing service on L1 and L2 chains, waiting
+// for the batches being indexed to complete.
 func (b *Indexer) Stop() {
+	if b.cancel != nil {
+		b.cancel()
+	}
+
+	b.wg.Wait()
 }
@@ -0,0 +1,14 @@
+
+/**
+ * BRIDGING DATA
+ */
+
+-- Message passed to the L2ToL1MessagePasser, used to prove & finalize the withdrawal.
+-- The withdrawals indexed before this migration are left with an empty message
+ALTER TABLE withdrawals
+    ADD COLUMN IF NOT EXISTS message_nonce     UINT256 DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS message_sender    VARCHAR NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS message_target    VARCHAR NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS message_value     UINT256 DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS message_gas_limit UINT256 DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS message_data      VARCHAR NOT NULL DEFAULT '';
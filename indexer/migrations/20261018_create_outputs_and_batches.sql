@@ -0,0 +1,58 @@
+
+/**
+ * L2 OUTPUT DATA
+ */
+
+CREATE TABLE IF NOT EXISTS output_proposals (
+	guid                    VARCHAR PRIMARY KEY NOT NULL,
+
+    -- Event proposing the output, and the event deleting it when challenged
+    proposed_l1_event_guid  VARCHAR NOT NULL REFERENCES l1_contract_events(guid),
+    deleted_l1_event_guid   VARCHAR REFERENCES l1_contract_events(guid),
+
+    -- Output information
+	output_root     VARCHAR NOT NULL,
+	l2_output_index UINT256,
+	l2_block_number UINT256,
+	l1_timestamp    INTEGER NOT NULL
+);
+
+/**
+ * BATCH DATA
+ */
+
+CREATE TABLE IF NOT EXISTS batch_submissions (
+	transaction_hash  VARCHAR NOT NULL PRIMARY KEY,
+	l1_block_hash     VARCHAR NOT NULL REFERENCES l1_block_headers(hash),
+	transaction_index INTEGER NOT NULL,
+    timestamp         INTEGER NOT NULL,
+
+    -- First byte of the calldata, identifying the frames or the DA ref it carries
+	data_type         INTEGER NOT NULL,
+
+    -- Certificate of DAC batch refs (unverified)
+    dac_version       INTEGER,
+    dac_data_hash     VARCHAR,
+    dac_signer_mask   VARCHAR
+);
+
+-- Frames of the channels not yet complete. The frames of a channel are
+-- removed once the submission completing it is finalized
+CREATE TABLE IF NOT EXISTS batch_frames (
+	guid             VARCHAR PRIMARY KEY NOT NULL,
+	transaction_hash VARCHAR NOT NULL REFERENCES batch_submissions(transaction_hash),
+	channel_id       VARCHAR NOT NULL,
+	frame_number     INTEGER NOT NULL,
+	data             VARCHAR NOT NULL,
+	is_last          BOOLEAN NOT NULL
+);
+
+-- Complete channels, along with the range of L2 blocks made safe by the
+-- submission completing them
+CREATE TABLE IF NOT EXISTS batch_channels (
+	guid                       VARCHAR PRIMARY KEY NOT NULL,
+	channel_id                 VARCHAR NOT NULL,
+	completed_transaction_hash VARCHAR NOT NULL REFERENCES batch_submissions(transaction_hash),
+	first_l2_timestamp         INTEGER NOT NULL,
+	last_l2_timestamp          INTEGER NOT NULL
+);
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -25,9 +26,14 @@ const (
 
 type EthClient interface {
 	FinalizedBlockHeight() (*big.Int, error)
+	LatestBlockHeight() (*big.Int, error)
 
 	BlockHeadersByRange(*big.Int, *big.Int) ([]*types.Header, error)
 	BlockHeaderByHash(common.Hash) (*types.Header, error)
+	BlockHeaderByNumber(*big.Int) (*types.Header, error)
+	BlockByHash(common.Hash) (*types.Block, error)
+
+	FilterLogs(ethereum.FilterQuery) ([]types.Log, error)
 
 	RawRpcClient() *rpc.Client
 }
@@ -55,13 +61,21 @@ func (c *client) RawRpcClient() *rpc.Client {
 
 // FinalizedBlockHeight retrieves the latest block height in a finalized state
 func (c *client) FinalizedBlockHeight() (*big.Int, error) {
+	return c.blockHeightByTag("finalized")
+}
+
+// LatestBlockHeight retrieves the latest block height, which is not safe from reorgs.
+// Local devnets lacking a "finalized" block can be indexed from this height
+func (c *client) LatestBlockHeight() (*big.Int, error) {
+	return c.blockHeightByTag("latest")
+}
+
+func (c *client) blockHeightByTag(tag string) (*big.Int, error) {
 	ctxwt, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
 	defer cancel()
 
-	// Local devnet is having issues with the "finalized" block tag. Switch to "latest"
-	// to iterate faster locally but this needs to be updated
 	header := new(types.Header)
-	err := c.rpcClient.CallContext(ctxwt, header, "eth_getBlockByNumber", "latest", false)
+	err := c.rpcClient.CallContext(ctxwt, header, "eth_getBlockByNumber", tag, false)
 	if err != nil {
 		return nil, err
 	}
@@ -87,11 +101,47 @@ func (c *client) BlockHeaderByHash(hash common.Hash) (*types.Header, error) {
 	return header, nil
 }
 
+// BlockHeaderByNumber retrieves the canonical block header at the supplied height
+func (c *client) BlockHeaderByNumber(number *big.Int) (*types.Header, error) {
+	ctxwt, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
+	defer cancel()
+
+	header, err := ethclient.NewClient(c.rpcClient).HeaderByNumber(ctxwt, number)
+	if err != nil {
+		return nil, err
+	}
+
+	// sanity check on the data returned
+	if header.Number.Cmp(number) != 0 {
+		return nil, errors.New("header mismatch")
+	}
+
+	return header, nil
+}
+
+// BlockByHash retrieves the block, along with its transactions, attributed to the supplied hash
+func (c *client) BlockByHash(hash common.Hash) (*types.Block, error) {
+	ctxwt, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
+	defer cancel()
+
+	block, err := ethclient.NewClient(c.rpcClient).BlockByHash(ctxwt, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	// sanity check on the data returned
+	if block.Hash() != hash {
+		return nil, errors.New("block mismatch")
+	}
+
+	return block, nil
+}
+
 // BlockHeadersByRange will retrieve block headers within the specified range -- includsive. No restrictions
 // are placed on the range such as blocks in the "latest", "safe" or "finalized" states. If the specified
 // range is too large, `endHeight > latest`, the resulting list is truncated to the available headers
 func (c *client) BlockHeadersByRange(startHeight, endHeight *big.Int) ([]*types.Header, error) {
-	count := new(big.Int).Sub(endHeight, startHeight).Uint64()
+	count := new(big.Int).Sub(endHeight, startHeight).Uint64() + 1
 	batchElems := make([]rpc.BatchElem, count)
 	for i := uint64(0); i < count; i++ {
 		height := new(big.Int).Add(startHeight, new(big.Int).SetUint64(i))
@@ -136,6 +186,14 @@ func (c *client) BlockHeadersByRange(startHeight, endHeight *big.Int) ([]*types.
 	return headers, nil
 }
 
+// FilterLogs retrieves the logs matching the supplied query
+func (c *client) FilterLogs(query ethereum.FilterQuery) ([]types.Log, error) {
+	ctxwt, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
+	defer cancel()
+
+	return ethclient.NewClient(c.rpcClient).FilterLogs(ctxwt, query)
+}
+
 func toBlockNumArg(number *big.Int) string {
 	if number == nil {
 		return "latest"
@@ -24,7 +24,7 @@ func NewFetcher(ethClient EthClient, fromHeader *types.Header) *Fetcher {
 	return &Fetcher{ethClient: ethClient, lastHeader: fromHeader}
 }
 
-// NextConfirmedHeaders retrives the next set of headers that have been
+// NextFinalizedHeaders retrives the next set of headers that have been
 // marked as finalized by the connected client
 func (f *Fetcher) NextFinalizedHeaders() ([]*types.Header, error) {
 	finalizedBlockHeight, err := f.ethClient.FinalizedBlockHeight()
@@ -32,7 +32,34 @@ func (f *Fetcher) NextFinalizedHeaders() ([]*types.Header, error) {
 		return nil, err
 	}
 
-	if f.lastHeader != nil && f.lastHeader.Number.Cmp(finalizedBlockHeight) >= 0 {
+	return f.nextHeaders(finalizedBlockHeight)
+}
+
+// NextUnsafeHeaders retrieves the next set of headers up to the latest block of the
+// connected client. Unlike finalized headers, these headers may be reorg'd out, in which
+// case `ErrFetcherAndProviderMismatchedState` is returned once the provider diverges
+func (f *Fetcher) NextUnsafeHeaders() ([]*types.Header, error) {
+	latestBlockHeight, err := f.ethClient.LatestBlockHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	return f.nextHeaders(latestBlockHeight)
+}
+
+// LastHeader returns the last header returned by the Fetcher, nil indicating genesis
+func (f *Fetcher) LastHeader() *types.Header {
+	return f.lastHeader
+}
+
+// Reset moves the Fetcher back to the supplied header, from which it continues fetching
+// blocks. A nil header restarts the Fetcher from genesis.
+func (f *Fetcher) Reset(lastHeader *types.Header) {
+	f.lastHeader = lastHeader
+}
+
+func (f *Fetcher) nextHeaders(blockHeight *big.Int) ([]*types.Header, error) {
+	if f.lastHeader != nil && f.lastHeader.Number.Cmp(blockHeight) >= 0 {
 		// Warn if our fetcher is ahead of the provider. The fetcher should always
 		// be behind or at head with the provider.
 		return nil, nil
@@ -43,7 +70,7 @@ func (f *Fetcher) NextFinalizedHeaders() ([]*types.Header, error) {
 		nextHeight = new(big.Int).Add(f.lastHeader.Number, bigOne)
 	}
 
-	endHeight := clampBigInt(nextHeight, finalizedBlockHeight, maxHeaderBatchSize)
+	endHeight := clampBigInt(nextHeight, blockHeight, maxHeaderBatchSize)
 	headers, err := f.ethClient.BlockHeadersByRange(nextHeight, endHeight)
 	if err != nil {
 		return nil, err
@@ -53,8 +80,8 @@ func (f *Fetcher) NextFinalizedHeaders() ([]*types.Header, error) {
 	if numHeaders == 0 {
 		return nil, nil
 	} else if f.lastHeader != nil && headers[0].ParentHash != f.lastHeader.Hash() {
-		// The indexer's state is in an irrecoverable state relative to the provider. This
-		// should never happen since the indexer is dealing with only finalized blocks.
+		// The indexer's state has diverged from the provider. This should never happen
+		// for finalized blocks, while unsafe blocks may have been reorg'd out.
 		return nil, ErrFetcherAndProviderMismatchedState
 	}
 
@@ -116,3 +116,36 @@ func TestFetcherMismatchedProviderStateError(t *testing.T) {
 	assert.Nil(t, headers)
 	assert.Equal(t, ErrFetcherAndProviderMismatchedState, err)
 }
+
+func TestFetcherNextUnsafeHeadersReset(t *testing.T) {
+	client := new(MockEthClient)
+
+	// start from genesis
+	fetcher := NewFetcher(client, nil)
+
+	// blocks [0..4]
+	headers := makeHeaders(5, nil)
+	client.On("LatestBlockHeight").Return(big.NewInt(4), nil).Times(1) // Times so that we can override next
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigIntMatcher(0)), mock.MatchedBy(bigIntMatcher(4))).Return(headers, nil)
+	fetchedHeaders, err := fetcher.NextUnsafeHeaders()
+	assert.NoError(t, err)
+	assert.Len(t, fetchedHeaders, 5)
+	assert.Equal(t, headers[4], fetcher.LastHeader())
+
+	// blocks [3..9] after a reorg of blocks [3..4]
+	reorgedHeader := &types.Header{Number: big.NewInt(3), ParentHash: headers[2].Hash(), Time: 1}
+	reorgedHeaders := append([]*types.Header{reorgedHeader}, makeHeaders(6, reorgedHeader)...)
+	client.On("LatestBlockHeight").Return(big.NewInt(9), nil)
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigIntMatcher(5)), mock.MatchedBy(bigIntMatcher(9))).Return(reorgedHeaders[2:], nil)
+	fetchedHeaders, err = fetcher.NextUnsafeHeaders()
+	assert.Nil(t, fetchedHeaders)
+	assert.Equal(t, ErrFetcherAndProviderMismatchedState, err)
+
+	// continue from the common ancestor
+	fetcher.Reset(headers[2])
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigIntMatcher(3)), mock.MatchedBy(bigIntMatcher(9))).Return(reorgedHeaders, nil)
+	fetchedHeaders, err = fetcher.NextUnsafeHeaders()
+	assert.NoError(t, err)
+	assert.Len(t, fetchedHeaders, 7)
+	assert.Equal(t, reorgedHeaders[6], fetcher.LastHeader())
+}
@@ -0,0 +1,337 @@
+package processor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/da"
+	"github.com/ethereum-optimism/optimism/da/celestia"
+	"github.com/ethereum-optimism/optimism/da/dac"
+	"github.com/ethereum-optimism/optimism/da/fallback"
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/indexer/node"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/google/uuid"
+)
+
+// defaultDARequestTimeout is the duration to wait on the DA API for a batch to be retrieved
+const defaultDARequestTimeout = 10 * time.Second
+
+// BatchInbox configures the indexing of the transactions sent by the batcher to the batch inbox.
+// Submissions are not indexed when the inbox address is not set
+type BatchInbox struct {
+	Address common.Address
+	Batcher common.Address
+
+	// DAURL is the DA API serving the data of DAC batch refs. The frames of DAC submissions are
+	// only indexed when it is set and serves the batch, which excludes erasure coded batches
+	DAURL string
+	// DACFallbackTime is the L1 time from which the batches posted to the batch inbox calldata by
+	// the fallback are accepted, as set in the rollup config. Nil never accepts them
+	DACFallbackTime *uint64
+}
+
+// batchSubmission is an indexed submission along with the calldata of its transaction
+type batchSubmission struct {
+	database.BatchSubmission
+	data []byte
+}
+
+// batchSubmissions retrieves the transactions sent by the batcher to the batch inbox within the
+// batch of headers, in the order they were included
+func batchSubmissions(ethClient node.EthClient, headers []*types.Header, batchInbox BatchInbox) ([]*batchSubmission, error) {
+	submissions := []*batchSubmission{}
+	for _, header := range headers {
+		block, err := ethClient.BlockByHash(header.Hash())
+		if err != nil {
+			return nil, err
+		}
+
+		for i, tx := range block.Transactions() {
+			if tx.To() == nil || *tx.To() != batchInbox.Address || len(tx.Data()) == 0 {
+				continue
+			}
+
+			sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+			if err != nil {
+				return nil, err
+			} else if sender != batchInbox.Batcher {
+				continue
+			}
+
+			submission := &batchSubmission{
+				BatchSubmission: database.BatchSubmission{
+					TransactionHash:  tx.Hash(),
+					L1BlockHash:      header.Hash(),
+					TransactionIndex: uint64(i),
+					Timestamp:        header.Time,
+					DataType:         tx.Data()[0],
+				},
+				data: tx.Data(),
+			}
+
+			if submission.DataType == dac.DACBatchHeaderID {
+				decodeDACBatchRef(&submission.BatchSubmission, submission.data)
+			}
+
+			submissions = append(submissions, submission)
+		}
+	}
+
+	return submissions, nil
+}
+
+// decodeDACBatchRef sets the certificate of the DAC batch ref on the submission. The certificate is
+// not verified, as the indexer is not configured with the committees. Malformed refs are left unset
+func decodeDACBatchRef(submission *database.BatchSubmission, ref []byte) {
+	certificate, err := dac.DecodeCertificate(ref)
+	if err != nil {
+		return
+	}
+
+	version := uint8(certificate.Version)
+	mask := hexutil.Uint64(certificate.Mask)
+	submission.DACVersion = &version
+	submission.DACDataHash = &certificate.DataHash
+	submission.DACSignerMask = &mask
+}
+
+// batchIndexer indexes the batch submissions, reassembling the channels of their frames
+type batchIndexer struct {
+	processLog log.Logger
+
+	daURL        *url.URL
+	httpClient   *http.Client
+	fallbackTime *uint64
+}
+
+func newBatchIndexer(processLog log.Logger, batchInbox BatchInbox) (*batchIndexer, error) {
+	indexer := &batchIndexer{processLog: processLog, httpClient: &http.Client{Timeout: defaultDARequestTimeout}, fallbackTime: batchInbox.DACFallbackTime}
+	if batchInbox.DAURL != "" {
+		daURL, err := url.Parse(batchInbox.DAURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DA url: %w", err)
+		}
+		indexer.daURL = daURL
+	}
+
+	return indexer, nil
+}
+
+// indexSubmissions stores the submissions along with their frames. Each channel completed by a
+// submission is recorded with the range of L2 blocks it batched
+func (b *batchIndexer) indexSubmissions(db *database.DB, submissions []*batchSubmission) error {
+	if len(submissions) == 0 {
+		return nil
+	}
+
+	b.processLog.Info("detected batch submissions", "num", len(submissions))
+	dbSubmissions := make([]*database.BatchSubmission, len(submissions))
+	for i, submission := range submissions {
+		dbSubmissions[i] = &submission.BatchSubmission
+	}
+
+	err := db.Batches.StoreBatchSubmissions(dbSubmissions)
+	if err != nil {
+		return err
+	}
+
+	for _, submission := range submissions {
+		frames := b.frames(submission)
+		if len(frames) == 0 {
+			continue
+		}
+
+		dbFrames := make([]*database.BatchFrame, len(frames))
+		for i, frame := range frames {
+			dbFrames[i] = &database.BatchFrame{
+				GUID:            uuid.NewString(),
+				TransactionHash: submission.TransactionHash,
+				ChannelID:       frames[i].ID[:], // not the loop variable, shared by the frames
+				FrameNumber:     frame.FrameNumber,
+				Data:            frame.Data,
+				IsLast:          frame.IsLast,
+			}
+		}
+
+		err = db.Batches.StoreBatchFrames(dbFrames)
+		if err != nil {
+			return err
+		}
+
+		checked := make(map[derive.ChannelID]bool)
+		for _, frame := range frames {
+			if checked[frame.ID] {
+				continue
+			}
+
+			checked[frame.ID] = true
+			err := b.completeChannel(db, frame.ID, submission)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// frames returns the frames carried by the submission, retrieving them from the DA for DAC batch
+// refs. Submissions whose frames are not available to the indexer, or are invalid, carry no frame.
+// The DA is optional to the indexer, failing to retrieve a batch from it never fails indexing
+func (b *batchIndexer) frames(submission *batchSubmission) []derive.Frame {
+	var data []byte
+	switch submission.DataType {
+	case derive.DerivationVersion0:
+		data = submission.data
+	case fallback.CalldataHeaderID:
+		if !fallback.IsActive(b.fallbackTime, submission.Timestamp) {
+			b.processLog.Warn("calldata batch before the fallback activation", "tx_hash", submission.TransactionHash)
+			return nil
+		}
+		data = submission.data[1:]
+	case dac.DACBatchHeaderID:
+		if b.daURL == nil || submission.DACDataHash == nil {
+			return nil
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), defaultDARequestTimeout)
+		defer cancel()
+		batch, err := dac.FetchBatch(ctx, b.httpClient, b.daURL, submission.DACDataHash.Bytes())
+		if errors.Is(err, da.ErrBatchNotFound) || errors.Is(err, dac.ErrDataHashMismatch) {
+			b.processLog.Warn("DAC batch not served by the DA", "tx_hash", submission.TransactionHash, "data_hash", submission.DACDataHash, "err", err)
+			return nil
+		} else if err != nil {
+			b.processLog.Error("unable to fetch DAC batch", "tx_hash", submission.TransactionHash, "data_hash", submission.DACDataHash, "err", err)
+			return nil
+		}
+		data = batch
+	case celestia.CelestiaBatchHeaderID:
+		// celestia blobs are not retrieved by the indexer
+		return nil
+	default:
+		return nil
+	}
+
+	frames, err := derive.ParseFrames(data)
+	if err != nil {
+		b.processLog.Warn("invalid frames submitted", "tx_hash", submission.TransactionHash, "err", err)
+		return nil
+	}
+
+	return frames
+}
+
+// completeChannel records the channel once all of its frames have been submitted. The frames
+// are kept until the submission completing the channel is finalized, so that the channel can be
+// completed again should that submission be reorg'd out
+func (b *batchIndexer) completeChannel(db *database.DB, channelID derive.ChannelID, submission *batchSubmission) error {
+	// frames resubmitted after the channel was complete are ignored
+	channel, err := db.Batches.BatchChannelByID(channelID[:])
+	if err != nil {
+		return err
+	} else if channel != nil {
+		return nil
+	}
+
+	dbFrames, err := db.Batches.BatchFramesByChannelID(channelID[:])
+	if err != nil {
+		return err
+	}
+
+	// the first submission of a frame number is the one derived from
+	lastFrameNumber := -1
+	frames := make(map[uint16]*database.BatchFrame, len(dbFrames))
+	for _, frame := range dbFrames {
+		if _, ok := frames[frame.FrameNumber]; ok {
+			continue
+		}
+
+		frames[frame.FrameNumber] = frame
+		if frame.IsLast && (lastFrameNumber < 0 || int(frame.FrameNumber) < lastFrameNumber) {
+			lastFrameNumber = int(frame.FrameNumber)
+		}
+	}
+
+	if lastFrameNumber < 0 {
+		return nil
+	}
+
+	channelData := [][]byte{}
+	for i := 0; i <= lastFrameNumber; i++ {
+		frame, ok := frames[uint16(i)]
+		if !ok {
+			return nil
+		}
+		channelData = append(channelData, frame.Data)
+	}
+
+	firstTimestamp, lastTimestamp, ok := b.batchTimestamps(channelID, bytes.Join(channelData, nil), submission)
+	if !ok {
+		return nil
+	}
+
+	b.processLog.Info("channel complete", "channel_id", channelID, "tx_hash", submission.TransactionHash, "first_l2_timestamp", firstTimestamp, "last_l2_timestamp", lastTimestamp)
+	return db.Batches.StoreBatchChannel(&database.BatchChannel{
+		GUID:                     uuid.NewString(),
+		ChannelID:                channelID[:],
+		CompletedTransactionHash: submission.TransactionHash,
+		FirstL2Timestamp:         firstTimestamp,
+		LastL2Timestamp:          lastTimestamp,
+	})
+}
+
+// pruneFrames removes the frames of the channels completed by a submission included at or
+// below the finalized height, which can no longer be reorg'd out
+func (b *batchIndexer) pruneFrames(db *database.DB, finalizedHeight *big.Int) error {
+	return db.Batches.DeleteBatchFramesCompletedUntil(finalizedHeight)
+}
+
+// batchTimestamps decodes the batches of the channel, returning the range of timestamps of the
+// batched L2 blocks. Like the derivation pipeline, the batches read until the channel data turns
+// invalid are kept
+func (b *batchIndexer) batchTimestamps(channelID derive.ChannelID, channelData []byte, submission *batchSubmission) (uint64, uint64, bool) {
+	l1Ref := eth.L1BlockRef{Hash: submission.L1BlockHash, Time: submission.Timestamp}
+	readBatch, err := derive.BatchReader(bytes.NewReader(channelData), l1Ref)
+	if err != nil {
+		b.processLog.Warn("invalid channel data", "channel_id", channelID, "err", err)
+		return 0, 0, false
+	}
+
+	var firstTimestamp, lastTimestamp uint64
+	numBatches := 0
+	for {
+		batch, err := readBatch()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			b.processLog.Warn("invalid batch in channel", "channel_id", channelID, "err", err)
+			break
+		}
+
+		timestamp := batch.Batch.Timestamp
+		if numBatches == 0 || timestamp < firstTimestamp {
+			firstTimestamp = timestamp
+		}
+		if numBatches == 0 || timestamp > lastTimestamp {
+			lastTimestamp = timestamp
+		}
+		numBatches++
+	}
+
+	return firstTimestamp, lastTimestamp, numBatches > 0
+}
@@ -0,0 +1,370 @@
+package processor
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/da/dac"
+	"github.com/ethereum-optimism/optimism/da/fallback"
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+)
+
+// mockBatchesDB is an in-memory BatchesDB, the L1 block of each submission being
+// numbered through blockNumbers
+type mockBatchesDB struct {
+	blockNumbers map[common.Hash]uint64
+
+	submissions []*database.BatchSubmission
+	frames      []*database.BatchFrame
+	channels    []*database.BatchChannel
+}
+
+func newMockBatchesDB() *mockBatchesDB {
+	return &mockBatchesDB{blockNumbers: make(map[common.Hash]uint64)}
+}
+
+func (m *mockBatchesDB) BatchSubmissionForL2Block(*big.Int) (*database.BatchSubmission, error) {
+	return nil, nil
+}
+
+func (m *mockBatchesDB) StoreBatchSubmissions(submissions []*database.BatchSubmission) error {
+	m.submissions = append(m.submissions, submissions...)
+	return nil
+}
+
+func (m *mockBatchesDB) StoreBatchFrames(frames []*database.BatchFrame) error {
+	m.frames = append(m.frames, frames...)
+	return nil
+}
+
+func (m *mockBatchesDB) BatchFramesByChannelID(channelID []byte) ([]*database.BatchFrame, error) {
+	var frames []*database.BatchFrame
+	for _, frame := range m.frames {
+		if bytes.Equal(frame.ChannelID, channelID) {
+			frames = append(frames, frame)
+		}
+	}
+	return frames, nil
+}
+
+func (m *mockBatchesDB) DeleteBatchFramesCompletedUntil(number *big.Int) error {
+	m.frames = m.filterFrames(func(frame *database.BatchFrame) bool {
+		for _, channel := range m.channels {
+			if bytes.Equal(channel.ChannelID, frame.ChannelID) && m.includedAfter(channel.CompletedTransactionHash, number) {
+				return true
+			}
+		}
+		return false
+	})
+	return nil
+}
+
+func (m *mockBatchesDB) StoreBatchChannel(channel *database.BatchChannel) error {
+	m.channels = append(m.channels, channel)
+	return nil
+}
+
+func (m *mockBatchesDB) BatchChannelByID(channelID []byte) (*database.BatchChannel, error) {
+	for _, channel := range m.channels {
+		if bytes.Equal(channel.ChannelID, channelID) {
+			return channel, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockBatchesDB) RollbackL1BlocksAfter(number *big.Int) error {
+	var channels []*database.BatchChannel
+	for _, channel := range m.channels {
+		if !m.includedAfter(channel.CompletedTransactionHash, number) {
+			channels = append(channels, channel)
+		}
+	}
+	m.channels = channels
+
+	m.frames = m.filterFrames(func(frame *database.BatchFrame) bool {
+		return !m.includedAfter(frame.TransactionHash, number)
+	})
+
+	var submissions []*database.BatchSubmission
+	for _, submission := range m.submissions {
+		if m.blockNumbers[submission.L1BlockHash] <= number.Uint64() {
+			submissions = append(submissions, submission)
+		}
+	}
+	m.submissions = submissions
+	return nil
+}
+
+// includedAfter returns whether the submission is included in an L1 block above the height
+func (m *mockBatchesDB) includedAfter(txHash common.Hash, number *big.Int) bool {
+	for _, submission := range m.submissions {
+		if submission.TransactionHash == txHash {
+			return m.blockNumbers[submission.L1BlockHash] > number.Uint64()
+		}
+	}
+	return false
+}
+
+func (m *mockBatchesDB) filterFrames(keep func(*database.BatchFrame) bool) []*database.BatchFrame {
+	var frames []*database.BatchFrame
+	for _, frame := range m.frames {
+		if keep(frame) {
+			frames = append(frames, frame)
+		}
+	}
+	return frames
+}
+
+// channelData encodes the batches of the supplied L2 block timestamps as a channel
+func channelData(t *testing.T, timestamps ...uint64) []byte {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	for _, timestamp := range timestamps {
+		require.NoError(t, rlp.Encode(zw, &derive.BatchData{BatchV1: derive.BatchV1{Timestamp: timestamp}}))
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+// frameSubmission submits the frames as calldata, within the L1 block of the supplied number
+func frameSubmission(t *testing.T, db *mockBatchesDB, txHash common.Hash, l1BlockNumber uint64, frames ...derive.Frame) *batchSubmission {
+	data := &bytes.Buffer{}
+	data.WriteByte(derive.DerivationVersion0)
+	for _, frame := range frames {
+		require.NoError(t, frame.MarshalBinary(data))
+	}
+
+	// the blocks of a same height including different submissions are distinct
+	l1BlockHash := common.BigToHash(new(big.Int).SetUint64(l1BlockNumber))
+	l1BlockHash[0] = txHash[0]
+	db.blockNumbers[l1BlockHash] = l1BlockNumber
+	return &batchSubmission{
+		BatchSubmission: database.BatchSubmission{
+			TransactionHash: txHash,
+			L1BlockHash:     l1BlockHash,
+			Timestamp:       l1BlockNumber,
+			DataType:        derive.DerivationVersion0,
+		},
+		data: data.Bytes(),
+	}
+}
+
+func TestCompleteChannelReorg(t *testing.T) {
+	batches := newMockBatchesDB()
+	db := &database.DB{Batches: batches}
+	indexer := &batchIndexer{processLog: log.New()}
+
+	channelID := derive.ChannelID{1}
+	data := channelData(t, 10, 12)
+	first := derive.Frame{ID: channelID, FrameNumber: 0, Data: data[:len(data)/2]}
+	last := derive.Frame{ID: channelID, FrameNumber: 1, Data: data[len(data)/2:], IsLast: true}
+
+	// the channel is completed by its last frame, in L1 block 2
+	require.NoError(t, indexer.indexSubmissions(db, []*batchSubmission{frameSubmission(t, batches, common.Hash{1}, 1, first)}))
+	require.Empty(t, batches.channels)
+	require.NoError(t, indexer.indexSubmissions(db, []*batchSubmission{frameSubmission(t, batches, common.Hash{2}, 2, last)}))
+	require.Len(t, batches.channels, 1)
+	require.Equal(t, common.Hash{2}, batches.channels[0].CompletedTransactionHash)
+	require.Equal(t, uint64(10), batches.channels[0].FirstL2Timestamp)
+	require.Equal(t, uint64(12), batches.channels[0].LastL2Timestamp)
+
+	// the completing submission is reorg'd out, the frames of the earlier one are kept
+	require.NoError(t, batches.RollbackL1BlocksAfter(big.NewInt(1)))
+	require.Empty(t, batches.channels)
+	require.Len(t, batches.frames, 1)
+
+	// the last frame included again completes the channel
+	require.NoError(t, indexer.indexSubmissions(db, []*batchSubmission{frameSubmission(t, batches, common.Hash{3}, 2, last)}))
+	require.Len(t, batches.channels, 1)
+	require.Equal(t, common.Hash{3}, batches.channels[0].CompletedTransactionHash)
+
+	// the frames are removed once the completing submission is finalized
+	require.NoError(t, indexer.pruneFrames(db, big.NewInt(1)))
+	require.Len(t, batches.frames, 2)
+	require.NoError(t, indexer.pruneFrames(db, big.NewInt(2)))
+	require.Empty(t, batches.frames)
+}
+
+func TestDACBatchFrames(t *testing.T) {
+	frame := derive.Frame{ID: derive.ChannelID{1}, Data: channelData(t, 10), IsLast: true}
+	batch := &bytes.Buffer{}
+	batch.WriteByte(derive.DerivationVersion0)
+	require.NoError(t, frame.MarshalBinary(batch))
+	batchHash := crypto.Keccak256Hash(batch.Bytes())
+	corruptedHash := common.Hash{0xc0}
+
+	// the DA API is served under a base path
+	da := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/da/batch/" + hex.EncodeToString(batchHash.Bytes()):
+			_ = json.NewEncoder(w).Encode(map[string]string{"data": hex.EncodeToString(batch.Bytes())})
+		case "/da/batch/" + hex.EncodeToString(corruptedHash.Bytes()):
+			_ = json.NewEncoder(w).Encode(map[string]string{"data": "00"})
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer da.Close()
+
+	indexer, err := newBatchIndexer(log.New(), BatchInbox{DAURL: da.URL + "/da"})
+	require.NoError(t, err)
+
+	dacSubmission := func(txHash, dataHash common.Hash) *batchSubmission {
+		return &batchSubmission{BatchSubmission: database.BatchSubmission{TransactionHash: txHash, DataType: dac.DACBatchHeaderID, DACDataHash: &dataHash}}
+	}
+
+	// a failing DA, or one serving corrupted data, does not fail indexing
+	batches := newMockBatchesDB()
+	submissions := []*batchSubmission{
+		dacSubmission(common.Hash{1}, common.Hash{0xff}),
+		dacSubmission(common.Hash{2}, corruptedHash),
+		dacSubmission(common.Hash{3}, batchHash),
+	}
+	require.NoError(t, indexer.indexSubmissions(&database.DB{Batches: batches}, submissions))
+	require.Len(t, batches.submissions, 3)
+	require.Len(t, batches.frames, 1)
+	require.Equal(t, common.Hash{3}, batches.frames[0].TransactionHash)
+	require.Len(t, batches.channels, 1)
+}
+
+func TestCalldataBatchFrames(t *testing.T) {
+	frame := derive.Frame{ID: derive.ChannelID{1}, Data: channelData(t, 10), IsLast: true}
+	batch := &bytes.Buffer{}
+	batch.WriteByte(fallback.CalldataHeaderID)
+	batch.WriteByte(derive.DerivationVersion0)
+	require.NoError(t, frame.MarshalBinary(batch))
+	calldataSubmission := func(timestamp uint64) *batchSubmission {
+		return &batchSubmission{BatchSubmission: database.BatchSubmission{DataType: fallback.CalldataHeaderID, Timestamp: timestamp}, data: batch.Bytes()}
+	}
+
+	fallbackTime := uint64(100)
+	indexer, err := newBatchIndexer(log.New(), BatchInbox{DACFallbackTime: &fallbackTime})
+	require.NoError(t, err)
+	require.Empty(t, indexer.frames(calldataSubmission(fallbackTime-1)))
+	require.Len(t, indexer.frames(calldataSubmission(fallbackTime)), 1)
+
+	// calldata batches are never accepted on chains without the fallback
+	indexer, err = newBatchIndexer(log.New(), BatchInbox{})
+	require.NoError(t, err)
+	require.Empty(t, indexer.frames(calldataSubmission(fallbackTime)))
+}
+
+// dacBatchRef encodes a DAC batch ref, without a version byte for the legacy scheme
+func dacBatchRef(version dac.SchemeVersion, dataHash common.Hash, mask uint64) []byte {
+	ref := []byte{dac.DACBatchHeaderID}
+	if version != dac.SchemeLegacy {
+		ref = append(ref, byte(version))
+	}
+	ref = append(ref, dataHash.Bytes()...)
+	ref = append(ref, make([]byte, 192)...)
+	return binary.BigEndian.AppendUint64(ref, mask)
+}
+
+func TestDecodeDACBatchRef(t *testing.T) {
+	dataHash := common.Hash{0xda}
+
+	var legacy database.BatchSubmission
+	decodeDACBatchRef(&legacy, dacBatchRef(dac.SchemeLegacy, dataHash, 0b101))
+	require.Equal(t, uint8(dac.SchemeLegacy), *legacy.DACVersion)
+	require.Equal(t, dataHash, *legacy.DACDataHash)
+	require.Equal(t, hexutil.Uint64(0b101), *legacy.DACSignerMask)
+
+	var versioned database.BatchSubmission
+	decodeDACBatchRef(&versioned, dacBatchRef(dac.SchemeV1, dataHash, 0b011))
+	require.Equal(t, uint8(dac.SchemeV1), *versioned.DACVersion)
+	require.Equal(t, dataHash, *versioned.DACDataHash)
+	require.Equal(t, hexutil.Uint64(0b011), *versioned.DACSignerMask)
+
+	// malformed refs leave the certificate unset
+	for name, ref := range map[string][]byte{
+		"truncated":        dacBatchRef(dac.SchemeV1, dataHash, 1)[:100],
+		"versioned legacy": append([]byte{dac.DACBatchHeaderID, byte(dac.SchemeLegacy)}, dacBatchRef(dac.SchemeLegacy, dataHash, 1)[1:]...),
+	} {
+		var submission database.BatchSubmission
+		decodeDACBatchRef(&submission, ref)
+		require.Nil(t, submission.DACVersion, name)
+		require.Nil(t, submission.DACDataHash, name)
+		require.Nil(t, submission.DACSignerMask, name)
+	}
+}
+
+func TestCompleteChannel(t *testing.T) {
+	data := channelData(t, 20, 22, 24)
+	third := len(data) / 3
+	frame := func(id byte, number uint16, data []byte, isLast bool) derive.Frame {
+		return derive.Frame{ID: derive.ChannelID{id}, FrameNumber: number, Data: data, IsLast: isLast}
+	}
+
+	tests := []struct {
+		name        string
+		submissions [][]derive.Frame
+		// timestamps of the complete channel, none if nil
+		timestamps []uint64
+	}{
+		{
+			name:        "single submission",
+			submissions: [][]derive.Frame{{frame(1, 0, data[:third], false), frame(1, 1, data[third:2*third], false), frame(1, 2, data[2*third:], true)}},
+			timestamps:  []uint64{20, 24},
+		},
+		{
+			name:        "frames out of order",
+			submissions: [][]derive.Frame{{frame(1, 2, data[2*third:], true)}, {frame(1, 0, data[:third], false)}, {frame(1, 1, data[third:2*third], false)}},
+			timestamps:  []uint64{20, 24},
+		},
+		{
+			name:        "missing frame",
+			submissions: [][]derive.Frame{{frame(1, 0, data[:third], false)}, {frame(1, 2, data[2*third:], true)}},
+		},
+		{
+			name:        "first submission of a frame number",
+			submissions: [][]derive.Frame{{frame(1, 0, data[:third], false), frame(1, 1, data[third:2*third], false)}, {frame(1, 1, []byte{0xff}, false), frame(1, 2, data[2*third:], true)}},
+			timestamps:  []uint64{20, 24},
+		},
+		{
+			name:        "invalid channel data",
+			submissions: [][]derive.Frame{{frame(1, 0, []byte{0xff, 0xff}, true)}},
+		},
+		{
+			name:        "other channel",
+			submissions: [][]derive.Frame{{frame(1, 0, data[:third], false), frame(2, 0, data, true)}},
+			timestamps:  []uint64{20, 24},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			batches := newMockBatchesDB()
+			indexer := &batchIndexer{processLog: log.New()}
+			for i, frames := range test.submissions {
+				submission := frameSubmission(t, batches, common.Hash{byte(i + 1)}, uint64(i+1), frames...)
+				require.NoError(t, indexer.indexSubmissions(&database.DB{Batches: batches}, []*batchSubmission{submission}))
+			}
+
+			if test.timestamps == nil {
+				require.Empty(t, batches.channels)
+				return
+			}
+
+			require.Len(t, batches.channels, 1)
+			require.Equal(t, common.Hash{byte(len(test.submissions))}, batches.channels[0].CompletedTransactionHash)
+			require.Equal(t, test.timestamps[0], batches.channels[0].FirstL2Timestamp)
+			require.Equal(t, test.timestamps[1], batches.channels[0].LastL2Timestamp)
+		})
+	}
+}
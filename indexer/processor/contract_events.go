@@ -0,0 +1,69 @@
+package processor
+
+import (
+	"errors"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/indexer/node"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/google/uuid"
+)
+
+// contractLog is a log emitted by one of the indexed contracts, along with
+// the header of the block it was emitted in
+type contractLog struct {
+	types.Log
+	header *types.Header
+}
+
+// contractLogs retrieves the logs emitted by the supplied contracts within the batch of headers,
+// in the order they were emitted. Logs that do not belong to the batch, i.e the chain reorg'd in
+// between retrieving the headers and the logs, fail the batch.
+func contractLogs(ethClient node.EthClient, headers []*types.Header, contracts []common.Address) ([]contractLog, error) {
+	headerMap := make(map[common.Hash]*types.Header, len(headers))
+	for _, header := range headers {
+		headerMap[header.Hash()] = header
+	}
+
+	logs, err := ethClient.FilterLogs(ethereum.FilterQuery{
+		FromBlock: headers[0].Number,
+		ToBlock:   headers[len(headers)-1].Number,
+		Addresses: contracts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	contractLogs := make([]contractLog, 0, len(logs))
+	for _, log := range logs {
+		header, ok := headerMap[log.BlockHash]
+		if !ok {
+			return nil, errors.New("log emitted in a block outside of the indexed batch")
+		}
+
+		// the indexed contracts do not emit anonymous events
+		if len(log.Topics) == 0 {
+			continue
+		}
+
+		contractLogs = append(contractLogs, contractLog{Log: log, header: header})
+	}
+
+	return contractLogs, nil
+}
+
+// contractEvent creates the contract event recording the log
+func contractEvent(log contractLog) database.ContractEvent {
+	return database.ContractEvent{
+		GUID:            uuid.NewString(),
+		BlockHash:       log.BlockHash,
+		TransactionHash: log.TxHash,
+		EventSignature:  log.Topics[0].Bytes(),
+		LogIndex:        uint64(log.Index),
+		Timestamp:       log.header.Time,
+	}
+}
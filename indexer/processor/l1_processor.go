@@ -1,18 +1,39 @@
 package processor
 
 import (
+	"fmt"
+	"math/big"
+
 	"github.com/ethereum-optimism/optimism/indexer/database"
 	"github.com/ethereum-optimism/optimism/indexer/node"
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-service/backoff"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/google/uuid"
 )
 
+// L1Contracts are the addresses of the L1 contracts the bridge and output events are indexed from
+type L1Contracts struct {
+	OptimismPortal         common.Address
+	L1CrossDomainMessenger common.Address
+	L1StandardBridge       common.Address
+	L2OutputOracle         common.Address
+}
+
+func (c L1Contracts) toSlice() []common.Address {
+	return []common.Address{c.OptimismPortal, c.L1CrossDomainMessenger, c.L1StandardBridge, c.L2OutputOracle}
+}
+
 type L1Processor struct {
 	processor
 }
 
-func NewL1Processor(ethClient node.EthClient, db *database.DB) (*L1Processor, error) {
+func NewL1Processor(ethClient node.EthClient, db *database.DB, l1Contracts L1Contracts, batchInbox BatchInbox, indexUnsafeHeads bool) (*L1Processor, error) {
 	l1ProcessLog := log.New("processor", "l1")
 	l1ProcessLog.Info("initializing processor")
 
@@ -24,7 +45,7 @@ func NewL1Processor(ethClient node.EthClient, db *database.DB) (*L1Processor, er
 	var fromL1Header *types.Header
 	if latestHeader != nil {
 		l1ProcessLog.Info("detected last indexed block", "height", latestHeader.Number.Int, "hash", latestHeader.Hash)
-		l1Header, err := ethClient.BlockHeaderByHash(latestHeader.Hash)
+		l1Header, err := startHeader(ethClient, latestHeader.BlockHeader, indexUnsafeHeads)
 		if err != nil {
 			l1ProcessLog.Error("unable to fetch header for last indexed block", "hash", latestHeader.Hash, "err", err)
 			return nil, err
@@ -37,22 +58,70 @@ func NewL1Processor(ethClient node.EthClient, db *database.DB) (*L1Processor, er
 		fromL1Header = nil
 	}
 
+	processFn, err := l1ProcessFn(l1ProcessLog, ethClient, l1Contracts, batchInbox, indexUnsafeHeads)
+	if err != nil {
+		return nil, err
+	}
+
 	l1Processor := &L1Processor{
 		processor: processor{
-			fetcher:    node.NewFetcher(ethClient, fromL1Header),
-			db:         db,
-			processFn:  l1ProcessFn(ethClient),
-			processLog: l1ProcessLog,
+			ethClient:        ethClient,
+			fetcher:          node.NewFetcher(ethClient, fromL1Header),
+			indexUnsafeHeads: indexUnsafeHeads,
+			db:               db,
+			processFn:        processFn,
+			rollbackFn:       l1RollbackFn,
+			indexedHeaderFn:  l1IndexedHeaderFn,
+			processLog:       l1ProcessLog,
+			strategy:         backoff.Exponential(),
 		},
 	}
 
 	return l1Processor, nil
 }
 
-func l1ProcessFn(ethClient node.EthClient) func(db *database.DB, headers []*types.Header) error {
+func l1ProcessFn(processLog log.Logger, ethClient node.EthClient, l1Contracts L1Contracts, batchInbox BatchInbox, indexUnsafeHeads bool) (processFn, error) {
+	l1StandardBridgeABI, err := bindings.L1StandardBridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	optimismPortalABI, err := bindings.OptimismPortalMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	l2OutputOracleABI, err := bindings.L2OutputOracleMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	l1StandardBridge, err := bindings.NewL1StandardBridgeFilterer(l1Contracts.L1StandardBridge, nil)
+	if err != nil {
+		return nil, err
+	}
+	optimismPortal, err := bindings.NewOptimismPortalFilterer(l1Contracts.OptimismPortal, nil)
+	if err != nil {
+		return nil, err
+	}
+	l2OutputOracle, err := bindings.NewL2OutputOracleFilterer(l1Contracts.L2OutputOracle, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	batchIndexer, err := newBatchIndexer(processLog, batchInbox)
+	if err != nil {
+		return nil, err
+	}
+
+	ethDepositInitiatedEventID := l1StandardBridgeABI.Events["ETHDepositInitiated"].ID
+	erc20DepositInitiatedEventID := l1StandardBridgeABI.Events["ERC20DepositInitiated"].ID
+	withdrawalProvenEventID := optimismPortalABI.Events["WithdrawalProven"].ID
+	withdrawalFinalizedEventID := optimismPortalABI.Events["WithdrawalFinalized"].ID
+	outputProposedEventID := l2OutputOracleABI.Events["OutputProposed"].ID
+	outputsDeletedEventID := l2OutputOracleABI.Events["OutputsDeleted"].ID
+
 	return func(db *database.DB, headers []*types.Header) error {
 
-		// index all l2 blocks for now
+		// index all l1 blocks for now
 		l1Headers := make([]*database.L1BlockHeader, len(headers))
 		for i, header := range headers {
 			l1Headers[i] = &database.L1BlockHeader{
@@ -65,6 +134,247 @@ func l1ProcessFn(ethClient node.EthClient) func(db *database.DB, headers []*type
 			}
 		}
 
-		return db.Blocks.StoreL1BlockHeaders(l1Headers)
+		err := db.Blocks.StoreL1BlockHeaders(l1Headers)
+		if err != nil {
+			return err
+		}
+
+		// index the submissions of the batcher within this batch
+		if batchInbox.Address != (common.Address{}) {
+			submissions, err := batchSubmissions(ethClient, headers, batchInbox)
+			if err != nil {
+				return err
+			}
+
+			err = batchIndexer.indexSubmissions(db, submissions)
+			if err != nil {
+				return err
+			}
+
+			// unless indexing unsafe heads, the headers of this batch are finalized
+			finalizedHeight := headers[len(headers)-1].Number
+			if indexUnsafeHeads {
+				finalizedHeight, err = ethClient.FinalizedBlockHeight()
+				if err != nil {
+					return err
+				}
+			}
+
+			err = batchIndexer.pruneFrames(db, finalizedHeight)
+			if err != nil {
+				return err
+			}
+		}
+
+		// index the events emitted by the bridge & output contracts within this batch
+		logs, err := contractLogs(ethClient, headers, l1Contracts.toSlice())
+		if err != nil {
+			return err
+		}
+
+		if len(logs) == 0 {
+			return nil
+		}
+
+		l1ContractEvents := make([]*database.L1ContractEvent, len(logs))
+		for i, log := range logs {
+			l1ContractEvents[i] = &database.L1ContractEvent{ContractEvent: contractEvent(log)}
+		}
+
+		err = db.ContractEvents.StoreL1ContractEvents(l1ContractEvents)
+		if err != nil {
+			return err
+		}
+
+		deposits := []*database.Deposit{}
+		outputProposals := []*database.OutputProposal{}
+		for i, log := range logs {
+			eventGUID := l1ContractEvents[i].GUID
+
+			switch {
+			case log.Address == l1Contracts.L1StandardBridge && log.Topics[0] == ethDepositInitiatedEventID:
+				ethDeposit, err := l1StandardBridge.ParseETHDepositInitiated(log.Log)
+				if err != nil {
+					return err
+				}
+
+				deposits = append(deposits, &database.Deposit{
+					GUID:                 uuid.NewString(),
+					InitiatedL1EventGUID: eventGUID,
+					Tx: database.Transaction{
+						FromAddress: ethDeposit.From,
+						ToAddress:   ethDeposit.To,
+						Amount:      database.U256{Int: ethDeposit.Amount},
+						Data:        ethDeposit.ExtraData,
+						Timestamp:   log.header.Time,
+					},
+					TokenPair: database.TokenPair{L1TokenAddress: common.Address{}, L2TokenAddress: predeploys.LegacyERC20ETHAddr},
+				})
+
+			case log.Address == l1Contracts.L1StandardBridge && log.Topics[0] == erc20DepositInitiatedEventID:
+				erc20Deposit, err := l1StandardBridge.ParseERC20DepositInitiated(log.Log)
+				if err != nil {
+					return err
+				}
+
+				deposits = append(deposits, &database.Deposit{
+					GUID:                 uuid.NewString(),
+					InitiatedL1EventGUID: eventGUID,
+					Tx: database.Transaction{
+						FromAddress: erc20Deposit.From,
+						ToAddress:   erc20Deposit.To,
+						Amount:      database.U256{Int: erc20Deposit.Amount},
+						Data:        erc20Deposit.ExtraData,
+						Timestamp:   log.header.Time,
+					},
+					TokenPair: database.TokenPair{L1TokenAddress: erc20Deposit.L1Token, L2TokenAddress: erc20Deposit.L2Token},
+				})
+
+			case log.Address == l1Contracts.OptimismPortal && log.Topics[0] == withdrawalProvenEventID:
+				withdrawalProven, err := optimismPortal.ParseWithdrawalProven(log.Log)
+				if err != nil {
+					return err
+				}
+
+				withdrawal, err := db.Bridge.WithdrawalByHash(withdrawalProven.WithdrawalHash)
+				if err != nil {
+					return err
+				} else if withdrawal == nil {
+					if err := checkUnindexedWithdrawal(db, withdrawalProven.WithdrawalHash, log.header); err != nil {
+						return err
+					}
+					processLog.Warn("skipping proof of an unindexed withdrawal", "withdrawal_hash", common.Hash(withdrawalProven.WithdrawalHash), "tx_hash", log.TxHash)
+					continue
+				}
+
+				processLog.Info("withdrawal proven", "withdrawal_hash", common.Hash(withdrawalProven.WithdrawalHash), "tx_hash", log.TxHash)
+				err = db.Bridge.MarkProvenWithdrawalEvent(withdrawal.GUID, eventGUID)
+				if err != nil {
+					return err
+				}
+
+			case log.Address == l1Contracts.OptimismPortal && log.Topics[0] == withdrawalFinalizedEventID:
+				withdrawalFinalized, err := optimismPortal.ParseWithdrawalFinalized(log.Log)
+				if err != nil {
+					return err
+				}
+
+				withdrawal, err := db.Bridge.WithdrawalByHash(withdrawalFinalized.WithdrawalHash)
+				if err != nil {
+					return err
+				} else if withdrawal == nil {
+					if err := checkUnindexedWithdrawal(db, withdrawalFinalized.WithdrawalHash, log.header); err != nil {
+						return err
+					}
+					processLog.Warn("skipping finalization of an unindexed withdrawal", "withdrawal_hash", common.Hash(withdrawalFinalized.WithdrawalHash), "tx_hash", log.TxHash)
+					continue
+				}
+
+				processLog.Info("withdrawal finalized", "withdrawal_hash", common.Hash(withdrawalFinalized.WithdrawalHash), "tx_hash", log.TxHash, "success", withdrawalFinalized.Success)
+				err = db.Bridge.MarkFinalizedWithdrawalEvent(withdrawal.GUID, eventGUID)
+				if err != nil {
+					return err
+				}
+
+			case log.Address == l1Contracts.L2OutputOracle && log.Topics[0] == outputProposedEventID:
+				outputProposed, err := l2OutputOracle.ParseOutputProposed(log.Log)
+				if err != nil {
+					return err
+				}
+
+				outputProposals = append(outputProposals, &database.OutputProposal{
+					GUID:                uuid.NewString(),
+					ProposedL1EventGUID: eventGUID,
+					OutputRoot:          outputProposed.OutputRoot,
+					L2OutputIndex:       database.U256{Int: outputProposed.L2OutputIndex},
+					L2BlockNumber:       database.U256{Int: outputProposed.L2BlockNumber},
+					L1Timestamp:         outputProposed.L1Timestamp.Uint64(),
+				})
+
+			case log.Address == l1Contracts.L2OutputOracle && log.Topics[0] == outputsDeletedEventID:
+				outputsDeleted, err := l2OutputOracle.ParseOutputsDeleted(log.Log)
+				if err != nil {
+					return err
+				}
+
+				// outputs proposed earlier within this batch must be stored prior to being deleted
+				if len(outputProposals) > 0 {
+					err = db.Outputs.StoreOutputProposals(outputProposals)
+					if err != nil {
+						return err
+					}
+					outputProposals = outputProposals[:0]
+				}
+
+				processLog.Info("outputs deleted", "prev_next_output_index", outputsDeleted.PrevNextOutputIndex, "new_next_output_index", outputsDeleted.NewNextOutputIndex)
+				err = db.Outputs.MarkDeletedOutputProposals(outputsDeleted.NewNextOutputIndex, eventGUID)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(outputProposals) > 0 {
+			processLog.Info("detected output proposals", "num", len(outputProposals))
+			err = db.Outputs.StoreOutputProposals(outputProposals)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(deposits) > 0 {
+			processLog.Info("detected deposits", "num", len(deposits))
+			return db.Bridge.StoreDeposits(deposits)
+		}
+
+		return nil
+	}, nil
+}
+
+func l1IndexedHeaderFn(db *database.DB, number *big.Int) (*common.Hash, error) {
+	l1Header, err := db.Blocks.L1BlockHeaderByNumber(number)
+	if err != nil || l1Header == nil {
+		return nil, err
 	}
+
+	return &l1Header.Hash, nil
+}
+
+// checkUnindexedWithdrawal errors while the L2 processor may still index the withdrawal proven or
+// finalized in the L1 block, so that the block is processed again once it did. A withdrawal is
+// initiated in an L2 block older than the L1 block, so a withdrawal still missing once the indexed
+// L2 blocks are past the L1 block was initiated before them, such as legacy withdrawals.
+func checkUnindexedWithdrawal(db *database.DB, withdrawalHash common.Hash, l1Header *types.Header) error {
+	latestL2Header, err := db.Blocks.FinalizedL2BlockHeader()
+	if err != nil {
+		return err
+	} else if latestL2Header == nil || latestL2Header.Timestamp < l1Header.Time {
+		return fmt.Errorf("withdrawal %s is not indexed yet, L2 blocks are not indexed up to L1 block %d", withdrawalHash, l1Header.Number)
+	}
+
+	return nil
+}
+
+func l1RollbackFn(db *database.DB, number *big.Int) error {
+	err := db.Bridge.RollbackL1EventsAfter(number)
+	if err != nil {
+		return err
+	}
+
+	err = db.Outputs.RollbackL1EventsAfter(number)
+	if err != nil {
+		return err
+	}
+
+	err = db.Batches.RollbackL1BlocksAfter(number)
+	if err != nil {
+		return err
+	}
+
+	err = db.ContractEvents.DeleteL1ContractEventsAfter(number)
+	if err != nil {
+		return err
+	}
+
+	return db.Blocks.DeleteL1BlockHeadersAfter(number)
 }
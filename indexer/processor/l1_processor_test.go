@@ -0,0 +1,37 @@
+package processor
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// mockL2BlocksDB serves the latest indexed L2 block header, nil if none
+type mockL2BlocksDB struct {
+	database.BlocksDB
+	latestL2Header *database.L2BlockHeader
+}
+
+func (m *mockL2BlocksDB) FinalizedL2BlockHeader() (*database.L2BlockHeader, error) {
+	return m.latestL2Header, nil
+}
+
+func TestCheckUnindexedWithdrawal(t *testing.T) {
+	blocks := &mockL2BlocksDB{}
+	db := &database.DB{Blocks: blocks}
+	l1Header := &types.Header{Number: big.NewInt(10), Time: 100}
+
+	// the L2 processor may still index the withdrawal
+	require.Error(t, checkUnindexedWithdrawal(db, common.Hash{1}, l1Header))
+	blocks.latestL2Header = &database.L2BlockHeader{BlockHeader: database.BlockHeader{Timestamp: 99}}
+	require.Error(t, checkUnindexedWithdrawal(db, common.Hash{1}, l1Header))
+
+	// once past the L1 block, the withdrawal was initiated before the indexed L2 blocks
+	blocks.latestL2Header.Timestamp = 100
+	require.NoError(t, checkUnindexedWithdrawal(db, common.Hash{1}, l1Header))
+}
@@ -1,18 +1,34 @@
 package processor
 
 import (
+	"errors"
+	"math/big"
+
 	"github.com/ethereum-optimism/optimism/indexer/database"
 	"github.com/ethereum-optimism/optimism/indexer/node"
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-service/backoff"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/google/uuid"
 )
 
+// l2Contracts are the predeploys the bridge events are indexed from
+var l2Contracts = []common.Address{
+	predeploys.L2ToL1MessagePasserAddr,
+	predeploys.L2CrossDomainMessengerAddr,
+	predeploys.L2StandardBridgeAddr,
+}
+
 type L2Processor struct {
 	processor
 }
 
-func NewL2Processor(ethClient node.EthClient, db *database.DB) (*L2Processor, error) {
+func NewL2Processor(ethClient node.EthClient, db *database.DB, indexUnsafeHeads bool) (*L2Processor, error) {
 	l2ProcessLog := log.New("processor", "l2")
 	l2ProcessLog.Info("initializing processor")
 
@@ -24,7 +40,7 @@ func NewL2Processor(ethClient node.EthClient, db *database.DB) (*L2Processor, er
 	var fromL2Header *types.Header
 	if latestHeader != nil {
 		l2ProcessLog.Info("detected last indexed block", "height", latestHeader.Number.Int, "hash", latestHeader.Hash)
-		l2Header, err := ethClient.BlockHeaderByHash(latestHeader.Hash)
+		l2Header, err := startHeader(ethClient, latestHeader.BlockHeader, indexUnsafeHeads)
 		if err != nil {
 			l2ProcessLog.Error("unable to fetch header for last indexed block", "hash", latestHeader.Hash, "err", err)
 			return nil, err
@@ -36,19 +52,50 @@ func NewL2Processor(ethClient node.EthClient, db *database.DB) (*L2Processor, er
 		fromL2Header = nil
 	}
 
+	processFn, err := l2ProcessFn(l2ProcessLog, ethClient)
+	if err != nil {
+		return nil, err
+	}
+
 	l2Processor := &L2Processor{
 		processor: processor{
-			fetcher:    node.NewFetcher(ethClient, fromL2Header),
-			db:         db,
-			processFn:  l2ProcessFn(ethClient),
-			processLog: l2ProcessLog,
+			ethClient:        ethClient,
+			fetcher:          node.NewFetcher(ethClient, fromL2Header),
+			indexUnsafeHeads: indexUnsafeHeads,
+			db:               db,
+			processFn:        processFn,
+			rollbackFn:       l2RollbackFn,
+			indexedHeaderFn:  l2IndexedHeaderFn,
+			processLog:       l2ProcessLog,
+			strategy:         backoff.Exponential(),
 		},
 	}
 
 	return l2Processor, nil
 }
 
-func l2ProcessFn(ethClient node.EthClient) func(db *database.DB, headers []*types.Header) error {
+func l2ProcessFn(processLog log.Logger, ethClient node.EthClient) (processFn, error) {
+	l2StandardBridgeABI, err := bindings.L2StandardBridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	l2ToL1MessagePasserABI, err := bindings.L2ToL1MessagePasserMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	l2StandardBridge, err := bindings.NewL2StandardBridgeFilterer(predeploys.L2StandardBridgeAddr, nil)
+	if err != nil {
+		return nil, err
+	}
+	l2ToL1MessagePasser, err := bindings.NewL2ToL1MessagePasserFilterer(predeploys.L2ToL1MessagePasserAddr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	withdrawalInitiatedEventID := l2StandardBridgeABI.Events["WithdrawalInitiated"].ID
+	messagePassedEventID := l2ToL1MessagePasserABI.Events["MessagePassed"].ID
+
 	return func(db *database.DB, headers []*types.Header) error {
 
 		// index all l2 blocks for now
@@ -64,6 +111,120 @@ func l2ProcessFn(ethClient node.EthClient) func(db *database.DB, headers []*type
 			}
 		}
 
-		return db.Blocks.StoreL2BlockHeaders(l2Headers)
+		err := db.Blocks.StoreL2BlockHeaders(l2Headers)
+		if err != nil {
+			return err
+		}
+
+		// index the events emitted by the bridge contracts within this batch
+		logs, err := contractLogs(ethClient, headers, l2Contracts)
+		if err != nil {
+			return err
+		}
+
+		if len(logs) == 0 {
+			return nil
+		}
+
+		l2ContractEvents := make([]*database.L2ContractEvent, len(logs))
+		for i, log := range logs {
+			l2ContractEvents[i] = &database.L2ContractEvent{ContractEvent: contractEvent(log)}
+		}
+
+		err = db.ContractEvents.StoreL2ContractEvents(l2ContractEvents)
+		if err != nil {
+			return err
+		}
+
+		// The L2StandardBridge emits `WithdrawalInitiated` prior to the withdrawal message sent through the
+		// L2CrossDomainMessenger. The withdrawal hash is known once the `MessagePassed` event that follows
+		// in the same transaction is seen
+		initiatedWithdrawals := make(map[common.Hash][]*database.Withdrawal)
+		withdrawals := []*database.Withdrawal{}
+		for i, log := range logs {
+			switch {
+			case log.Address == predeploys.L2StandardBridgeAddr && log.Topics[0] == withdrawalInitiatedEventID:
+				withdrawalInitiated, err := l2StandardBridge.ParseWithdrawalInitiated(log.Log)
+				if err != nil {
+					return err
+				}
+
+				withdrawal := &database.Withdrawal{
+					GUID:                 uuid.NewString(),
+					InitiatedL2EventGUID: l2ContractEvents[i].GUID,
+					Tx: database.Transaction{
+						FromAddress: withdrawalInitiated.From,
+						ToAddress:   withdrawalInitiated.To,
+						Amount:      database.U256{Int: withdrawalInitiated.Amount},
+						Data:        withdrawalInitiated.ExtraData,
+						Timestamp:   log.header.Time,
+					},
+					TokenPair: database.TokenPair{L1TokenAddress: withdrawalInitiated.L1Token, L2TokenAddress: withdrawalInitiated.L2Token},
+				}
+
+				initiatedWithdrawals[log.TxHash] = append(initiatedWithdrawals[log.TxHash], withdrawal)
+
+			case log.Address == predeploys.L2ToL1MessagePasserAddr && log.Topics[0] == messagePassedEventID:
+				messagePassed, err := l2ToL1MessagePasser.ParseMessagePassed(log.Log)
+				if err != nil {
+					return err
+				}
+
+				// messages not sent by the bridge are not indexed as withdrawals
+				pending := initiatedWithdrawals[log.TxHash]
+				if messagePassed.Sender != predeploys.L2CrossDomainMessengerAddr || len(pending) == 0 {
+					continue
+				}
+
+				withdrawal := pending[0]
+				withdrawal.WithdrawalHash = messagePassed.WithdrawalHash
+				withdrawal.Message = database.WithdrawalMessage{
+					Nonce:    database.U256{Int: messagePassed.Nonce},
+					Sender:   messagePassed.Sender,
+					Target:   messagePassed.Target,
+					Value:    database.U256{Int: messagePassed.Value},
+					GasLimit: database.U256{Int: messagePassed.GasLimit},
+					Data:     messagePassed.Data,
+				}
+				withdrawals = append(withdrawals, withdrawal)
+				initiatedWithdrawals[log.TxHash] = pending[1:]
+			}
+		}
+
+		for _, pending := range initiatedWithdrawals {
+			if len(pending) > 0 {
+				return errors.New("initiated withdrawal without a passed message")
+			}
+		}
+
+		if len(withdrawals) > 0 {
+			processLog.Info("detected withdrawals", "num", len(withdrawals))
+			return db.Bridge.StoreWithdrawals(withdrawals)
+		}
+
+		return nil
+	}, nil
+}
+
+func l2IndexedHeaderFn(db *database.DB, number *big.Int) (*common.Hash, error) {
+	l2Header, err := db.Blocks.L2BlockHeaderByNumber(number)
+	if err != nil || l2Header == nil {
+		return nil, err
 	}
+
+	return &l2Header.Hash, nil
+}
+
+func l2RollbackFn(db *database.DB, number *big.Int) error {
+	err := db.Bridge.RollbackL2EventsAfter(number)
+	if err != nil {
+		return err
+	}
+
+	err = db.ContractEvents.DeleteL2ContractEventsAfter(number)
+	if err != nil {
+		return err
+	}
+
+	return db.Blocks.DeleteL2BlockHeadersAfter(number)
 }
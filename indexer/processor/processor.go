@@ -1,64 +1,188 @@
 package processor
 
 import (
+	"context"
+	"errors"
+	"math/big"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/indexer/database"
 	"github.com/ethereum-optimism/optimism/indexer/node"
+	"github.com/ethereum-optimism/optimism/op-service/backoff"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 )
 
-const defaultLoopInterval = 5 * time.Second
+const (
+	defaultLoopInterval = 5 * time.Second
+
+	// defaultProcessAttempts is the number of times a batch of headers is processed,
+	// backing off in between, before waiting for the next poll to try again
+	defaultProcessAttempts = 5
+)
 
 // processFn is the the function used to process unindexed headers. In
 // the event of a failure, all database operations are not committed
 type processFn func(*database.DB, []*types.Header) error
 
+// rollbackFn is the function used to remove all indexed state above the
+// supplied height, when the indexed unsafe headers have been reorg'd out
+type rollbackFn func(*database.DB, *big.Int) error
+
+// indexedHeaderFn returns the hash of the header indexed at the supplied
+// height, nil if there is none
+type indexedHeaderFn func(*database.DB, *big.Int) (*common.Hash, error)
+
 type processor struct {
-	fetcher *node.Fetcher
+	ethClient node.EthClient
+	fetcher   *node.Fetcher
+
+	// When set, headers are indexed up to the latest block instead of
+	// the finalized one and rolled back when reorg'd out
+	indexUnsafeHeads bool
 
-	db         *database.DB
-	processFn  processFn
-	processLog log.Logger
+	db              *database.DB
+	processFn       processFn
+	rollbackFn      rollbackFn
+	indexedHeaderFn indexedHeaderFn
+	processLog      log.Logger
+
+	// strategy backs off in between the attempts to process a batch of headers
+	strategy backoff.Strategy
 }
 
-// Start kicks off the processing loop
-func (p processor) Start() {
+// Start kicks off the processing loop, until the supplied context is done
+func (p processor) Start(ctx context.Context) {
 	pollTicker := time.NewTicker(defaultLoopInterval)
+	defer pollTicker.Stop()
+
 	p.processLog.Info("starting processor...")
+	for {
+		select {
+		case <-ctx.Done():
+			p.processLog.Info("stopping processor...")
+			return
+		case <-pollTicker.C:
+		}
+
+		p.indexNextBatch(ctx)
+	}
+}
 
-	// Make this loop stoppable
-	for range pollTicker.C {
-		p.processLog.Info("checking for new headers...")
+// indexNextBatch indexes the next batch of headers. A batch failing to be indexed is
+// retried from the same headers on the next call
+func (p processor) indexNextBatch(ctx context.Context) {
+	p.processLog.Info("checking for new headers...")
 
-		headers, err := p.fetcher.NextFinalizedHeaders()
-		if err != nil {
-			p.processLog.Error("unable to query for headers", "err", err)
-			continue
-		}
+	lastHeader := p.fetcher.LastHeader()
+	headers, err := p.nextHeaders()
+	if err != nil {
+		p.processLog.Error("unable to query for headers", "err", err)
+		return
+	}
 
-		if len(headers) == 0 {
-			p.processLog.Info("no new headers. indexer must be at head...")
-			continue
-		}
+	if len(headers) == 0 {
+		p.processLog.Info("no new headers. indexer must be at head...")
+		return
+	}
 
-		batchLog := p.processLog.New("startHeight", headers[0].Number, "endHeight", headers[len(headers)-1].Number)
-		batchLog.Info("indexing batch of headers")
+	batchLog := p.processLog.New("startHeight", headers[0].Number, "endHeight", headers[len(headers)-1].Number)
+	batchLog.Info("indexing batch of headers")
 
-		// wrap operations within a single transaction
-		err = p.db.Transaction(func(db *database.DB) error {
+	// wrap operations within a single transaction
+	err = backoff.DoCtx(ctx, defaultProcessAttempts, p.strategy, func() error {
+		err := p.db.Transaction(func(db *database.DB) error {
 			return p.processFn(db, headers)
 		})
+		if err != nil {
+			batchLog.Warn("unable to index batch, retrying", "err", err)
+		}
+		return err
+	})
 
-		// TODO(DX-79) if processFn failed, the next poll should retry starting from this same batch of headers
+	if err != nil {
+		// The fetcher is moved back so that the next poll starts from this same batch
+		// of headers. Refetching the batch also picks up any reorg of the unsafe heads
+		batchLog.Error("unable to index batch", "err", err)
+		p.fetcher.Reset(lastHeader)
+	} else {
+		batchLog.Info("done indexing batch")
+	}
+}
 
-		if err != nil {
-			batchLog.Info("unable to index batch", "err", err)
-			panic(err)
-		} else {
-			batchLog.Info("done indexing batch")
+// nextHeaders retrieves the next batch of headers to index. When indexing unsafe heads, a
+// reorg of the indexed headers is rolled back prior to retrieving the headers of the new chain
+func (p processor) nextHeaders() ([]*types.Header, error) {
+	if !p.indexUnsafeHeads {
+		return p.fetcher.NextFinalizedHeaders()
+	}
+
+	headers, err := p.fetcher.NextUnsafeHeaders()
+	if !errors.Is(err, node.ErrFetcherAndProviderMismatchedState) {
+		return headers, err
+	}
+
+	p.processLog.Warn("detected reorg of the indexed headers")
+	if err := p.rollback(); err != nil {
+		return nil, err
+	}
+
+	return p.fetcher.NextUnsafeHeaders()
+}
+
+// rollback removes the indexed state above the latest indexed header that is still part
+// of the canonical chain, and resets the fetcher to continue from this header
+func (p processor) rollback() error {
+	lastHeader := p.fetcher.LastHeader()
+	if lastHeader == nil {
+		return errors.New("no indexed headers to rollback")
+	}
+
+	var commonHeader *types.Header
+	err := p.db.Transaction(func(db *database.DB) error {
+		for height := new(big.Int).Set(lastHeader.Number); height.Sign() >= 0; height.Sub(height, bigOne) {
+			indexedHash, err := p.indexedHeaderFn(db, height)
+			if err != nil {
+				return err
+			} else if indexedHash == nil {
+				return errors.New("reorg deeper than the indexed headers")
+			}
+
+			header, err := p.ethClient.BlockHeaderByNumber(height)
+			if err != nil {
+				return err
+			}
+
+			if header.Hash() == *indexedHash {
+				commonHeader = header
+				p.processLog.Info("rolling back indexed state", "height", height, "hash", header.Hash())
+				return p.rollbackFn(db, height)
+			}
 		}
+
+		return errors.New("no common ancestor with the indexed headers")
+	})
+	if err != nil {
+		return err
 	}
+
+	p.fetcher.Reset(commonHeader)
+	return nil
 }
+
+// startHeader returns the header the fetcher continues from, the last indexed one. When indexing
+// unsafe heads, the last indexed header may have been reorg'd out while the indexer was down. The
+// fetcher then starts from a placeholder of the indexed header, whose hash mismatches the chain, so
+// that the rollback finds the common ancestor with the indexed headers
+func startHeader(ethClient node.EthClient, indexed database.BlockHeader, indexUnsafeHeads bool) (*types.Header, error) {
+	header, err := ethClient.BlockHeaderByHash(indexed.Hash)
+	if errors.Is(err, ethereum.NotFound) && indexUnsafeHeads {
+		return &types.Header{ParentHash: indexed.ParentHash, Number: new(big.Int).Set(indexed.Number.Int), Time: indexed.Timestamp}, nil
+	}
+	return header, err
+}
+
+var bigOne = big.NewInt(1)
@@ -0,0 +1,203 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/indexer/node"
+	"github.com/ethereum-optimism/optimism/op-service/backoff"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// mockEthClient serves a chain of headers, which can be reorg'd
+type mockEthClient struct {
+	headers   []*types.Header
+	finalized uint64
+}
+
+// newMockEthClient creates a chain of numHeaders headers, all finalized
+func newMockEthClient(numHeaders int) *mockEthClient {
+	c := &mockEthClient{}
+	c.reorg(0, numHeaders, 0)
+	c.finalized = uint64(numHeaders - 1)
+	return c
+}
+
+// reorg replaces the headers from the supplied height onwards with those of the fork,
+// up to numHeaders headers in total
+func (c *mockEthClient) reorg(height uint64, numHeaders int, fork byte) {
+	c.headers = c.headers[:height]
+	for i := height; i < uint64(numHeaders); i++ {
+		header := &types.Header{Number: new(big.Int).SetUint64(i), Extra: []byte{fork}}
+		if i > 0 {
+			header.ParentHash = c.headers[i-1].Hash()
+		}
+		c.headers = append(c.headers, header)
+	}
+}
+
+func (c *mockEthClient) FinalizedBlockHeight() (*big.Int, error) {
+	return new(big.Int).SetUint64(c.finalized), nil
+}
+
+func (c *mockEthClient) LatestBlockHeight() (*big.Int, error) {
+	return big.NewInt(int64(len(c.headers) - 1)), nil
+}
+
+func (c *mockEthClient) BlockHeadersByRange(start, end *big.Int) ([]*types.Header, error) {
+	return c.headers[start.Uint64() : end.Uint64()+1], nil
+}
+
+func (c *mockEthClient) BlockHeaderByHash(hash common.Hash) (*types.Header, error) {
+	for _, header := range c.headers {
+		if header.Hash() == hash {
+			return header, nil
+		}
+	}
+	return nil, ethereum.NotFound
+}
+
+func (c *mockEthClient) BlockHeaderByNumber(number *big.Int) (*types.Header, error) {
+	return c.headers[number.Uint64()], nil
+}
+
+func (c *mockEthClient) BlockByHash(common.Hash) (*types.Block, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *mockEthClient) FilterLogs(ethereum.FilterQuery) ([]types.Log, error) {
+	return nil, nil
+}
+
+func (c *mockEthClient) RawRpcClient() *rpc.Client {
+	return nil
+}
+
+// mockIndex records the hashes of the indexed headers by height
+type mockIndex map[uint64]common.Hash
+
+func (i mockIndex) processFn(db *database.DB, headers []*types.Header) error {
+	for _, header := range headers {
+		i[header.Number.Uint64()] = header.Hash()
+	}
+	return nil
+}
+
+func (i mockIndex) indexedHeaderFn(db *database.DB, number *big.Int) (*common.Hash, error) {
+	hash, ok := i[number.Uint64()]
+	if !ok {
+		return nil, nil
+	}
+	return &hash, nil
+}
+
+func newTestProcessor(ethClient *mockEthClient, index mockIndex, indexUnsafeHeads bool) processor {
+	return processor{
+		ethClient:        ethClient,
+		fetcher:          node.NewFetcher(ethClient, nil),
+		indexUnsafeHeads: indexUnsafeHeads,
+		db:               &database.DB{},
+		processFn:        index.processFn,
+		indexedHeaderFn:  index.indexedHeaderFn,
+		processLog:       log.New(),
+		strategy:         backoff.Fixed(0),
+	}
+}
+
+func TestProcessorRetry(t *testing.T) {
+	ethClient := newMockEthClient(10)
+	index := mockIndex{}
+	p := newTestProcessor(ethClient, index, false)
+
+	attempts := 0
+	p.processFn = func(db *database.DB, headers []*types.Header) error {
+		attempts++
+		return errors.New("failed to index")
+	}
+
+	// the batch is attempted a bounded number of times, and fetched again on the next poll
+	p.indexNextBatch(context.Background())
+	require.Equal(t, defaultProcessAttempts, attempts)
+	require.Nil(t, p.fetcher.LastHeader())
+	require.Empty(t, index)
+
+	p.processFn = index.processFn
+	p.indexNextBatch(context.Background())
+	require.Len(t, index, 10)
+	require.Equal(t, ethClient.headers[9].Hash(), p.fetcher.LastHeader().Hash())
+}
+
+func TestProcessorRollback(t *testing.T) {
+	ethClient := newMockEthClient(10)
+	index := mockIndex{}
+	p := newTestProcessor(ethClient, index, true)
+
+	var rolledBackTo *big.Int
+	p.rollbackFn = func(db *database.DB, number *big.Int) error {
+		rolledBackTo = new(big.Int).Set(number)
+		for height := range index {
+			if height > number.Uint64() {
+				delete(index, height)
+			}
+		}
+		return nil
+	}
+
+	p.indexNextBatch(context.Background())
+	require.Len(t, index, 10)
+
+	// the headers above 5 are reorg'd out, the chain growing to 12 headers
+	ethClient.reorg(6, 12, 1)
+	p.indexNextBatch(context.Background())
+	require.NotNil(t, rolledBackTo)
+	require.Equal(t, uint64(5), rolledBackTo.Uint64())
+
+	require.Len(t, index, 12)
+	for height, hash := range index {
+		require.Equal(t, ethClient.headers[height].Hash(), hash)
+	}
+	require.Equal(t, ethClient.headers[11].Hash(), p.fetcher.LastHeader().Hash())
+}
+
+func TestProcessorStartHeaderReorged(t *testing.T) {
+	ethClient := newMockEthClient(10)
+	index := mockIndex{}
+	p := newTestProcessor(ethClient, index, true)
+	p.rollbackFn = func(db *database.DB, number *big.Int) error {
+		for height := range index {
+			if height > number.Uint64() {
+				delete(index, height)
+			}
+		}
+		return nil
+	}
+	p.indexNextBatch(context.Background())
+
+	// the last indexed header is reorg'd out while the indexer is down
+	last := ethClient.headers[9]
+	indexed := database.BlockHeader{Hash: last.Hash(), ParentHash: last.ParentHash, Number: database.U256{Int: last.Number}, Timestamp: last.Time}
+	ethClient.reorg(6, 12, 1)
+
+	_, err := startHeader(ethClient, indexed, false)
+	require.ErrorIs(t, err, ethereum.NotFound)
+	header, err := startHeader(ethClient, indexed, true)
+	require.NoError(t, err)
+	require.Equal(t, uint64(9), header.Number.Uint64())
+
+	// the processor restarted from the stored header rolls back to the common ancestor
+	p.fetcher = node.NewFetcher(ethClient, header)
+	p.indexNextBatch(context.Background())
+	require.Len(t, index, 12)
+	for height, hash := range index {
+		require.Equal(t, ethClient.headers[height].Hash(), hash)
+	}
+}
@@ -0,0 +1,168 @@
+package batcher
+
+import (
+	"context"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/ethereum-optimism/optimism/da"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+)
+
+// maxCertificationAttempts is the number of times a frame certification is started when it
+// times out, before the frame is handed back to the channel manager to be certified again later.
+const maxCertificationAttempts = 3
+
+// daActivationMargin is the time, in seconds, before a DA activation from which frames are
+// certified for the activation, and held back until it is reached. A frame submitted then may be
+// included on either side of the activation, while its certificate is only valid on one side.
+const daActivationMargin = 60
+
+// DAActivations are the L1 timestamps, by increasing time, at which the certificates of the DA
+// change, such as the DAC committee rotations and signature scheme upgrades. A certificate made
+// for an L1 time is only valid in the L1 blocks between the activations surrounding that time.
+type DAActivations []uint64
+
+// NewDAActivations lists the activations of the DA of the rollup
+func NewDAActivations(rcfg *rollup.Config) DAActivations {
+	var activations DAActivations
+	if rcfg.DataAvailabilityComittee == nil {
+		return activations
+	}
+	for _, keyset := range rcfg.DataAvailabilityComittee.Schedule {
+		activations = append(activations, keyset.ActivationTime)
+	}
+	if rcfg.DACV1Time != nil && *rcfg.DACV1Time > 0 {
+		activations = append(activations, *rcfg.DACV1Time)
+	}
+	sort.Slice(activations, func(i, j int) bool { return activations[i] < activations[j] })
+	return activations
+}
+
+// window returns the L1 times from which and until which a certificate made for l1Time is valid
+func (a DAActivations) window(l1Time uint64) (start uint64, end uint64) {
+	end = math.MaxUint64
+	for _, activation := range a {
+		if activation > l1Time {
+			end = activation
+			break
+		}
+		start = activation
+	}
+	return start, end
+}
+
+// CertificationTime returns the L1 time the frames certified at the L1 tip are certified for:
+// the time of the tip, or the next activation once it is within the margin
+func (a DAActivations) CertificationTime(l1Tip uint64) uint64 {
+	if _, end := a.window(l1Tip); end <= l1Tip+daActivationMargin {
+		return end
+	}
+	return l1Tip
+}
+
+// Expired reports whether a frame certified for l1Time may be included after the activation
+// following it, if submitted at the L1 tip
+func (a DAActivations) Expired(l1Time uint64, l1Tip uint64) bool {
+	_, end := a.window(l1Time)
+	return l1Tip+daActivationMargin >= end
+}
+
+// Early reports whether a frame certified for l1Time, an activation, may be included before it
+// if submitted at the L1 tip. The next L1 block is at least one second after the tip.
+func (a DAActivations) Early(l1Time uint64, l1Tip uint64) bool {
+	start, _ := a.window(l1Time)
+	return l1Tip+1 < start
+}
+
+// certifyTxData hands the next frames to the DA until MaxConcurrentCertifications frames are
+// ahead of their L1 submission, or there is no pending frame. Each frame is certified by its
+// own worker for the L1 origin of the given tip, or the DA activation following it closely.
+// Certified frames that may be included after a DA activation are certified again first.
+func (l *BatchSubmitter) certifyTxData(ctx context.Context, l1tip eth.L1BlockRef) error {
+	l.state.ExpireCertified(l1tip.Time)
+	l1Time := l.Channel.DAActivations.CertificationTime(l1tip.Time)
+	for {
+		if l.MaxConcurrentCertifications > 0 {
+			certifying, certified := l.state.CertificationQueue()
+			if uint64(certifying+certified) >= l.MaxConcurrentCertifications {
+				return nil
+			}
+		}
+
+		txdata, err := l.state.TxData(l1tip.ID())
+		if err == io.EOF {
+			l.log.Trace("no transaction data available")
+			return nil
+		} else if err != nil {
+			l.log.Error("unable to get tx data", "err", err)
+			return err
+		}
+
+		l.wg.Add(1)
+		go l.certify(ctx, txdata, l1Time)
+	}
+}
+
+// certify posts the frame to the DA and records it as certified with the transaction
+// referencing it, or as failed so that it is certified again later. The certified
+// notification is sent in both cases.
+func (l *BatchSubmitter) certify(ctx context.Context, txdata txData, l1Time uint64) {
+	defer l.wg.Done()
+	defer l.notifyCertified()
+
+	data := txdata.Bytes()
+	for attempt := 1; ; attempt++ {
+		batchRef, timedOut, err := l.postBatch(ctx, data, l1Time)
+		if timedOut && attempt < maxCertificationAttempts {
+			l.log.Warn("DA certification timed out, certifying again", "id", txdata.ID(), "attempt", attempt, "err", err)
+			l.metr.RecordCertificationTimeout()
+			continue
+		}
+		if err != nil {
+			l.log.Error("unable to publish data to DA", "err", err, "data_size", len(data))
+			// the frames are sent again in a later transaction
+			l.recordFailedTx(txdata.ID(), err)
+			return
+		}
+
+		tx, err := batchRef.ToTx()
+		if err != nil {
+			l.log.Error("unable to construct tx from batch ref", "err", err)
+			l.recordFailedTx(txdata.ID(), err)
+			return
+		}
+		l.state.TxCertified(txdata, tx, l1Time)
+		return
+	}
+}
+
+// postBatch posts the data to the DA within the certification timeout. timedOut is set if
+// the post did not complete in time, while the parent context is still live.
+func (l *BatchSubmitter) postBatch(ctx context.Context, data []byte, l1Time uint64) (batchRef da.BatchRef, timedOut bool, err error) {
+	postCtx := ctx
+	if l.CertificationTimeout > 0 {
+		var cancel context.CancelFunc
+		postCtx, cancel = context.WithTimeout(ctx, l.CertificationTimeout)
+		defer cancel()
+	}
+	batchRef, err = l.DA.PostBatch(postCtx, data, l1Time)
+	timedOut = err != nil && postCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil
+	return batchRef, timedOut, err
+}
+
+// notifyCertified wakes up the submission of the certified frames to L1
+func (l *BatchSubmitter) notifyCertified() {
+	select {
+	case l.certified <- struct{}{}:
+	default:
+	}
+}
+
+// certificationsPending reports whether frames are still being certified by the DA
+func (l *BatchSubmitter) certificationsPending() bool {
+	certifying, _ := l.state.CertificationQueue()
+	return certifying > 0
+}
@@ -58,6 +58,10 @@ type ChannelConfig struct {
 
 	// CompressorConfig contains the configuration for creating new compressors.
 	CompressorConfig compressor.Config
+
+	// DAActivations are the L1 times at which the DA certificates change, the certified frames
+	// are held back or certified again so that they are included on the right side of them.
+	DAActivations DAActivations
 }
 
 // Check validates the [ChannelConfig] parameters.
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync"
 
+	"github.com/ethereum-optimism/optimism/da"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
@@ -21,8 +23,12 @@ var ErrReorg = errors.New("block does not extend existing chain")
 // For simplicity, it only creates a single pending channel at a time & waits for
 // the channel to either successfully be submitted or timeout before creating a new
 // channel.
-// Functions on channelManager are not safe for concurrent access.
+//
+// The frames returned by TxData are certified by the DA before their submission to L1,
+// the channelManager tracks them until they are submitted. Functions on channelManager
+// are safe for concurrent access, so that certifications complete concurrently.
 type channelManager struct {
+	mu   sync.Mutex
 	log  log.Logger
 	metr metrics.Metricer
 	cfg  ChannelConfig
@@ -39,6 +45,13 @@ type channelManager struct {
 	// used to lookup channels by tx ID upon tx success / failure
 	txChannels map[txID]*channel
 
+	// frames being certified by the DA
+	certifying map[txID]struct{}
+	// certified frames waiting for their L1 submission, in certification order
+	certified []certifiedTx
+	// frames to certify again before any other, as they may be included after a DA activation
+	recertify []txData
+
 	// if set to true, prevents production of any new channel frames
 	closed bool
 }
@@ -49,12 +62,23 @@ func NewChannelManager(log log.Logger, metr metrics.Metricer, cfg ChannelConfig)
 		metr:       metr,
 		cfg:        cfg,
 		txChannels: make(map[txID]*channel),
+		certifying: make(map[txID]struct{}),
 	}
 }
 
+// certifiedTx is a frame certified by the DA, with the L1 transaction referencing it
+type certifiedTx struct {
+	txdata txData
+	tx     da.Tx
+	// L1 time the frame is certified for
+	l1Time uint64
+}
+
 // Clear clears the entire state of the channel manager.
 // It is intended to be used after an L2 reorg.
 func (s *channelManager) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.log.Trace("clearing channel manager state")
 	s.blocks = s.blocks[:0]
 	s.tip = common.Hash{}
@@ -62,11 +86,21 @@ func (s *channelManager) Clear() {
 	s.currentChannel = nil
 	s.channelQueue = nil
 	s.txChannels = make(map[txID]*channel)
+	s.certifying = make(map[txID]struct{})
+	s.certified = nil
+	s.recertify = nil
+	s.recordCertificationQueue()
 }
 
-// TxFailed records a transaction as failed. It will attempt to resubmit the data
-// in the failed transaction.
+// TxFailed records a transaction, or the certification of its frame, as failed. It will
+// attempt to resubmit the data in the failed transaction.
 func (s *channelManager) TxFailed(id txID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.certifying[id]; ok {
+		delete(s.certifying, id)
+		s.recordCertificationQueue()
+	}
 	if channel, ok := s.txChannels[id]; ok {
 		delete(s.txChannels, id)
 		channel.TxFailed(id)
@@ -84,6 +118,8 @@ func (s *channelManager) TxFailed(id txID) {
 // resubmitted.
 // This function may reset the pending channel if the pending channel has timed out.
 func (s *channelManager) TxConfirmed(id txID, inclusionBlock eth.BlockID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if channel, ok := s.txChannels[id]; ok {
 		delete(s.txChannels, id)
 		done, blocks := channel.TxConfirmed(id, inclusionBlock)
@@ -125,15 +161,89 @@ func (s *channelManager) nextTxData(channel *channel) (txData, error) {
 	}
 	tx := channel.NextTxData()
 	s.txChannels[tx.ID()] = channel
+	s.certifying[tx.ID()] = struct{}{}
+	s.recordCertificationQueue()
 	return tx, nil
 }
 
-// TxData returns the next tx data that should be submitted to L1.
+// TxCertified records the frame as certified by the DA for the L1 time, to be returned by
+// CertifiedTxData. Frames of channels cleared during their certification are dropped.
+func (s *channelManager) TxCertified(txdata txData, tx da.Tx, l1Time uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.certifying[txdata.ID()]; !ok {
+		s.log.Info("dropping certified frame of a cleared channel", "id", txdata.ID())
+		return
+	}
+	delete(s.certifying, txdata.ID())
+	s.certified = append(s.certified, certifiedTx{txdata, tx, l1Time})
+	s.recordCertificationQueue()
+}
+
+// CertifiedTxData returns the next certified frame that should be submitted to L1 at the L1 tip,
+// with the transaction referencing it. It returns io.EOF if there's no certified frame, or if the
+// next one may be included on the wrong side of a DA activation.
+func (s *channelManager) CertifiedTxData(l1Tip uint64) (txData, da.Tx, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.certified) == 0 {
+		return txData{}, da.Tx{}, io.EOF
+	}
+	next := s.certified[0]
+	if s.cfg.DAActivations.Early(next.l1Time, l1Tip) || s.cfg.DAActivations.Expired(next.l1Time, l1Tip) {
+		return txData{}, da.Tx{}, io.EOF
+	}
+	s.certified = s.certified[1:]
+	s.recordCertificationQueue()
+	return next.txdata, next.tx, nil
+}
+
+// ExpireCertified moves the certified frames that may be included after a DA activation if
+// submitted at the L1 tip back to the frames to certify, ahead of the others.
+func (s *channelManager) ExpireCertified(l1Tip uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	certified := s.certified[:0]
+	for _, c := range s.certified {
+		if s.cfg.DAActivations.Expired(c.l1Time, l1Tip) {
+			s.log.Info("certifying frame again for a DA activation", "id", c.txdata.ID(), "certified_for", c.l1Time, "l1_tip", l1Tip)
+			s.recertify = append(s.recertify, c.txdata)
+			continue
+		}
+		certified = append(certified, c)
+	}
+	s.certified = certified
+	s.recordCertificationQueue()
+}
+
+// CertificationQueue returns the number of frames being certified and of certified frames
+// waiting for their L1 submission.
+func (s *channelManager) CertificationQueue() (certifying int, certified int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.certifying), len(s.certified)
+}
+
+func (s *channelManager) recordCertificationQueue() {
+	s.metr.RecordCertificationQueue(len(s.certifying), len(s.certified))
+}
+
+// TxData returns the next tx data that should be certified by the DA, then submitted to L1.
 //
 // It currently only uses one frame per transaction. If the pending channel is
 // full, it only returns the remaining frames of this channel until it got
 // successfully fully sent to L1. It returns io.EOF if there's no pending frame.
 func (s *channelManager) TxData(l1Head eth.BlockID) (txData, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.recertify) > 0 {
+		tx := s.recertify[0]
+		s.recertify = s.recertify[1:]
+		s.certifying[tx.ID()] = struct{}{}
+		s.recordCertificationQueue()
+		return tx, nil
+	}
+
 	var firstWithFrame *channel
 	for _, ch := range s.channelQueue {
 		if ch.HasFrame() {
@@ -296,6 +406,8 @@ func (s *channelManager) outputFrames() error {
 // if the block does not extend the last block loaded into the state. If no
 // blocks were added yet, the parent hash check is skipped.
 func (s *channelManager) AddL2Block(block *types.Block) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if s.tip != (common.Hash{}) && s.tip != block.ParentHash() {
 		return ErrReorg
 	}
@@ -322,6 +434,8 @@ func l2BlockRefFromBlockAndL1Info(block *types.Block, l1info derive.L1BlockInfo)
 // and prevents the creation of any new channels.
 // Any outputted frames still need to be published.
 func (s *channelManager) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if s.closed {
 		return nil
 	}
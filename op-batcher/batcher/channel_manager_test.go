@@ -7,9 +7,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/da"
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	derivetest "github.com/ethereum-optimism/optimism/op-node/rollup/derive/test"
 	"github.com/ethereum-optimism/optimism/op-node/testlog"
@@ -195,6 +197,133 @@ func TestChannelManager_TxResend(t *testing.T) {
 	require.Len(fs, 1)
 }
 
+// TestChannelManager_Certification tests the tracking of the frames certified by the DA
+// ahead of their L1 submission.
+func TestChannelManager_Certification(t *testing.T) {
+	require := require.New(t)
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	log := testlog.Logger(t, log.LvlError)
+	m := NewChannelManager(log, metrics.NoopMetrics,
+		ChannelConfig{
+			MaxFrameSize: 120_000,
+			CompressorConfig: compressor.Config{
+				TargetFrameSize:  1,
+				TargetNumFrames:  1,
+				ApproxComprRatio: 1.0,
+			},
+		})
+
+	a, _ := derivetest.RandomL2Block(rng, 4)
+	require.NoError(m.AddL2Block(a))
+
+	txdata0, err := m.TxData(eth.BlockID{})
+	require.NoError(err)
+	certifying, certified := m.CertificationQueue()
+	require.Equal(1, certifying)
+	require.Equal(0, certified)
+	_, _, err = m.CertifiedTxData(0)
+	require.ErrorIs(err, io.EOF)
+
+	// a failed certification requeues the frame
+	m.TxFailed(txdata0.ID())
+	certifying, _ = m.CertificationQueue()
+	require.Equal(0, certifying)
+	txdata1, err := m.TxData(eth.BlockID{})
+	require.NoError(err)
+	require.Equal(txdata0.Bytes(), txdata1.Bytes())
+
+	tx := da.Tx{Data: []byte{0xaa}}
+	m.TxCertified(txdata1, tx, 0)
+	certifying, certified = m.CertificationQueue()
+	require.Equal(0, certifying)
+	require.Equal(1, certified)
+
+	gotTxData, gotTx, err := m.CertifiedTxData(0)
+	require.NoError(err)
+	require.Equal(txdata1.ID(), gotTxData.ID())
+	require.Equal(tx, gotTx)
+	_, certified = m.CertificationQueue()
+	require.Equal(0, certified)
+
+	// the frames certified after a clear are dropped
+	m.TxFailed(txdata1.ID())
+	txdata2, err := m.TxData(eth.BlockID{})
+	require.NoError(err)
+	m.Clear()
+	m.TxCertified(txdata2, tx, 0)
+	_, _, err = m.CertifiedTxData(0)
+	require.ErrorIs(err, io.EOF)
+}
+
+// TestChannelManager_DAActivation ensures that the certified frames are submitted on the side of
+// a DA activation their certificate is valid on.
+func TestChannelManager_DAActivation(t *testing.T) {
+	require := require.New(t)
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	log := testlog.Logger(t, log.LvlError)
+	activation := uint64(1000)
+	m := NewChannelManager(log, metrics.NoopMetrics,
+		ChannelConfig{
+			MaxFrameSize: 120_000,
+			CompressorConfig: compressor.Config{
+				TargetFrameSize:  1,
+				TargetNumFrames:  1,
+				ApproxComprRatio: 1.0,
+			},
+			DAActivations: DAActivations{activation},
+		})
+
+	a, _ := derivetest.RandomL2Block(rng, 4)
+	require.NoError(m.AddL2Block(a))
+	txdata, err := m.TxData(eth.BlockID{})
+	require.NoError(err)
+	tx := da.Tx{Data: []byte{0xaa}}
+	m.TxCertified(txdata, tx, 900)
+
+	// close to the activation, the frame may be included after it
+	closeToActivation := activation - daActivationMargin
+	_, _, err = m.CertifiedTxData(closeToActivation)
+	require.ErrorIs(err, io.EOF)
+	m.ExpireCertified(closeToActivation)
+	recertified, err := m.TxData(eth.BlockID{})
+	require.NoError(err)
+	require.Equal(txdata.ID(), recertified.ID())
+
+	// certified for the activation, it is held back until the next L1 block is past it
+	l1Time := m.cfg.DAActivations.CertificationTime(closeToActivation)
+	require.Equal(activation, l1Time)
+	m.TxCertified(recertified, tx, l1Time)
+	_, _, err = m.CertifiedTxData(activation - 2)
+	require.ErrorIs(err, io.EOF)
+	gotTxData, _, err := m.CertifiedTxData(activation - 1)
+	require.NoError(err)
+	require.Equal(txdata.ID(), gotTxData.ID())
+}
+
+func TestDAActivations(t *testing.T) {
+	activations := DAActivations{1000, 2000}
+
+	require.Equal(t, uint64(500), activations.CertificationTime(500))
+	require.Equal(t, uint64(1000), activations.CertificationTime(1000-daActivationMargin))
+	require.Equal(t, uint64(1500), activations.CertificationTime(1500))
+
+	require.False(t, activations.Expired(500, 1000-daActivationMargin-1))
+	require.True(t, activations.Expired(500, 1000-daActivationMargin))
+	require.False(t, activations.Expired(2500, 1<<40))
+
+	require.True(t, activations.Early(1000, 998))
+	require.False(t, activations.Early(1000, 999))
+	require.False(t, activations.Early(500, 0))
+
+	require.Empty(t, NewDAActivations(&rollup.Config{}))
+	v1Time := uint64(1500)
+	rcfg := &rollup.Config{
+		DataAvailabilityComittee: &rollup.DAC{Schedule: []rollup.ScheduledDACKeyset{{ActivationTime: 1000}, {ActivationTime: 2000}}},
+		DACV1Time:                &v1Time,
+	}
+	require.Equal(t, DAActivations{1000, 1500, 2000}, NewDAActivations(rcfg))
+}
+
 // TestChannelManagerCloseBeforeFirstUse ensures that the channel manager
 // will not produce any frames if closed immediately.
 func TestChannelManagerCloseBeforeFirstUse(t *testing.T) {
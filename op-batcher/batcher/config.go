@@ -15,6 +15,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/sources"
+	ophttp "github.com/ethereum-optimism/optimism/op-service/httputil"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -34,6 +35,12 @@ type Config struct {
 	PollInterval           time.Duration
 	MaxPendingTransactions uint64
 
+	// MaxConcurrentCertifications bounds the frames certified by the DA ahead of their L1
+	// submission (0 == no limit), and CertificationTimeout restarts the certifications that
+	// did not complete in time (0 == no timeout).
+	MaxConcurrentCertifications uint64
+	CertificationTimeout        time.Duration
+
 	// RollupConfig is queried at startup
 	Rollup *rollup.Config
 
@@ -49,7 +56,7 @@ func (c *Config) Check() error {
 	if err := c.Channel.Check(); err != nil {
 		return err
 	}
-	if c.Rollup.DataAvailabilityComittee != nil && c.DA == nil {
+	if (c.Rollup.DataAvailabilityComittee != nil || c.Rollup.BlobDA != nil) && c.DA == nil {
 		return errors.New("da inbox address set but no da client configured")
 	}
 	return nil
@@ -67,6 +74,31 @@ type CLIConfig struct {
 
   CentralizedDAApi string
 
+	// DACMembers are the DAC members HTTP api URLs, as <hex public key>=<url> entries
+	// covering the members of every rollup config keyset.
+	// If set, the batcher aggregates the members signatures itself.
+	DACMembers []string
+
+	// DACMemberTimeout is the timeout of a batch post to a single DAC member.
+	DACMemberTimeout time.Duration
+
+	// DACAuthPrivateKey authenticates the batches posted to the DAC.
+	// If empty, the tx manager private key is used, if any.
+	DACAuthPrivateKey string
+
+	// DACFallbackTimeout is how long the DAC or the blob DA may be unavailable before batches are
+	// posted to the batch inbox as raw calldata. Zero disables the fallback, which is also only used
+	// once activated by the rollup config.
+	DACFallbackTimeout time.Duration
+
+	// BlobDARPC is the JSON-RPC URL of the DA node of the rollup config blob DA.
+	BlobDARPC string
+	// BlobDAAuthToken authenticates the batcher to the blob DA node, if set.
+	BlobDAAuthToken string
+
+	// DAHTTPConfig configures the HTTP client of the centralized DA api, the DAC members and the blob DA node.
+	DAHTTPConfig ophttp.ClientCLIConfig
+
 	// MaxChannelDuration is the maximum duration (in #L1-blocks) to keep a
 	// channel open. This allows to more eagerly send batcher transactions
 	// during times of low L2 transaction volume. Note that the effective
@@ -90,6 +122,14 @@ type CLIConfig struct {
 	// transactions sent to the transaction manager (0 == no limit).
 	MaxPendingTransactions uint64
 
+	// MaxConcurrentCertifications is the maximum number of frames certified by the
+	// DA ahead of their submission to L1 (0 == no limit).
+	MaxConcurrentCertifications uint64
+
+	// CertificationTimeout is the timeout of a frame certification by the DA, after
+	// which the certification is started again (0 == no timeout).
+	CertificationTimeout time.Duration
+
 	// MaxL1TxSize is the maximum size of a batch tx submitted to L1.
 	MaxL1TxSize uint64
 
@@ -119,6 +159,9 @@ func (c CLIConfig) Check() error {
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
+	if err := c.DAHTTPConfig.Check(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -130,11 +173,20 @@ func NewConfig(ctx *cli.Context) CLIConfig {
 		L2EthRpc:        ctx.GlobalString(flags.L2EthRpcFlag.Name),
 		RollupRpc:       ctx.GlobalString(flags.RollupRpcFlag.Name),
     CentralizedDAApi: ctx.GlobalString(flags.CentralizedDAApiFlag.Name),
+		DACMembers:       ctx.GlobalStringSlice(flags.DACMembersFlag.Name),
+		DACMemberTimeout: ctx.GlobalDuration(flags.DACMemberTimeoutFlag.Name),
+		DACAuthPrivateKey: ctx.GlobalString(flags.DACAuthPrivateKeyFlag.Name),
+		DACFallbackTimeout: ctx.GlobalDuration(flags.DACFallbackTimeoutFlag.Name),
+		BlobDARPC:          ctx.GlobalString(flags.BlobDARPCFlag.Name),
+		BlobDAAuthToken:    ctx.GlobalString(flags.BlobDAAuthTokenFlag.Name),
+		DAHTTPConfig:       ophttp.ReadClientCLIConfig(ctx, flags.DAHTTPFlagPrefix),
 		SubSafetyMargin: ctx.GlobalUint64(flags.SubSafetyMarginFlag.Name),
 		PollInterval:    ctx.GlobalDuration(flags.PollIntervalFlag.Name),
 
 		/* Optional Flags */
 		MaxPendingTransactions: ctx.GlobalUint64(flags.MaxPendingTransactionsFlag.Name),
+		MaxConcurrentCertifications: ctx.GlobalUint64(flags.MaxConcurrentCertificationsFlag.Name),
+		CertificationTimeout:        ctx.GlobalDuration(flags.CertificationTimeoutFlag.Name),
 		MaxChannelDuration:     ctx.GlobalUint64(flags.MaxChannelDurationFlag.Name),
 		MaxL1TxSize:            ctx.GlobalUint64(flags.MaxL1TxSizeBytesFlag.Name),
 		Stopped:                ctx.GlobalBool(flags.StoppedFlag.Name),
@@ -7,18 +7,24 @@ import (
 	"io"
 	"math/big"
 	_ "net/http/pprof"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/da"
+	"github.com/ethereum-optimism/optimism/da/celestia"
 	"github.com/ethereum-optimism/optimism/da/dac"
+	"github.com/ethereum-optimism/optimism/da/fallback"
 	"github.com/ethereum-optimism/optimism/da/rollupda"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	opclient "github.com/ethereum-optimism/optimism/op-service/client"
+	ophttp "github.com/ethereum-optimism/optimism/op-service/httputil"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -43,6 +49,8 @@ type BatchSubmitter struct {
 	lastL1Tip       eth.L1BlockRef
 
 	state *channelManager
+	// notified when a frame certification by the DA completes
+	certified chan struct{}
 }
 
 // NewBatchSubmitterFromCLIConfig initializes the BatchSubmitter, gathering any resources
@@ -79,12 +87,50 @@ func NewBatchSubmitterFromCLIConfig(cfg CLIConfig, l log.Logger, m metrics.Metri
 
   daClient := rollupda.NewClient(rcfg.BatchInboxAddress)
   if rcfg.DataAvailabilityComittee != nil {
-    keyset, err := dac.NewKeySetFromString(rcfg.DataAvailabilityComittee.PublicKeys)
+    committees, err := rcfg.DataAvailabilityComittee.Committees()
     if err != nil {
-      return nil, fmt.Errorf("could not create DAC keyset: %w", err)
+      return nil, fmt.Errorf("could not create DAC committees: %w", err)
     }
-    daClient = dac.NewClient(cfg.CentralizedDAApi, rcfg.BatchInboxAddress, keyset)
+    auth, err := newDACAuth(cfg, rcfg.L2ChainID)
+    if err != nil {
+      return nil, err
+    }
+    schemes := dac.SchemeConfig{ChainID: rcfg.L2ChainID, V1Time: rcfg.DACV1Time}
+    httpClient, err := ophttp.NewClient(l, cfg.DAHTTPConfig)
+    if err != nil {
+      return nil, fmt.Errorf("could not create DA http client: %w", err)
+    }
+    if len(cfg.DACMembers) > 0 {
+      memberUrls, err := dac.ParseMemberUrls(cfg.DACMembers)
+      if err != nil {
+        return nil, err
+      }
+      daClient, err = dac.NewAggregator(l, memberUrls, rcfg.BatchInboxAddress, committees, schemes, cfg.DACMemberTimeout, m, auth, httpClient)
+      if err != nil {
+        return nil, fmt.Errorf("could not create DAC aggregator: %w", err)
+      }
+    } else {
+      daClient = dac.NewClient(cfg.CentralizedDAApi, rcfg.BatchInboxAddress, committees, auth, schemes, httpClient)
+    }
+    daClient = fallback.NewClient(l, daClient, rcfg.BatchInboxAddress, rcfg.DACFallbackTime, cfg.DACFallbackTimeout)
+  } else if rcfg.BlobDA != nil {
+    httpClient, err := ophttp.NewClient(l, cfg.DAHTTPConfig)
+    if err != nil {
+      return nil, fmt.Errorf("could not create DA http client: %w", err)
+    }
+    daClient, err = celestia.NewClient(cfg.BlobDARPC, cfg.BlobDAAuthToken, rcfg.BlobDA.Namespace, rcfg.BatchInboxAddress, httpClient)
+    if err != nil {
+      return nil, fmt.Errorf("could not create blob DA client: %w", err)
+    }
+    daClient = fallback.NewClient(l, daClient, rcfg.BatchInboxAddress, rcfg.DACFallbackTime, cfg.DACFallbackTimeout)
   }
+  daClient = da.NewMeteredClient(daClient, m)
+
+	maxFrameSize := cfg.MaxL1TxSize - 1 // subtract 1 byte for version
+	if rcfg.DACFallbackTime != nil && cfg.DACFallbackTimeout != 0 && (rcfg.DataAvailabilityComittee != nil || rcfg.BlobDA != nil) {
+		// frames posted to L1 calldata are also prefixed by the fallback header
+		maxFrameSize--
+	}
 
 	batcherCfg := Config{
 		L1Client:               l1Client,
@@ -92,6 +138,8 @@ func NewBatchSubmitterFromCLIConfig(cfg CLIConfig, l log.Logger, m metrics.Metri
 		RollupNode:             rollupClient,
 		PollInterval:           cfg.PollInterval,
 		MaxPendingTransactions: cfg.MaxPendingTransactions,
+		MaxConcurrentCertifications: cfg.MaxConcurrentCertifications,
+		CertificationTimeout:        cfg.CertificationTimeout,
 		NetworkTimeout:         cfg.TxMgrConfig.NetworkTimeout,
 		TxManager:              txManager,
 		Rollup:                 rcfg,
@@ -101,8 +149,9 @@ func NewBatchSubmitterFromCLIConfig(cfg CLIConfig, l log.Logger, m metrics.Metri
 			ChannelTimeout:     rcfg.ChannelTimeout,
 			MaxChannelDuration: cfg.MaxChannelDuration,
 			SubSafetyMargin:    cfg.SubSafetyMargin,
-			MaxFrameSize:       cfg.MaxL1TxSize - 1, // subtract 1 byte for version
+			MaxFrameSize:       maxFrameSize,
 			CompressorConfig:   cfg.CompressorConfig.Config(),
+			DAActivations:      NewDAActivations(rcfg),
 		},
 	}
 
@@ -114,6 +163,24 @@ func NewBatchSubmitterFromCLIConfig(cfg CLIConfig, l log.Logger, m metrics.Metri
 	return NewBatchSubmitter(ctx, batcherCfg, l, m)
 }
 
+// newDACAuth creates the authentication of the batches posted to the DAC, from the DAC
+// specific key or else the tx manager private key, bound to the L2 chain. It returns nil if no
+// key is available.
+func newDACAuth(cfg CLIConfig, chainID *big.Int) (dac.BatchAuth, error) {
+	key := cfg.DACAuthPrivateKey
+	if key == "" {
+		key = cfg.TxMgrConfig.PrivateKey
+	}
+	if key == "" {
+		return nil, nil
+	}
+	privKey, err := crypto.HexToECDSA(strings.TrimPrefix(key, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the DAC auth private key: %w", err)
+	}
+	return dac.PrivateKeyBatchAuth(privKey, chainID), nil
+}
+
 // NewBatchSubmitter initializes the BatchSubmitter, gathering any resources
 // that will be needed during operation.
 func NewBatchSubmitter(ctx context.Context, cfg Config, l log.Logger, m metrics.Metricer) (*BatchSubmitter, error) {
@@ -131,6 +198,8 @@ func NewBatchSubmitter(ctx context.Context, cfg Config, l log.Logger, m metrics.
 		Config: cfg,
 		txMgr:  cfg.TxManager,
 		state:  NewChannelManager(l, m, cfg.Channel),
+
+		certified: make(chan struct{}, 1),
 	}, nil
 
 }
@@ -319,6 +388,8 @@ func (l *BatchSubmitter) loop() {
 			l.publishStateToL1(queue, receiptsCh, false)
 		case r := <-receiptsCh:
 			l.handleReceipt(r)
+		case <-l.certified:
+			l.publishStateToL1(queue, receiptsCh, false)
 		case <-l.shutdownCtx.Done():
 			err := l.state.Close()
 			if err != nil {
@@ -345,6 +416,15 @@ func (l *BatchSubmitter) publishStateToL1(queue *txmgr.Queue[txData], receiptsCh
 		}()
 		for {
 			err := l.publishTxToL1(l.killCtx, queue, receiptsCh)
+			if err == io.EOF && drain && l.certificationsPending() {
+				// the frames being certified are sent once certified
+				select {
+				case <-l.certified:
+					continue
+				case <-l.killCtx.Done():
+					return
+				}
+			}
 			if err != nil {
 				if drain && err != io.EOF {
 					l.log.Error("error sending tx while draining state", "err", err)
@@ -374,38 +454,28 @@ func (l *BatchSubmitter) publishTxToL1(ctx context.Context, queue *txmgr.Queue[t
 	}
 	l.recordL1Tip(l1tip)
 
-	// Collect next transaction data
-	txdata, err := l.state.TxData(l1tip.ID())
-	if err == io.EOF {
-		l.log.Trace("no transaction data available")
+	// Hand the next frames to the DA ahead of their submission
+	if err := l.certifyTxData(ctx, l1tip); err != nil {
 		return err
-	} else if err != nil {
-		l.log.Error("unable to get tx data", "err", err)
+	}
+
+	// Collect next certified transaction data
+	txdata, tx, err := l.state.CertifiedTxData(l1tip.Time)
+	if err == io.EOF {
+		l.log.Trace("no certified transaction data available")
 		return err
 	}
 
-	l.sendTransaction(txdata, queue, receiptsCh)
+	l.sendTransaction(txdata, tx, queue, receiptsCh)
 	return nil
 }
 
-// sendTransaction creates & submits a transaction to the batch inbox address with the given `data`.
+// sendTransaction creates & submits a transaction to the batch inbox address referencing the
+// certified `txdata`.
 // It currently uses the underlying `txmgr` to handle transaction sending & price management.
 // This is a blocking method. It should not be called concurrently.
-func (l *BatchSubmitter) sendTransaction(txdata txData, queue *txmgr.Queue[txData], receiptsCh chan txmgr.TxReceipt[txData]) {
+func (l *BatchSubmitter) sendTransaction(txdata txData, tx da.Tx, queue *txmgr.Queue[txData], receiptsCh chan txmgr.TxReceipt[txData]) {
 	// Do the gas estimation offline. A value of 0 will cause the [txmgr] to estimate the gas limit.
-	data := txdata.Bytes()
-
-  batchRef, err := l.DA.PostBatch(data)
-  if err != nil {
-    l.log.Error("unable to publish data to DA", "err", err, "data_size", len(data))
-    return
-  }
-
-  tx, err := batchRef.ToTx()
-  if err != nil {
-    l.log.Error("unable to construct tx from batch ref", "err")
-  }
-
   // NOTE(kelvyne): this only works while we post data to EOA addresses.
   // If this changes, we need to move gas limit calculation to the underlying da.Client 
 	intrinsicGas, err := core.IntrinsicGas(tx.Data, nil, false, true, true, false)
@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	ophttp "github.com/ethereum-optimism/optimism/op-service/httputil"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -18,6 +19,9 @@ import (
 
 const EnvVarPrefix = "OP_BATCHER"
 
+// DAHTTPFlagPrefix prefixes the flags of the HTTP client of the centralized DA api, the DAC members and the blob DA node
+const DAHTTPFlagPrefix = "da"
+
 var (
 	// Required flags
 	L1EthRpcFlag = cli.StringFlag{
@@ -41,6 +45,39 @@ var (
     Usage: "HTTP api URL for Centralized DA",
     Required: false,
     EnvVar: opservice.PrefixEnvVar(EnvVarPrefix, "CENTRALIZED_DA_API"),
+  }
+  DACMembersFlag = cli.StringSliceFlag{
+    Name: "dac-members",
+    Usage: "HTTP api URLs of the members of every rollup config DAC keyset, as <hex public key>=<url>. " +
+      "When set, batches are posted to the members directly instead of the centralized DA api",
+    EnvVar: opservice.PrefixEnvVar(EnvVarPrefix, "DAC_MEMBERS"),
+  }
+  BlobDARPCFlag = cli.StringFlag{
+    Name: "blob-da.rpc",
+    Usage: "JSON-RPC URL of the DA node posting the batches as blobs of the rollup config blob DA namespace",
+    EnvVar: opservice.PrefixEnvVar(EnvVarPrefix, "BLOB_DA_RPC"),
+  }
+  BlobDAAuthTokenFlag = cli.StringFlag{
+    Name: "blob-da.auth-token",
+    Usage: "Auth token of the blob DA node JSON-RPC API",
+    EnvVar: opservice.PrefixEnvVar(EnvVarPrefix, "BLOB_DA_AUTH_TOKEN"),
+  }
+  DACAuthPrivateKeyFlag = cli.StringFlag{
+    Name: "dac-auth-private-key",
+    Usage: "secp256k1 private key authenticating the batches posted to the DAC, defaults to the tx manager private key",
+    EnvVar: opservice.PrefixEnvVar(EnvVarPrefix, "DAC_AUTH_PRIVATE_KEY"),
+  }
+  DACMemberTimeoutFlag = cli.DurationFlag{
+    Name: "dac-member-timeout",
+    Usage: "Timeout of a batch post to a single DAC member",
+    Value: 10 * time.Second,
+    EnvVar: opservice.PrefixEnvVar(EnvVarPrefix, "DAC_MEMBER_TIMEOUT"),
+  }
+  DACFallbackTimeoutFlag = cli.DurationFlag{
+    Name: "dac-fallback-timeout",
+    Usage: "Duration the DAC or the blob DA may be unavailable before batches are posted to the batch inbox as raw calldata, " +
+      "the DA is tried again after the same duration. 0 to disable the fallback",
+    EnvVar: opservice.PrefixEnvVar(EnvVarPrefix, "DAC_FALLBACK_TIMEOUT"),
   }
 	SubSafetyMarginFlag = cli.Uint64Flag{
 		Name: "sub-safety-margin",
@@ -62,6 +99,18 @@ var (
 		Value:  1,
 		EnvVar: opservice.PrefixEnvVar(EnvVarPrefix, "MAX_PENDING_TX"),
 	}
+  MaxConcurrentCertificationsFlag = cli.Uint64Flag{
+    Name: "max-concurrent-certifications",
+    Usage: "The maximum number of frames certified by the DA ahead of their L1 submission. 0 for no limit.",
+    Value: 4,
+    EnvVar: opservice.PrefixEnvVar(EnvVarPrefix, "MAX_CONCURRENT_CERTIFICATIONS"),
+  }
+  CertificationTimeoutFlag = cli.DurationFlag{
+    Name: "certification-timeout",
+    Usage: "Timeout of a frame certification by the DA, after which the certification is started again. 0 for no timeout",
+    Value: 2 * time.Minute,
+    EnvVar: opservice.PrefixEnvVar(EnvVarPrefix, "CERTIFICATION_TIMEOUT"),
+  }
 	MaxChannelDurationFlag = cli.Uint64Flag{
 		Name:   "max-channel-duration",
 		Usage:  "The maximum duration of L1-blocks to keep a channel open. 0 to disable.",
@@ -98,6 +147,14 @@ var optionalFlags = []cli.Flag{
 	StoppedFlag,
 	SequencerHDPathFlag,
   CentralizedDAApiFlag,
+  DACMembersFlag,
+  DACMemberTimeoutFlag,
+  DACAuthPrivateKeyFlag,
+  DACFallbackTimeoutFlag,
+  BlobDARPCFlag,
+  BlobDAAuthTokenFlag,
+  MaxConcurrentCertificationsFlag,
+  CertificationTimeoutFlag,
 }
 
 func init() {
@@ -108,6 +165,7 @@ func init() {
 	optionalFlags = append(optionalFlags, rpc.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, txmgr.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, compressor.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, ophttp.ClientCLIFlags(opservice.PrefixEnvVar(EnvVarPrefix, "DA"), DAHTTPFlagPrefix)...)
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
@@ -2,6 +2,8 @@ package metrics
 
 import (
 	"context"
+	"strconv"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -9,6 +11,8 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ethereum-optimism/optimism/da"
+	"github.com/ethereum-optimism/optimism/da/dac"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -27,6 +31,12 @@ type Metricer interface {
 	// Record Tx metrics
 	txmetrics.TxMetricer
 
+	// Record DA client calls
+	da.Metricer
+
+	// Record DAC members interactions
+	dac.Metricer
+
 	RecordLatestL1Block(l1ref eth.L1BlockRef)
 	RecordL2BlocksLoaded(l2ref eth.L2BlockRef)
 	RecordChannelOpened(id derive.ChannelID, numPendingBlocks int)
@@ -41,6 +51,9 @@ type Metricer interface {
 	RecordBatchTxSuccess()
 	RecordBatchTxFailed()
 
+	RecordCertificationQueue(certifying int, certified int)
+	RecordCertificationTimeout()
+
 	Document() []opmetrics.DocumentedMetric
 }
 
@@ -73,6 +86,17 @@ type Metrics struct {
 	channelOutputBytesTotal prometheus.Counter
 
 	batcherTxEvs opmetrics.EventVec
+
+	daPostDuration *prometheus.HistogramVec
+	daPostedBytes  prometheus.Counter
+
+	daCertificationQueue    prometheus.GaugeVec
+	daCertificationTimeouts prometheus.Counter
+
+	dacMemberSignatureDuration *prometheus.HistogramVec
+	dacMemberFailures          *prometheus.CounterVec
+	dacCertificationDuration   prometheus.Histogram
+	dacBatchSigners            prometheus.Histogram
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -173,6 +197,60 @@ func NewMetrics(procName string) *Metrics {
 		}),
 
 		batcherTxEvs: opmetrics.NewEventVec(factory, ns, "", "batcher_tx", "BatcherTx", []string{"stage"}),
+
+		daPostDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Subsystem: "da",
+			Name:      "post_batch_seconds",
+			Help:      "Duration of a batch post to the DA, by result: ok, or the class of the DA error.",
+			Buckets:   prometheus.ExponentialBuckets(0.05, 2, 10),
+		}, []string{"result"}),
+		daPostedBytes: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "da",
+			Name:      "posted_bytes_total",
+			Help:      "Total size of the batches successfully posted to the DA.",
+		}),
+		daCertificationQueue: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: "da",
+			Name:      "certification_queue_frames",
+			Help:      "Number of frames ahead of their L1 submission, by stage: certifying, or certified.",
+		}, []string{"stage"}),
+		daCertificationTimeouts: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "da",
+			Name:      "certification_timeouts_total",
+			Help:      "Number of frame certifications that timed out and were started again.",
+		}),
+
+		dacMemberSignatureDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Subsystem: "dac",
+			Name:      "member_signature_seconds",
+			Help:      "Duration of a successful batch post to a DAC member, by member index.",
+			Buckets:   prometheus.ExponentialBuckets(0.05, 2, 10),
+		}, []string{"member"}),
+		dacMemberFailures: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "dac",
+			Name:      "member_failures_total",
+			Help:      "Number of batch posts a DAC member failed to sign, by member index.",
+		}, []string{"member"}),
+		dacCertificationDuration: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Subsystem: "dac",
+			Name:      "certification_seconds",
+			Help:      "Duration until enough DAC members signed a batch.",
+			Buckets:   prometheus.ExponentialBuckets(0.05, 2, 10),
+		}),
+		dacBatchSigners: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Subsystem: "dac",
+			Name:      "batch_signers",
+			Help:      "Number of DAC members signatures aggregated per certified batch.",
+			Buckets:   prometheus.LinearBuckets(1, 1, 16),
+		}),
 	}
 }
 
@@ -212,6 +290,9 @@ const (
 	TxStageSubmitted = "submitted"
 	TxStageSuccess   = "success"
 	TxStageFailed    = "failed"
+
+	CertificationStageCertifying = "certifying"
+	CertificationStageCertified  = "certified"
 )
 
 func (m *Metrics) RecordLatestL1Block(l1ref eth.L1BlockRef) {
@@ -295,6 +376,39 @@ func (m *Metrics) RecordBatchTxFailed() {
 	m.batcherTxEvs.Record(TxStageFailed)
 }
 
+// RecordCertificationQueue records the frames handed to the DA ahead of their L1 submission
+func (m *Metrics) RecordCertificationQueue(certifying int, certified int) {
+	m.daCertificationQueue.WithLabelValues(CertificationStageCertifying).Set(float64(certifying))
+	m.daCertificationQueue.WithLabelValues(CertificationStageCertified).Set(float64(certified))
+}
+
+func (m *Metrics) RecordCertificationTimeout() {
+	m.daCertificationTimeouts.Inc()
+}
+
+func (m *Metrics) RecordDAPostBatch(size int, duration time.Duration, err error) {
+	m.daPostDuration.WithLabelValues(da.ErrorClass(err)).Observe(duration.Seconds())
+	if err == nil {
+		m.daPostedBytes.Add(float64(size))
+	}
+}
+
+// RecordDAGetBatch is not recorded, the batcher does not retrieve batches
+func (m *Metrics) RecordDAGetBatch(time.Duration, error) {}
+
+func (m *Metrics) RecordDACMemberSignature(member int, duration time.Duration) {
+	m.dacMemberSignatureDuration.WithLabelValues(strconv.Itoa(member)).Observe(duration.Seconds())
+}
+
+func (m *Metrics) RecordDACMemberFailure(member int) {
+	m.dacMemberFailures.WithLabelValues(strconv.Itoa(member)).Inc()
+}
+
+func (m *Metrics) RecordDACBatchCertified(signers int, duration time.Duration) {
+	m.dacCertificationDuration.Observe(duration.Seconds())
+	m.dacBatchSigners.Observe(float64(signers))
+}
+
 // estimateBatchSize estimates the size of the batch
 func estimateBatchSize(block *types.Block) uint64 {
 	size := uint64(70) // estimated overhead of batch metadata
@@ -1,6 +1,9 @@
 package metrics
 
 import (
+	"time"
+
+	"github.com/ethereum-optimism/optimism/da/dac"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -11,6 +14,7 @@ import (
 type noopMetrics struct {
 	opmetrics.NoopRefMetrics
 	txmetrics.NoopTxMetrics
+	dac.NoopMetrics
 }
 
 var NoopMetrics Metricer = new(noopMetrics)
@@ -35,3 +39,9 @@ func (*noopMetrics) RecordChannelTimedOut(derive.ChannelID)       {}
 func (*noopMetrics) RecordBatchTxSubmitted() {}
 func (*noopMetrics) RecordBatchTxSuccess()   {}
 func (*noopMetrics) RecordBatchTxFailed()    {}
+
+func (*noopMetrics) RecordCertificationQueue(int, int) {}
+func (*noopMetrics) RecordCertificationTimeout()       {}
+
+func (*noopMetrics) RecordDAPostBatch(int, time.Duration, error) {}
+func (*noopMetrics) RecordDAGetBatch(time.Duration, error)       {}
@@ -71,6 +71,10 @@ type DeployConfig struct {
 
 	// Seconds after genesis block that Regolith hard fork activates. 0 to activate at genesis. Nil to disable regolith
 	L2GenesisRegolithTimeOffset *hexutil.Uint64 `json:"l2GenesisRegolithTimeOffset,omitempty"`
+	// Seconds after genesis block that the version 1 DAC signature scheme activates. 0 to activate at genesis. Nil to disable it
+	DACV1TimeOffset *hexutil.Uint64 `json:"dacV1TimeOffset,omitempty"`
+	// Seconds after genesis block that batches posted to L1 calldata are accepted. 0 to activate at genesis. Nil to disable it
+	DACFallbackTimeOffset *hexutil.Uint64 `json:"dacFallbackTimeOffset,omitempty"`
 
 	// Owner of the ProxyAdmin predeploy
 	ProxyAdminOwner common.Address `json:"proxyAdminOwner"`
@@ -115,6 +119,7 @@ type DeployConfig struct {
 
   EnableDAC bool `json:"enableDAC"`
   DACPublicKeys []string `json:"dacPublicKeys"`
+  DACProofsOfPossession []string `json:"dacProofsOfPossession"`
   DACHonnestMembersAssumption uint `json:"dacHonnestMembersAssumption"`
 }
 
@@ -313,6 +318,28 @@ func (d *DeployConfig) RegolithTime(genesisTime uint64) *uint64 {
 	return &v
 }
 
+func (d *DeployConfig) DACV1Time(genesisTime uint64) *uint64 {
+	if d.DACV1TimeOffset == nil {
+		return nil
+	}
+	v := uint64(0)
+	if offset := *d.DACV1TimeOffset; offset > 0 {
+		v = genesisTime + uint64(offset)
+	}
+	return &v
+}
+
+func (d *DeployConfig) DACFallbackTime(genesisTime uint64) *uint64 {
+	if d.DACFallbackTimeOffset == nil {
+		return nil
+	}
+	v := uint64(0)
+	if offset := *d.DACFallbackTimeOffset; offset > 0 {
+		v = genesisTime + uint64(offset)
+	}
+	return &v
+}
+
 // RollupConfig converts a DeployConfig to a rollup.Config
 func (d *DeployConfig) RollupConfig(l1StartBlock *types.Block, l2GenesisBlockHash common.Hash, l2GenesisBlockNumber uint64) (*rollup.Config, error) {
 	if d.OptimismPortalProxy == (common.Address{}) {
@@ -325,8 +352,11 @@ func (d *DeployConfig) RollupConfig(l1StartBlock *types.Block, l2GenesisBlockHas
   var dac *rollup.DAC
   if d.EnableDAC {
     dac = &rollup.DAC{
-    	PublicKeys:               d.DACPublicKeys,
-    	HonnestMembersAssumption: d.DACHonnestMembersAssumption,
+      DACKeyset: rollup.DACKeyset{
+        PublicKeys:               d.DACPublicKeys,
+        ProofsOfPossession:       d.DACProofsOfPossession,
+        HonnestMembersAssumption: d.DACHonnestMembersAssumption,
+      },
     }
   }
 
@@ -358,6 +388,8 @@ func (d *DeployConfig) RollupConfig(l1StartBlock *types.Block, l2GenesisBlockHas
 		DepositContractAddress: d.OptimismPortalProxy,
 		L1SystemConfigAddress:  d.SystemConfigProxy,
 		RegolithTime:           d.RegolithTime(l1StartBlock.Time()),
+		DACV1Time:              d.DACV1Time(l1StartBlock.Time()),
+		DACFallbackTime:        d.DACFallbackTime(l1StartBlock.Time()),
 
     DataAvailabilityComittee: dac,
 	}, nil
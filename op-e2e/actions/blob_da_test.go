@@ -0,0 +1,71 @@
+package actions
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/da"
+	"github.com/ethereum-optimism/optimism/da/celestia"
+	"github.com/ethereum-optimism/optimism/da/fallback"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/sources"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+func TestBlobDABatches(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LvlDebug)
+
+	node := celestia.NewMockServer("token")
+	t.Cleanup(node.Close)
+	namespace := append(make([]byte, celestia.NamespaceSize-4), []byte("test")...)
+	sd.RollupCfg.BlobDA = &rollup.BlobDA{Namespace: namespace}
+	require.NoError(t, sd.RollupCfg.Check())
+	newDA := func() da.Client {
+		client, err := celestia.NewClient(node.URL, "token", namespace, sd.RollupCfg.BatchInboxAddress, http.DefaultClient)
+		require.NoError(t, err)
+		return fallback.NewClient(log, client, sd.RollupCfg.BatchInboxAddress, sd.RollupCfg.DACFallbackTime, 0)
+	}
+
+	jwtPath := e2eutils.WriteDefaultJWT(t)
+	miner := NewL1Miner(t, log, sd.L1Cfg)
+	l1F, err := sources.NewL1Client(miner.RPCClient(), log, nil, sources.L1ClientDefaultConfig(sd.RollupCfg, false, sources.RPCKindBasic))
+	require.NoError(t, err)
+	seqEngine := NewL2Engine(t, log, sd.L2Cfg, sd.RollupCfg.Genesis.L1, jwtPath)
+	seqEngCl, err := sources.NewEngineClient(seqEngine.RPCClient(), log, nil, sources.EngineClientDefaultConfig(sd.RollupCfg))
+	require.NoError(t, err)
+	sequencer := NewL2SequencerWithDA(t, log, l1F, seqEngCl, sd.RollupCfg, 0, newDA())
+	verifEngine := NewL2Engine(t, log, sd.L2Cfg, sd.RollupCfg.Genesis.L1, jwtPath)
+	verifier := NewL2VerifierWithDA(t, log, miner.L1Client(t, sd.RollupCfg), verifEngine.EngineClient(t, sd.RollupCfg), sd.RollupCfg, newDA())
+	batcher := NewL2Batcher(log, sd.RollupCfg, &BatcherCfg{
+		MinL1TxSize: 0,
+		MaxL1TxSize: 128_000,
+		BatcherKey:  dp.Secrets.Batcher,
+		DAClient:    newDA(),
+	}, sequencer.RollupClient(), miner.EthClient(), seqEngine.EthClient())
+
+	sequencer.ActL2PipelineFull(t)
+	verifier.ActL2PipelineFull(t)
+
+	sequencer.ActL2StartBlock(t)
+	sequencer.ActL2EndBlock(t)
+	batcher.ActSubmitAll(t)
+	miner.ActL1StartBlock(12)(t)
+	miner.ActL1IncludeTx(sd.RollupCfg.Genesis.SystemConfig.BatcherAddr)(t)
+	miner.ActL1EndBlock(t)
+
+	txs := miner.l1Chain.GetBlockByHash(miner.l1Chain.CurrentBlock().Hash()).Transactions()
+	require.Len(t, txs, 1, "need the batch tx")
+	require.Equal(t, celestia.CelestiaBatchHeaderID, txs[0].Data()[0])
+	require.Equal(t, uint64(1), node.Height(), "the batch must be submitted to the DA node")
+
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, sequencer.L2Unsafe(), verifier.L2Safe(), "verifier must derive the sequencer chain")
+}
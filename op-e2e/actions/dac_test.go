@@ -0,0 +1,185 @@
+package actions
+
+import (
+	"crypto/ecdsa"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/da"
+	"github.com/ethereum-optimism/optimism/da/dac"
+	"github.com/ethereum-optimism/optimism/da/fallback"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/sources"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+type dacTest struct {
+	sd        *e2eutils.SetupData
+	committee *e2eutils.DACCommittee
+	miner     *L1Miner
+	sequencer *L2Sequencer
+	verifier  *L2Verifier
+	batcher   *L2Batcher
+
+	batcherKey *ecdsa.PrivateKey
+}
+
+// setupDACTest starts a chain whose batches are certified by a committee of 3 members with a
+// threshold of 2, erasure coded in data shards if not zero. The batcher falls back to L1 calldata
+// after the fallback timeout, if not zero.
+func setupDACTest(t Testing, dataShards uint, fallbackTimeout time.Duration) *dacTest {
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LvlDebug)
+
+	committee := e2eutils.NewDACCommittee(t, 3, 2, sd.RollupCfg.L2ChainID, dp.Addresses.Batcher)
+	sd.RollupCfg.DataAvailabilityComittee = committee.RollupConfig()
+	sd.RollupCfg.DataAvailabilityComittee.DataShards = dataShards
+	sd.RollupCfg.DACFallbackTime = new(uint64)
+	require.NoError(t, sd.RollupCfg.Check())
+
+	jwtPath := e2eutils.WriteDefaultJWT(t)
+	miner := NewL1Miner(t, log, sd.L1Cfg)
+
+	seqDA, err := committee.NewReader(log, sd.RollupCfg)
+	require.NoError(t, err)
+	l1F, err := sources.NewL1Client(miner.RPCClient(), log, nil, sources.L1ClientDefaultConfig(sd.RollupCfg, false, sources.RPCKindBasic))
+	require.NoError(t, err)
+	seqEngine := NewL2Engine(t, log, sd.L2Cfg, sd.RollupCfg.Genesis.L1, jwtPath)
+	seqEngCl, err := sources.NewEngineClient(seqEngine.RPCClient(), log, nil, sources.EngineClientDefaultConfig(sd.RollupCfg))
+	require.NoError(t, err)
+	sequencer := NewL2SequencerWithDA(t, log, l1F, seqEngCl, sd.RollupCfg, 0, seqDA)
+
+	verifDA, err := committee.NewReader(log, sd.RollupCfg)
+	require.NoError(t, err)
+	verifEngine := NewL2Engine(t, log, sd.L2Cfg, sd.RollupCfg.Genesis.L1, jwtPath)
+	verifier := NewL2VerifierWithDA(t, log, miner.L1Client(t, sd.RollupCfg), verifEngine.EngineClient(t, sd.RollupCfg), sd.RollupCfg, verifDA)
+
+	batcherDA, err := committee.NewAggregator(log, sd.RollupCfg, dp.Secrets.Batcher, fallbackTimeout)
+	require.NoError(t, err)
+	batcher := NewL2Batcher(log, sd.RollupCfg, &BatcherCfg{
+		MinL1TxSize: 0,
+		MaxL1TxSize: 128_000,
+		BatcherKey:  dp.Secrets.Batcher,
+		DAClient:    batcherDA,
+	}, sequencer.RollupClient(), miner.EthClient(), seqEngine.EthClient())
+
+	sequencer.ActL2PipelineFull(t)
+	verifier.ActL2PipelineFull(t)
+
+	return &dacTest{
+		sd:        sd,
+		committee: committee,
+		miner:     miner,
+		sequencer: sequencer,
+		verifier:  verifier,
+		batcher:   batcher,
+
+		batcherKey: dp.Secrets.Batcher,
+	}
+}
+
+// batchL2Block builds an L2 block and includes its batch in a new L1 block.
+// It returns the header of the batch tx.
+func (d *dacTest) batchL2Block(t Testing) byte {
+	d.sequencer.ActL2StartBlock(t)
+	d.sequencer.ActL2EndBlock(t)
+
+	d.batcher.ActSubmitAll(t)
+	d.miner.ActL1StartBlock(12)(t)
+	d.miner.ActL1IncludeTx(d.sd.RollupCfg.Genesis.SystemConfig.BatcherAddr)(t)
+	d.miner.ActL1EndBlock(t)
+
+	txs := d.miner.l1Chain.GetBlockByHash(d.miner.l1Chain.CurrentBlock().Hash()).Transactions()
+	require.Len(t, txs, 1, "need the batch tx")
+	return txs[0].Data()[0]
+}
+
+// requireSafe checks that the verifier derived the whole sequencer chain from the batches
+func (d *dacTest) requireSafe(t Testing) {
+	d.verifier.ActL1HeadSignal(t)
+	d.verifier.ActL2PipelineFull(t)
+	require.Equal(t, d.sequencer.L2Unsafe(), d.verifier.L2Safe(), "verifier must derive the sequencer chain")
+}
+
+func TestDACBatches(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	d := setupDACTest(t, 0, 0)
+
+	require.Equal(t, dac.DACBatchHeaderID, d.batchL2Block(t))
+	d.requireSafe(t)
+
+	// the threshold is still reached without a member
+	d.committee.Members[0].Kill()
+	require.Equal(t, dac.DACBatchHeaderID, d.batchL2Block(t))
+	d.requireSafe(t)
+}
+
+func TestDACCorruptedBatches(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	d := setupDACTest(t, 0, 0)
+
+	// only the first two members sign and store the batch
+	d.committee.Members[2].Kill()
+	require.Equal(t, dac.DACBatchHeaderID, d.batchL2Block(t))
+	d.committee.Members[2].Revive()
+
+	// the verifier fails over to the member still serving the batch
+	d.committee.Members[0].CorruptBatches(t)
+	d.requireSafe(t)
+}
+
+func TestDACWithheldSignatures(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	// falls back to L1 calldata on the first failure
+	d := setupDACTest(t, 0, time.Millisecond)
+
+	d.committee.Members[0].WithholdSignatures(true)
+	d.committee.Members[1].WithholdSignatures(true)
+
+	// the batch cannot be certified by the committee
+	aggregator, err := d.committee.NewAggregator(testlog.Logger(t, log.LvlDebug), d.sd.RollupCfg, d.batcherKey, 0)
+	require.NoError(t, err)
+	_, err = aggregator.PostBatch(t.Ctx(), []byte("some batch data"), 0)
+	require.ErrorIs(t, err, dac.ErrThresholdNotReached)
+	require.ErrorIs(t, err, da.ErrUnavailable)
+
+	require.Equal(t, fallback.CalldataHeaderID, d.batchL2Block(t))
+	d.requireSafe(t)
+}
+
+func TestDACErasureCodedBatches(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	// any 2 of the 3 chunks reconstruct a batch
+	d := setupDACTest(t, 2, 0)
+
+	require.Equal(t, dac.DACBatchHeaderID, d.batchL2Block(t))
+	d.requireSafe(t)
+
+	// the threshold is still reached without a member, whose chunk is not needed
+	d.committee.Members[0].Kill()
+	require.Equal(t, dac.DACBatchHeaderID, d.batchL2Block(t))
+	d.requireSafe(t)
+}
+
+func TestDACErasureCodedWithheldSignatures(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	// falls back to L1 calldata on the first failure
+	d := setupDACTest(t, 2, time.Millisecond)
+
+	d.committee.Members[0].WithholdSignatures(true)
+	d.committee.Members[1].WithholdSignatures(true)
+
+	// the chunks cannot be certified by the committee
+	aggregator, err := d.committee.NewAggregator(testlog.Logger(t, log.LvlDebug), d.sd.RollupCfg, d.batcherKey, 0)
+	require.NoError(t, err)
+	_, err = aggregator.PostBatch(t.Ctx(), []byte("some batch data"), 0)
+	require.ErrorIs(t, err, dac.ErrThresholdNotReached)
+	require.ErrorIs(t, err, da.ErrUnavailable)
+
+	require.Equal(t, fallback.CalldataHeaderID, d.batchL2Block(t))
+	d.requireSafe(t)
+}
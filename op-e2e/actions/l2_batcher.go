@@ -16,6 +16,7 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum-optimism/optimism/da"
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
@@ -44,6 +45,10 @@ type BatcherCfg struct {
 	BatcherKey *ecdsa.PrivateKey
 
 	GarbageCfg *GarbageChannelCfg
+
+	// DA the frames are posted to, the batch txs then only carry their refs.
+	// Frames are posted to L1 calldata if nil.
+	DAClient da.Client
 }
 
 // L2Batcher buffers and submits L2 batches to L1.
@@ -196,13 +201,22 @@ func (s *L2Batcher) ActL2BatchSubmit(t Testing, txOpts ...func(tx *types.Dynamic
 	require.NoError(t, err, "need l1 pending header for gas price estimation")
 	gasFeeCap := new(big.Int).Add(gasTipCap, new(big.Int).Mul(pendingHeader.BaseFee, big.NewInt(2)))
 
+	txData := data.Bytes()
+	if s.l2BatcherCfg.DAClient != nil {
+		ref, err := s.l2BatcherCfg.DAClient.PostBatch(t.Ctx(), txData, pendingHeader.Time)
+		require.NoError(t, err, "need to post frame to the DA")
+		daTx, err := ref.ToTx()
+		require.NoError(t, err, "need DA batch ref tx")
+		txData = daTx.Data
+	}
+
 	rawTx := &types.DynamicFeeTx{
 		ChainID:   s.rollupCfg.L1ChainID,
 		Nonce:     nonce,
 		To:        &s.rollupCfg.BatchInboxAddress,
 		GasTipCap: gasTipCap,
 		GasFeeCap: gasFeeCap,
-		Data:      data.Bytes(),
+		Data:      txData,
 	}
 	for _, opt := range txOpts {
 		opt(rawTx)
@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum-optimism/optimism/da"
 	"github.com/ethereum-optimism/optimism/op-node/client"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/node"
@@ -56,8 +57,14 @@ type L2API interface {
 }
 
 func NewL2Verifier(t Testing, log log.Logger, l1 derive.L1Fetcher, eng L2API, cfg *rollup.Config) *L2Verifier {
+	return NewL2VerifierWithDA(t, log, l1, eng, cfg, nil)
+}
+
+// NewL2VerifierWithDA creates a verifier reading the batches of the batch txs from the DA,
+// as required by the chains with a DAC.
+func NewL2VerifierWithDA(t Testing, log log.Logger, l1 derive.L1Fetcher, eng L2API, cfg *rollup.Config, daClient da.Client) *L2Verifier {
 	metrics := &testutils.TestDerivationMetrics{}
-	pipeline := derive.NewDerivationPipeline(log, cfg, l1, eng, metrics, nil)
+	pipeline := derive.NewDerivationPipeline(log, cfg, l1, eng, metrics, daClient)
 	pipeline.Reset()
 
 	rollupNode := &L2Verifier{
@@ -0,0 +1,209 @@
+package e2eutils
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/da"
+	"github.com/ethereum-optimism/optimism/da/dac"
+	"github.com/ethereum-optimism/optimism/da/dac/member"
+	"github.com/ethereum-optimism/optimism/da/fallback"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+)
+
+// DACMemberTimeout bounds the batch posts to a single member of the test committees
+const DACMemberTimeout = 10 * time.Second
+
+// DACMember is an in-memory dac-member server, storing the batches in a temporary directory.
+// Tests can kill it, make it withhold its signatures or corrupt the batches it stores.
+type DACMember struct {
+	Server  *member.Server
+	Storage member.FileStorage
+	URL     string
+
+	handler http.Handler
+
+	mu          sync.Mutex
+	killed      bool
+	withholding bool
+}
+
+func (m *DACMember) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	m.mu.Lock()
+	killed, withholding := m.killed, m.withholding
+	m.mu.Unlock()
+
+	if killed {
+		// drops the connection without a response, like a crashed member
+		panic(http.ErrAbortHandler)
+	}
+	// the batches and the chunks of erasure coded batches are signed when posted
+	if withholding && req.Method == http.MethodPost && (req.URL.Path == "/batch" || req.URL.Path == "/chunk") {
+		http.Error(w, "signature withheld", http.StatusServiceUnavailable)
+		return
+	}
+	m.handler.ServeHTTP(w, req)
+}
+
+// PublicKey is the hex encoded BLS public key of the member, as listed in the rollup config
+func (m *DACMember) PublicKey() string {
+	return hex.EncodeToString(m.Server.PublicKey().ToBytes())
+}
+
+// Kill makes the member drop every request until it is revived
+func (m *DACMember) Kill() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.killed = true
+}
+
+// Revive makes a killed member serve requests again, with the batches it stored before
+func (m *DACMember) Revive() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.killed = false
+}
+
+// WithholdSignatures makes the member reject the posted batches and chunks without storing or signing them,
+// while it keeps serving the batches it already stored
+func (m *DACMember) WithholdSignatures(withhold bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.withholding = withhold
+}
+
+// CorruptBatches overwrites every batch stored by the member, so that they no longer
+// hash to their id and the member fails to serve them
+func (m *DACMember) CorruptBatches(t TestingBase) {
+	err := m.Storage.List(func(id string, _ time.Time) error {
+		return os.WriteFile(filepath.Join(m.Storage.Directory, id), []byte("corrupted batch"), 0600)
+	})
+	if err != nil {
+		t.Fatalf("failed to corrupt DAC member batches: %v", err)
+	}
+}
+
+// DACCommittee is a committee of in-memory DAC members with generated BLS keys
+type DACCommittee struct {
+	Members   []*DACMember
+	Threshold uint
+}
+
+// NewDACCommittee starts size members signing the batches of the L2 chain for the given batchers,
+// or for anyone if no batcher is given. The members are stopped when the test ends.
+func NewDACCommittee(t TestingBase, size int, threshold uint, chainID *big.Int, batchers ...common.Address) *DACCommittee {
+	var authorizer member.Authorizer
+	if len(batchers) > 0 {
+		addrs := make([]string, len(batchers))
+		for i, addr := range batchers {
+			addrs[i] = addr.Hex()
+		}
+		authorizer = member.NewAllowlist(addrs)
+	}
+
+	committee := &DACCommittee{Threshold: threshold}
+	for i := 0; i < size; i++ {
+		// below the BLS12-381 scalar field order
+		key := make([]byte, 31)
+		if _, err := rand.Read(key); err != nil {
+			t.Fatalf("failed to generate DAC member key: %v", err)
+		}
+		storage := member.NewFileStorage(t.TempDir())
+		server, err := member.NewServer(
+			member.NewVerifiedStorage(storage, 0),
+			hexutil.EncodeBig(new(big.Int).SetBytes(key)),
+			authorizer,
+			member.NewClientLimiter(0, 0, 0, 0),
+			0,
+			chainID,
+			[]uint64{uint64(i)},
+			member.NoopMetrics{},
+		)
+		if err != nil {
+			t.Fatalf("failed to create DAC member %v: %v", i, err)
+		}
+
+		m := &DACMember{Server: server, Storage: storage, handler: server.Router()}
+		srv := httptest.NewServer(m)
+		t.Cleanup(srv.Close)
+		m.URL = srv.URL
+		committee.Members = append(committee.Members, m)
+	}
+	return committee
+}
+
+// Keyset is the rollup config keyset of the committee
+func (c *DACCommittee) Keyset() rollup.DACKeyset {
+	keyset := rollup.DACKeyset{HonnestMembersAssumption: c.Threshold}
+	for _, m := range c.Members {
+		keyset.PublicKeys = append(keyset.PublicKeys, m.PublicKey())
+		keyset.ProofsOfPossession = append(keyset.ProofsOfPossession, hex.EncodeToString(m.Server.ProofOfPossession().ToBytes()))
+	}
+	return keyset
+}
+
+// RollupConfig is the DAC of a rollup config certifying batches with the committee from genesis
+func (c *DACCommittee) RollupConfig() *rollup.DAC {
+	return &rollup.DAC{DACKeyset: c.Keyset()}
+}
+
+// MemberUrls maps the hex public key of every member to its url
+func (c *DACCommittee) MemberUrls() map[string]string {
+	urls := make(map[string]string, len(c.Members))
+	for _, m := range c.Members {
+		urls[m.PublicKey()] = m.URL
+	}
+	return urls
+}
+
+// MemberEntries lists the members as the <hex public key>=<url> entries of the op-batcher and op-node flags
+func (c *DACCommittee) MemberEntries() []string {
+	entries := make([]string, 0, len(c.Members))
+	for _, m := range c.Members {
+		entries = append(entries, fmt.Sprintf("%s=%s", m.PublicKey(), m.URL))
+	}
+	return entries
+}
+
+// NewReader creates the DA client of a rollup node of the chain, reading the batches from the members
+func (c *DACCommittee) NewReader(logger log.Logger, cfg *rollup.Config) (da.Client, error) {
+	committees, err := cfg.DataAvailabilityComittee.Committees()
+	if err != nil {
+		return nil, err
+	}
+	schemes := dac.SchemeConfig{ChainID: cfg.L2ChainID, V1Time: cfg.DACV1Time}
+	reader, err := dac.NewReader(logger, c.MemberUrls(), committees, schemes, &http.Client{Timeout: DACMemberTimeout})
+	if err != nil {
+		return nil, err
+	}
+	return fallback.NewClient(logger, reader, cfg.BatchInboxAddress, cfg.DACFallbackTime, 0), nil
+}
+
+// NewAggregator creates the DA client of a batcher of the chain, posting the batches to the members
+// authenticated by the batcher key. Batches are posted to L1 calldata once the committee is unavailable
+// for the fallback timeout, a zero timeout never falls back.
+func (c *DACCommittee) NewAggregator(logger log.Logger, cfg *rollup.Config, batcherKey *ecdsa.PrivateKey, fallbackTimeout time.Duration) (da.Client, error) {
+	committees, err := cfg.DataAvailabilityComittee.Committees()
+	if err != nil {
+		return nil, err
+	}
+	schemes := dac.SchemeConfig{ChainID: cfg.L2ChainID, V1Time: cfg.DACV1Time}
+	aggregator, err := dac.NewAggregator(logger, c.MemberUrls(), cfg.BatchInboxAddress, committees, schemes, DACMemberTimeout, dac.NoopMetrics{}, dac.PrivateKeyBatchAuth(batcherKey, cfg.L2ChainID), &http.Client{})
+	if err != nil {
+		return nil, err
+	}
+	return fallback.NewClient(logger, aggregator, cfg.BatchInboxAddress, cfg.DACFallbackTime, fallbackTimeout), nil
+}
@@ -233,6 +233,11 @@ type SystemConfig struct {
 
 	// Target L1 tx size for the batcher transactions
 	BatcherTargetL1TxSizeBytes uint64
+
+	// In-process DAC committee certifying the batches, nil to post the batches to L1 calldata.
+	// The committee keyset is written into the rollup config, and the rollup nodes and the
+	// batcher are connected to its members.
+	DAC *e2eutils.DACCommittee
 }
 
 type System struct {
@@ -375,6 +380,10 @@ func (cfg SystemConfig) Start(_opts ...SystemConfigOption) (*System, error) {
 	}
 
 	makeRollupConfig := func() rollup.Config {
+		var dacConfig *rollup.DAC
+		if cfg.DAC != nil {
+			dacConfig = cfg.DAC.RollupConfig()
+		}
 		return rollup.Config{
 			Genesis: rollup.Genesis{
 				L1: eth.BlockID{
@@ -398,6 +407,10 @@ func (cfg SystemConfig) Start(_opts ...SystemConfigOption) (*System, error) {
 			DepositContractAddress: predeploys.DevOptimismPortalAddr,
 			L1SystemConfigAddress:  predeploys.DevSystemConfigAddr,
 			RegolithTime:           cfg.DeployConfig.RegolithTime(uint64(cfg.DeployConfig.L1GenesisBlockTimestamp)),
+			DACV1Time:              cfg.DeployConfig.DACV1Time(uint64(cfg.DeployConfig.L1GenesisBlockTimestamp)),
+			DACFallbackTime:        cfg.DeployConfig.DACFallbackTime(uint64(cfg.DeployConfig.L1GenesisBlockTimestamp)),
+
+			DataAvailabilityComittee: dacConfig,
 		}
 	}
 	defaultConfig := makeRollupConfig()
@@ -549,6 +562,14 @@ func (cfg SystemConfig) Start(_opts ...SystemConfigOption) (*System, error) {
 			}
 		}
 
+		if cfg.DAC != nil && c.DA == nil {
+			c.DA, err = cfg.DAC.NewReader(cfg.Loggers[name], &c.Rollup)
+			if err != nil {
+				didErrAfterStart = true
+				return nil, fmt.Errorf("failed to create DAC reader: %w", err)
+			}
+		}
+
 		c.Rollup.LogDescription(cfg.Loggers[name], chaincfg.L2ChainIDToNetworkName)
 
 		node, err := rollupNode.New(context.Background(), &c, cfg.Loggers[name], snapLog, "", metrics.NewMetrics(""))
@@ -611,10 +632,16 @@ func (cfg SystemConfig) Start(_opts ...SystemConfigOption) (*System, error) {
 	}
 
 	// Batch Submitter
+	var dacMembers []string
+	if cfg.DAC != nil {
+		dacMembers = cfg.DAC.MemberEntries()
+	}
 	sys.BatchSubmitter, err = bss.NewBatchSubmitterFromCLIConfig(bss.CLIConfig{
 		L1EthRpc:               sys.Nodes["l1"].WSEndpoint(),
 		L2EthRpc:               sys.Nodes["sequencer"].WSEndpoint(),
 		RollupRpc:              sys.RollupNodes["sequencer"].HTTPEndpoint(),
+		DACMembers:             dacMembers,
+		DACMemberTimeout:       e2eutils.DACMemberTimeout,
 		MaxPendingTransactions: 0,
 		MaxChannelDuration:     1,
 		MaxL1TxSize:            120_000,
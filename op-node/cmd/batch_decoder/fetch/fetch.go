@@ -10,7 +10,7 @@ import (
 	"path"
 	"time"
 
-	"github.com/ethereum-optimism/optimism/da/dac"
+	"github.com/ethereum-optimism/optimism/da"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -39,6 +39,8 @@ type Config struct {
 	BatchInbox   common.Address
 	BatchSenders map[common.Address]struct{}
 	OutDirectory string
+	// DA resolves the batch refs of the transactions
+	DA da.Client
 }
 
 // Batches fetches & stores all transactions sent to the batch inbox address in
@@ -83,15 +85,13 @@ func fetchBatchesPerBlock(client *ethclient.Client, number *big.Int, signer type
 
 			validFrames := true
 			frameError := ""
-      da := dac.NewClient("https://da.testnet.optimism.alembic.tech", config.BatchInbox, nil)
-      data, err := da.GetBatch(tx.Data())
+      var frames []derive.Frame
+      data, err := config.DA.GetBatch(ctx, tx.Data(), block.Time())
       if err != nil {
         fmt.Printf("DA could not retrieve data of %v: %v\n", hexutil.Encode(tx.Data()), err)
         validFrames = false
         frameError = err.Error()
-      }
-			frames, err := derive.ParseFrames(data)
-			if err != nil {
+      } else if frames, err = derive.ParseFrames(data); err != nil {
 				fmt.Printf("Found a transaction (%s) with invalid data: %v\n", tx.Hash().String(), err)
 				validFrames = false
 				frameError = err.Error()
@@ -2,23 +2,69 @@ package main
 
 import (
 	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"net/url"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/da"
+	"github.com/ethereum-optimism/optimism/da/celestia"
+	"github.com/ethereum-optimism/optimism/da/dac"
+	"github.com/ethereum-optimism/optimism/da/fallback"
+	"github.com/ethereum-optimism/optimism/da/rollupda"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/batch_decoder/fetch"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/batch_decoder/reassemble"
+	"github.com/ethereum-optimism/optimism/op-node/cmd/batch_decoder/verify"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	ophttp "github.com/ethereum-optimism/optimism/op-service/httputil"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
+	gethlog "github.com/ethereum/go-ethereum/log"
 	"github.com/urfave/cli"
 )
 
+const daHTTPFlagPrefix = "da"
+
+// daFlags resolve the batch refs of a DAC chain, they are shared by the commands
+var daFlags = append([]cli.Flag{
+	cli.StringFlag{
+		Name:   "rollup-config",
+		Usage:  "Rollup config JSON file, provides the DAC committees verifying the batch refs",
+		EnvVar: "ROLLUP_CONFIG",
+	},
+	cli.StringFlag{
+		Name:   "da-url",
+		Usage:  "DA API URL to retrieve the DAC batches from",
+		EnvVar: "DA_URL",
+	},
+	cli.StringSliceFlag{
+		Name:   "dac-members",
+		Usage:  "DAC members to retrieve the DAC batches from, as <hex public key>=<url> entries. Preferred over the DA API URL by fetch",
+		EnvVar: "DAC_MEMBERS",
+	},
+	cli.StringFlag{
+		Name:   "blob-da.rpc",
+		Usage:  "JSON-RPC URL of the DA node serving the blobs of the rollup config blob DA namespace",
+		EnvVar: "BLOB_DA_RPC",
+	},
+	cli.StringFlag{
+		Name:   "blob-da.auth-token",
+		Usage:  "Auth token of the blob DA node JSON-RPC API",
+		EnvVar: "BLOB_DA_AUTH_TOKEN",
+	},
+}, ophttp.ClientCLIFlags("DA", daHTTPFlagPrefix)...)
+
 func main() {
 	app := cli.NewApp()
 	app.Name = "batch-decoder"
 	app.Usage = "Optimism Batch Decoding Utility"
+	app.Flags = daFlags
 	app.Commands = []cli.Command{
 		{
 			Name:  "fetch",
@@ -67,6 +113,10 @@ func main() {
 				if err != nil {
 					log.Fatal(err)
 				}
+				daClient, err := newDAClient(cliCtx, common.HexToAddress(cliCtx.String("inbox")))
+				if err != nil {
+					log.Fatal(err)
+				}
 				config := fetch.Config{
 					Start:   uint64(cliCtx.Int("start")),
 					End:     uint64(cliCtx.Int("end")),
@@ -76,6 +126,7 @@ func main() {
 					},
 					BatchInbox:   common.HexToAddress(cliCtx.String("inbox")),
 					OutDirectory: cliCtx.String("out"),
+					DA:           daClient,
 				}
 				totalValid, totalInvalid := fetch.Batches(client, config)
 				fmt.Printf("Fetched batches in range [%v,%v). Found %v valid & %v invalid batches\n", config.Start, config.End, totalValid, totalInvalid)
@@ -84,6 +135,44 @@ func main() {
 				return nil
 			},
 		},
+		{
+			Name:  "dac",
+			Usage: "Inspects the DAC batch refs",
+			Subcommands: []cli.Command{
+				{
+					Name:  "verify",
+					Usage: "Verifies the certificates of the DAC batch refs in the specified range, and that their batches are retrievable",
+					Flags: []cli.Flag{
+						cli.IntFlag{
+							Name:     "start",
+							Required: true,
+							Usage:    "First block (inclusive) to verify",
+						},
+						cli.IntFlag{
+							Name:     "end",
+							Required: true,
+							Usage:    "Last block (exclusive) to verify",
+						},
+						cli.StringFlag{
+							Name:     "sender",
+							Required: true,
+							Usage:    "Batch Sender Address",
+						},
+						cli.StringFlag{
+							Name:     "l1",
+							Required: true,
+							Usage:    "L1 RPC URL",
+							EnvVar:   "L1_RPC",
+						},
+						cli.BoolFlag{
+							Name:  "json",
+							Usage: "Output the reports as JSON",
+						},
+					},
+					Action: verifyDAC,
+				},
+			},
+		},
 		{
 			Name:  "reassemble",
 			Usage: "Reassembles channels from fetched batches",
@@ -160,3 +249,213 @@ func main() {
 		log.Fatal(err)
 	}
 }
+
+func loadRollupConfig(cliCtx *cli.Context) (*rollup.Config, error) {
+	path := cliCtx.GlobalString("rollup-config")
+	if path == "" {
+		return nil, nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rollup config: %w", err)
+	}
+	defer file.Close()
+
+	var rollupConfig rollup.Config
+	if err := json.NewDecoder(file).Decode(&rollupConfig); err != nil {
+		return nil, fmt.Errorf("failed to decode rollup config: %w", err)
+	}
+	return &rollupConfig, nil
+}
+
+// dacConfig reads the committees and signature schemes of the DAC of the rollup config
+func dacConfig(rollupConfig *rollup.Config) (dac.CommitteeSchedule, dac.SchemeConfig, error) {
+	if rollupConfig == nil || rollupConfig.DataAvailabilityComittee == nil {
+		return nil, dac.SchemeConfig{}, errors.New("a rollup config with a DAC is required")
+	}
+	committees, err := rollupConfig.DataAvailabilityComittee.Committees()
+	if err != nil {
+		return nil, dac.SchemeConfig{}, fmt.Errorf("could not create DAC committees: %w", err)
+	}
+	return committees, dac.SchemeConfig{ChainID: rollupConfig.L2ChainID, V1Time: rollupConfig.DACV1Time}, nil
+}
+
+// newDAClient creates the DA client resolving the batch refs sent to the inbox, as the op-node
+// does. Without a rollup config, or with one without a DA, the batches are read from calldata.
+func newDAClient(cliCtx *cli.Context, inbox common.Address) (da.Client, error) {
+	rollupConfig, err := loadRollupConfig(cliCtx)
+	if err != nil {
+		return nil, err
+	}
+	if rollupConfig == nil || (rollupConfig.DataAvailabilityComittee == nil && rollupConfig.BlobDA == nil) {
+		return rollupda.NewClient(inbox), nil
+	}
+	httpClient, err := ophttp.NewClient(gethlog.Root(), ophttp.ReadClientCLIConfig(cliCtx, daHTTPFlagPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("could not create DA http client: %w", err)
+	}
+	if rollupConfig.BlobDA != nil {
+		daClient, err := celestia.NewClient(cliCtx.GlobalString("blob-da.rpc"), cliCtx.GlobalString("blob-da.auth-token"), rollupConfig.BlobDA.Namespace, inbox, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("could not create blob DA client: %w", err)
+		}
+		return fallback.NewClient(gethlog.Root(), daClient, inbox, rollupConfig.DACFallbackTime, 0), nil
+	}
+	committees, schemes, err := dacConfig(rollupConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var daClient da.Client
+	if members := cliCtx.GlobalStringSlice("dac-members"); len(members) > 0 {
+		memberUrls, err := dac.ParseMemberUrls(members)
+		if err != nil {
+			return nil, err
+		}
+		if daClient, err = dac.NewReader(gethlog.Root(), memberUrls, committees, schemes, httpClient); err != nil {
+			return nil, fmt.Errorf("could not create DAC reader: %w", err)
+		}
+	} else if daURL := cliCtx.GlobalString("da-url"); daURL != "" {
+		daClient = dac.NewClient(daURL, inbox, committees, nil, schemes, httpClient)
+	} else {
+		return nil, errors.New("the DAC batches require a DA API URL or DAC members")
+	}
+	// batches posted to L1 calldata while the DAC was unavailable
+	return fallback.NewClient(gethlog.Root(), daClient, inbox, rollupConfig.DACFallbackTime, 0), nil
+}
+
+// memberEndpoints indexes the urls of the DAC members by the hex encoding of their public key
+func memberEndpoints(entries []string) (map[string]*url.URL, error) {
+	memberUrls, err := dac.ParseMemberUrls(entries)
+	if err != nil {
+		return nil, err
+	}
+	endpoints := make(map[string]*url.URL, len(memberUrls))
+	for key, memberUrl := range memberUrls {
+		publicKey, err := dac.PublicKeyFromString(strings.TrimPrefix(key, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid DAC member public key %v: %w", key, err)
+		}
+		parsed, err := url.Parse(memberUrl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DAC member %v url: %w", key, err)
+		}
+		endpoints[hex.EncodeToString(publicKey.ToBytes())] = parsed
+	}
+	return endpoints, nil
+}
+
+func verifyDAC(cliCtx *cli.Context) error {
+	rollupConfig, err := loadRollupConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	committees, schemes, err := dacConfig(rollupConfig)
+	if err != nil {
+		return err
+	}
+	members, err := memberEndpoints(cliCtx.GlobalStringSlice("dac-members"))
+	if err != nil {
+		return err
+	}
+	var daURL *url.URL
+	if rawURL := cliCtx.GlobalString("da-url"); rawURL != "" {
+		if daURL, err = url.Parse(rawURL); err != nil {
+			return fmt.Errorf("invalid DA url: %w", err)
+		}
+	} else if len(members) == 0 {
+		return errors.New("the DAC batches require a DA API URL or DAC members")
+	}
+	httpClient, err := ophttp.NewClient(gethlog.Root(), ophttp.ReadClientCLIConfig(cliCtx, daHTTPFlagPrefix))
+	if err != nil {
+		return fmt.Errorf("could not create DA http client: %w", err)
+	}
+
+	client, err := ethclient.Dial(cliCtx.String("l1"))
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return err
+	}
+	config := verify.Config{
+		Start:   uint64(cliCtx.Int("start")),
+		End:     uint64(cliCtx.Int("end")),
+		ChainID: chainID,
+		BatchSenders: map[common.Address]struct{}{
+			common.HexToAddress(cliCtx.String("sender")): {},
+		},
+		BatchInbox:   rollupConfig.BatchInboxAddress,
+		Committees:   committees,
+		Schemes:      schemes,
+		FallbackTime: rollupConfig.DACFallbackTime,
+		DAURL:        daURL,
+		Members:      members,
+		HTTPClient:   httpClient,
+	}
+	reports, err := verify.Batches(client, config)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, report := range reports {
+		if !report.Ok() {
+			failed++
+		}
+	}
+	if cliCtx.Bool("json") {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(reports); err != nil {
+			return err
+		}
+	} else {
+		for _, report := range reports {
+			printReport(report)
+		}
+		fmt.Printf("Verified batches in range [%v,%v). Found %v valid & %v invalid batches\n", config.Start, config.End, len(reports)-failed, failed)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%v batches failed verification", failed)
+	}
+	return nil
+}
+
+func printReport(report *verify.BatchReport) {
+	status := "ok"
+	if !report.Ok() {
+		status = "FAILED"
+	}
+	fmt.Printf("%v block %v tx %v: %v\n", status, report.BlockNumber, report.TxHash, describeReport(report))
+	if !report.ValidSender {
+		fmt.Printf("  invalid sender %v\n", report.Sender)
+	}
+	for _, retrieval := range report.Retrievals {
+		fmt.Printf("  %v: retrievable %v, hash matches %v %v\n", retrieval.Source, retrieval.Retrievable, retrieval.HashMatches, retrieval.Error)
+	}
+}
+
+func describeReport(report *verify.BatchReport) string {
+	if report.Calldata {
+		return "calldata batch"
+	}
+	if report.CertificateErr != "" && report.Signers == nil {
+		return report.CertificateErr
+	}
+	signers := make([]int, len(report.Signers))
+	for i, signer := range report.Signers {
+		signers[i] = signer.Index
+	}
+	description := fmt.Sprintf("data hash %v, version %v, signers %v (threshold %v), signature valid %v", report.DataHash, report.Version, signers, report.Threshold, report.SignatureValid)
+	if report.DataShards > 0 {
+		description += fmt.Sprintf(", erasure coded into %v data shards", report.DataShards)
+	}
+	if report.CertificateErr != "" {
+		description += ", " + report.CertificateErr
+	}
+	return description
+}
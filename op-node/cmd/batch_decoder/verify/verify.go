@@ -0,0 +1,234 @@
+package verify
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/da/dac"
+	"github.com/ethereum-optimism/optimism/da/fallback"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+type Config struct {
+	Start, End   uint64
+	ChainID      *big.Int
+	BatchInbox   common.Address
+	BatchSenders map[common.Address]struct{}
+	Committees   dac.CommitteeSchedule
+	Schemes      dac.SchemeConfig
+	// FallbackTime is the activation time of the L1 calldata batches, nil if never active
+	FallbackTime *uint64
+	// DAURL is the DA API the batches are retrieved from, nil to only query the members
+	DAURL *url.URL
+	// Members maps the hex public keys of DAC members to the url they serve the batches at
+	Members    map[string]*url.URL
+	HTTPClient *http.Client
+}
+
+type SignerReport struct {
+	Index     int    `json:"index"`
+	PublicKey string `json:"public_key"`
+}
+
+// RetrievalReport is the result of a batch retrieval from the DA API or a member. For erasure
+// coded batches, it is the retrieval of the chunk of a member, and the hash matches if the chunk
+// is included in the certified chunk commitment root.
+type RetrievalReport struct {
+	Source      string `json:"source"`
+	Retrievable bool   `json:"retrievable"`
+	HashMatches bool   `json:"hash_matches"`
+	Error       string `json:"error,omitempty"`
+}
+
+// BatchReport is the audit of the batch ref of an inbox transaction
+type BatchReport struct {
+	TxHash      common.Hash    `json:"tx_hash"`
+	BlockNumber uint64         `json:"block_number"`
+	BlockTime   uint64         `json:"block_time"`
+	Sender      common.Address `json:"sender"`
+	ValidSender bool           `json:"valid_sender"`
+	// Calldata batches were posted to L1 while the DAC was unavailable, they have no certificate
+	Calldata bool `json:"calldata"`
+
+	Version   dac.SchemeVersion `json:"version"`
+	DataHash  common.Hash       `json:"data_hash"`
+	Mask      uint64            `json:"mask"`
+	Signers   []SignerReport    `json:"signers"`
+	Threshold uint              `json:"threshold"`
+	// DataShards is the number of chunks reconstructing an erasure coded batch, whose data hash
+	// is a chunk commitment root
+	DataShards uint `json:"data_shards,omitempty"`
+	// SignatureValid is set if the aggregate signature of the signers verifies
+	SignatureValid bool `json:"signature_valid"`
+	// CertificateValid is set if the ref is accepted by the derivation pipeline: a valid signature
+	// by enough signers, in a scheme active at the block time
+	CertificateValid bool              `json:"certificate_valid"`
+	CertificateErr   string            `json:"certificate_error,omitempty"`
+	Retrievals       []RetrievalReport `json:"retrievals"`
+}
+
+// Ok reports whether the batch is valid and could be retrieved from at least one source, or
+// enough of its chunks could be retrieved to reconstruct it
+func (r *BatchReport) Ok() bool {
+	if !r.ValidSender {
+		return false
+	}
+	if r.Calldata {
+		return true
+	}
+	if !r.CertificateValid {
+		return false
+	}
+	retrieved := uint(0)
+	for _, retrieval := range r.Retrievals {
+		if retrieval.HashMatches {
+			retrieved++
+		}
+	}
+	return retrieved > 0 && retrieved >= r.DataShards
+}
+
+// Batches verifies the DAC batch refs sent to the batch inbox address in the given block range
+// (inclusive to exclusive), and checks that their data can be retrieved.
+func Batches(client *ethclient.Client, config Config) ([]*BatchReport, error) {
+	var reports []*BatchReport
+	signer := types.LatestSignerForChainID(config.ChainID)
+	for i := config.Start; i < config.End; i++ {
+		blockReports, err := verifyBatchesPerBlock(client, new(big.Int).SetUint64(i), signer, config)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, blockReports...)
+	}
+	return reports, nil
+}
+
+func verifyBatchesPerBlock(client *ethclient.Client, number *big.Int, signer types.Signer, config Config) ([]*BatchReport, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	block, err := client.BlockByNumber(ctx, number)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch block %v: %w", number, err)
+	}
+
+	var reports []*BatchReport
+	for _, tx := range block.Transactions() {
+		if tx.To() == nil || *tx.To() != config.BatchInbox {
+			continue
+		}
+		sender, err := signer.Sender(tx)
+		if err != nil {
+			return nil, fmt.Errorf("could not recover the sender of %v: %w", tx.Hash(), err)
+		}
+		_, validSender := config.BatchSenders[sender]
+		report := &BatchReport{
+			TxHash:      tx.Hash(),
+			BlockNumber: block.NumberU64(),
+			BlockTime:   block.Time(),
+			Sender:      sender,
+			ValidSender: validSender,
+		}
+		if data := tx.Data(); len(data) > 0 && data[0] == fallback.CalldataHeaderID {
+			report.Calldata = true
+			if !fallback.IsActive(config.FallbackTime, block.Time()) {
+				report.CertificateErr = "calldata batch before the fallback activation"
+			}
+		} else {
+			verifyBatchRef(report, data, config)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func verifyBatchRef(report *BatchReport, dataRef []byte, config Config) {
+	cert, err := dac.DecodeCertificate(dataRef)
+	if err != nil {
+		report.CertificateErr = err.Error()
+		return
+	}
+	committee := config.Committees.At(report.BlockTime)
+	report.Version = cert.Version
+	report.DataHash = cert.DataHash
+	report.Mask = cert.Mask
+	report.Threshold = committee.Threshold
+	report.DataShards = committee.DataShards
+
+	signers := committee.Keyset.Signers(cert.Mask)
+	report.Signers = make([]SignerReport, len(signers))
+	for i, index := range signers {
+		report.Signers[i] = SignerReport{index, hex.EncodeToString(committee.Keyset[index].ToBytes())}
+	}
+	report.SignatureValid, _ = cert.VerifySignature(committee, config.Schemes)
+	if err := cert.Verify(config.Committees, config.Schemes, report.BlockTime); err != nil {
+		report.CertificateErr = err.Error()
+	} else {
+		report.CertificateValid = true
+	}
+
+	if committee.ErasureCoded() {
+		// the DA API does not serve chunks, and each signer stores a different one
+		for _, s := range report.Signers {
+			if memberUrl, ok := config.Members[s.PublicKey]; ok {
+				report.Retrievals = append(report.Retrievals, retrieveChunk(memberUrl, s.Index, cert.DataHash, uint64(len(committee.Keyset)), config))
+			}
+		}
+		return
+	}
+	if config.DAURL != nil {
+		report.Retrievals = append(report.Retrievals, retrieve(config.DAURL, cert.DataHash, config))
+	}
+	// only the members that signed are expected to store the batch
+	for _, s := range report.Signers {
+		if memberUrl, ok := config.Members[s.PublicKey]; ok {
+			report.Retrievals = append(report.Retrievals, retrieve(memberUrl, cert.DataHash, config))
+		}
+	}
+}
+
+func retrieve(source *url.URL, dataHash common.Hash, config Config) RetrievalReport {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	report := RetrievalReport{Source: source.String()}
+	_, err := dac.FetchBatch(ctx, config.HTTPClient, source, dataHash.Bytes())
+	switch {
+	case err == nil:
+		report.Retrievable = true
+		report.HashMatches = true
+	case errors.Is(err, dac.ErrDataHashMismatch):
+		report.Retrievable = true
+		report.Error = err.Error()
+	default:
+		report.Error = err.Error()
+	}
+	return report
+}
+
+func retrieveChunk(source *url.URL, index int, root common.Hash, total uint64, config Config) RetrievalReport {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	report := RetrievalReport{Source: source.String()}
+	chunk, err := dac.FetchChunk(ctx, config.HTTPClient, source, root, total)
+	switch {
+	case err == nil && chunk.Index == uint64(index):
+		report.Retrievable = true
+		report.HashMatches = true
+	case err == nil:
+		report.Retrievable = true
+		report.Error = fmt.Sprintf("got chunk %v of member %v", chunk.Index, index)
+	case errors.Is(err, dac.ErrInvalidChunk):
+		report.Retrievable = true
+		report.Error = err.Error()
+	default:
+		report.Error = err.Error()
+	}
+	return report
+}
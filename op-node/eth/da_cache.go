@@ -0,0 +1,12 @@
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DABlobsPage is a page of the blobs exported from the DA cache of a node
+type DABlobsPage struct {
+	Blobs []hexutil.Bytes `json:"blobs"`
+	// Next is the cursor of the following page, nil once the export is complete
+	Next *hexutil.Uint64 `json:"next,omitempty"`
+}
@@ -12,3 +12,20 @@ type OutputResponse struct {
 	StateRoot             common.Hash `json:"stateRoot"`
 	Status                *SyncStatus `json:"syncStatus"`
 }
+
+// OutputRootProof is the preimage of an output root, as passed to `OptimismPortal.proveWithdrawalTransaction`
+type OutputRootProof struct {
+	Version                  Bytes32     `json:"version"`
+	StateRoot                common.Hash `json:"stateRoot"`
+	MessagePasserStorageRoot common.Hash `json:"messagePasserStorageRoot"`
+	LatestBlockhash          common.Hash `json:"latestBlockhash"`
+}
+
+type WithdrawalProofResponse struct {
+	OutputRoot      Bytes32         `json:"outputRoot"`
+	OutputRootProof OutputRootProof `json:"outputRootProof"`
+	BlockRef        L2BlockRef      `json:"blockRef"`
+	// StorageProof proves the withdrawal in the L2ToL1MessagePasser storage, against the MessagePasserStorageRoot
+	StorageProof StorageProofEntry `json:"storageProof"`
+	Status       *SyncStatus       `json:"syncStatus"`
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-node/sources"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
+	ophttp "github.com/ethereum-optimism/optimism/op-service/httputil"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 
 	"github.com/urfave/cli"
@@ -17,6 +18,9 @@ import (
 
 const EnvVarPrefix = "OP_NODE"
 
+// DAHTTPFlagPrefix prefixes the flags of the HTTP client of the centralized DA api, the DAC members and the blob DA node
+const DAHTTPFlagPrefix = "da"
+
 func prefixEnvVar(name string) string {
 	return EnvVarPrefix + "_" + name
 }
@@ -38,6 +42,34 @@ var (
     Name: "centralized-da-api",
     Usage: "HTTP api URL for Centralized DA",
     EnvVar: prefixEnvVar("CENTRALIZED_DA_API"),
+  }
+  DACMembersFlag = cli.StringSliceFlag{
+    Name: "dac-members",
+    Usage: "HTTP api URLs of the members of every rollup config DAC keyset, as <hex public key>=<url>. " +
+      "When set, batches are retrieved from the members that signed them instead of the centralized DA api",
+    EnvVar: prefixEnvVar("DAC_MEMBERS"),
+  }
+  BlobDARPCFlag = cli.StringFlag{
+    Name: "blob-da.rpc",
+    Usage: "JSON-RPC URL of the DA node serving the blobs of the rollup config blob DA namespace",
+    EnvVar: prefixEnvVar("BLOB_DA_RPC"),
+  }
+  BlobDAAuthTokenFlag = cli.StringFlag{
+    Name: "blob-da.auth-token",
+    Usage: "Auth token of the blob DA node JSON-RPC API",
+    EnvVar: prefixEnvVar("BLOB_DA_AUTH_TOKEN"),
+  }
+  DACCachePathFlag = cli.StringFlag{
+    Name: "dac-cache.path",
+    Usage: "Path of the LevelDB database caching the batches retrieved from the DAC, keyed by their certified hash. " +
+      "The batches are downloaded again on every pipeline reset if not set",
+    EnvVar: prefixEnvVar("DAC_CACHE_PATH"),
+  }
+  DACCacheMaxSizeFlag = cli.Uint64Flag{
+    Name: "dac-cache.max-size",
+    Usage: "Maximum total size in bytes of the cached DAC batches, the least recently used batches are evicted above it. 0 for no limit",
+    EnvVar: prefixEnvVar("DAC_CACHE_MAX_SIZE"),
+    Value: 1 << 30,
   }
 	RollupConfig = cli.StringFlag{
 		Name:   "rollup.config",
@@ -224,6 +256,11 @@ var requiredFlags = []cli.Flag{
 
 var optionalFlags = []cli.Flag{
   CentralizedDAApiFlag,
+  DACMembersFlag,
+  BlobDARPCFlag,
+  BlobDAAuthTokenFlag,
+  DACCachePathFlag,
+  DACCacheMaxSizeFlag,
 	RollupConfig,
 	Network,
 	L1TrustRPC,
@@ -259,6 +296,7 @@ var Flags []cli.Flag
 func init() {
 	optionalFlags = append(optionalFlags, p2pFlags...)
 	optionalFlags = append(optionalFlags, oplog.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, ophttp.ClientCLIFlags(prefixEnvVar("DA"), DAHTTPFlagPrefix)...)
 	Flags = append(requiredFlags, optionalFlags...)
 }
 
@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/ethereum-optimism/optimism/da"
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DAMetrics implements the Metricer interface of the DA clients
+type DAMetrics struct {
+	GetDuration *prometheus.HistogramVec
+	GetFailures *prometheus.CounterVec
+}
+
+// RecordDAPostBatch is not recorded, the rollup node does not post batches
+func (m *DAMetrics) RecordDAPostBatch(int, time.Duration, error) {}
+
+// RecordDAGetBatch meters a batch retrieval from the DA, labelled by the class of its error if it failed
+func (m *DAMetrics) RecordDAGetBatch(duration time.Duration, err error) {
+	result := da.ErrorClass(err)
+	m.GetDuration.WithLabelValues(result).Observe(duration.Seconds())
+	if err != nil {
+		m.GetFailures.WithLabelValues(result).Inc()
+	}
+}
+
+func NewDAMetrics(factory metrics.Factory, ns string) *DAMetrics {
+	return &DAMetrics{
+		GetDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "da_get_batch_seconds",
+			Help:      "Duration of the batch retrievals from the DA, by result",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+		}, []string{
+			"result",
+		}),
+		GetFailures: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "da_get_batch_failures",
+			Help:      "Failed batch retrievals from the DA, by class of error",
+		}, []string{
+			"class",
+		}),
+	}
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DACacheMetrics implements the Metrics interface of the DA blob cache
+type DACacheMetrics struct {
+	GetVec    *prometheus.CounterVec
+	Entries   prometheus.Gauge
+	Size      prometheus.Gauge
+	Evictions prometheus.Counter
+}
+
+// RecordDACacheGet meters a lookup of a batch in the cache, indicating if the lookup was a hit
+func (m *DACacheMetrics) RecordDACacheGet(hit bool) {
+	if hit {
+		m.GetVec.WithLabelValues("true").Inc()
+	} else {
+		m.GetVec.WithLabelValues("false").Inc()
+	}
+}
+
+// RecordDACacheSize meters the number of cached batches and their total size
+func (m *DACacheMetrics) RecordDACacheSize(entries int, size uint64) {
+	m.Entries.Set(float64(entries))
+	m.Size.Set(float64(size))
+}
+
+// RecordDACacheEviction meters the eviction of the least recently used batch
+func (m *DACacheMetrics) RecordDACacheEviction() {
+	m.Evictions.Inc()
+}
+
+func NewDACacheMetrics(factory metrics.Factory, ns string) *DACacheMetrics {
+	return &DACacheMetrics{
+		GetVec: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "da_cache_get",
+			Help:      "DA blob cache lookups, hitting or not",
+		}, []string{
+			"hit",
+		}),
+		Entries: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "da_cache_entries",
+			Help:      "Number of batches in the DA blob cache",
+		}),
+		Size: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "da_cache_size_bytes",
+			Help:      "Total size of the batches in the DA blob cache",
+		}),
+		Evictions: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "da_cache_evictions",
+			Help:      "Batches evicted from the DA blob cache",
+		}),
+	}
+}
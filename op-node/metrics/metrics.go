@@ -90,6 +90,8 @@ type Metrics struct {
 
 	L1SourceCache *CacheMetrics
 	L2SourceCache *CacheMetrics
+	DACache       *DACacheMetrics
+	DA            *DAMetrics
 
 	DerivationIdle prometheus.Gauge
 
@@ -223,6 +225,8 @@ func NewMetrics(procName string) *Metrics {
 
 		L1SourceCache: NewCacheMetrics(factory, ns, "l1_source_cache", "L1 Source cache"),
 		L2SourceCache: NewCacheMetrics(factory, ns, "l2_source_cache", "L2 Source cache"),
+		DACache:       NewDACacheMetrics(factory, ns),
+		DA:            NewDAMetrics(factory, ns),
 
 		DerivationIdle: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
@@ -13,8 +13,12 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/version"
+	"github.com/ethereum-optimism/optimism/op-node/withdrawals"
 )
 
+// maxOutputsAtBlocks is the maximum number of outputs computed by a single optimism_outputsAtBlocks call
+const maxOutputsAtBlocks = 100
+
 type l2EthClient interface {
 	InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error)
 	// GetProof returns a proof of the account, it may return a nil result without error if the address was not found.
@@ -65,6 +69,59 @@ func (n *adminAPI) StopSequencer(ctx context.Context) (common.Hash, error) {
 	return n.dr.StopSequencer(ctx)
 }
 
+// maxDABlobsPage is the maximum number of blobs exported by a request, and the default page size
+const maxDABlobsPage = 64
+
+type daBlobCache interface {
+	Export(cursor uint64, limit int) ([][]byte, uint64, error)
+	Import(blobs [][]byte) (int, error)
+}
+
+// daCacheAPI exports and imports the batches of the DA blob cache, so that a node can seed the cache of another
+type daCacheAPI struct {
+	cache daBlobCache
+	m     rpcMetrics
+}
+
+func NewDACacheAPI(cache daBlobCache, m rpcMetrics) *daCacheAPI {
+	return &daCacheAPI{
+		cache: cache,
+		m:     m,
+	}
+}
+
+// ExportDABlobs returns a page of at most limit blobs from the cursor, starting from a zero cursor.
+// A limit that is not positive, or above the maximum page size, exports a page of the maximum size.
+func (n *daCacheAPI) ExportDABlobs(_ context.Context, cursor hexutil.Uint64, limit int) (*eth.DABlobsPage, error) {
+	recordDur := n.m.RecordRPCServerRequest("admin_exportDABlobs")
+	defer recordDur()
+	if limit <= 0 || limit > maxDABlobsPage {
+		limit = maxDABlobsPage
+	}
+	blobs, next, err := n.cache.Export(uint64(cursor), limit)
+	if err != nil {
+		return nil, err
+	}
+	page := &eth.DABlobsPage{Blobs: make([]hexutil.Bytes, len(blobs))}
+	for i, blob := range blobs {
+		page.Blobs[i] = blob
+	}
+	if next != 0 {
+		page.Next = (*hexutil.Uint64)(&next)
+	}
+	return page, nil
+}
+
+func (n *daCacheAPI) ImportDABlobs(_ context.Context, blobs []hexutil.Bytes) (int, error) {
+	recordDur := n.m.RecordRPCServerRequest("admin_importDABlobs")
+	defer recordDur()
+	in := make([][]byte, len(blobs))
+	for i, blob := range blobs {
+		in[i] = blob
+	}
+	return n.cache.Import(in)
+}
+
 type nodeAPI struct {
 	config *rollup.Config
 	client l2EthClient
@@ -87,37 +144,100 @@ func (n *nodeAPI) OutputAtBlock(ctx context.Context, number hexutil.Uint64) (*et
 	recordDur := n.m.RecordRPCServerRequest("optimism_outputAtBlock")
 	defer recordDur()
 
-	ref, status, err := n.dr.BlockRefWithStatus(ctx, uint64(number))
+	output, _, err := n.outputAtBlock(ctx, uint64(number), []common.Hash{})
+	return output, err
+}
+
+// OutputsAtBlocks returns the outputs at each of the blocks, in the order of the block numbers
+func (n *nodeAPI) OutputsAtBlocks(ctx context.Context, numbers []hexutil.Uint64) ([]*eth.OutputResponse, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_outputsAtBlocks")
+	defer recordDur()
+
+	if len(numbers) > maxOutputsAtBlocks {
+		return nil, fmt.Errorf("too many blocks requested: %d, at most %d", len(numbers), maxOutputsAtBlocks)
+	}
+
+	outputs := make([]*eth.OutputResponse, len(numbers))
+	for i, number := range numbers {
+		output, _, err := n.outputAtBlock(ctx, uint64(number), []common.Hash{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get output at block %d: %w", uint64(number), err)
+		}
+		outputs[i] = output
+	}
+	return outputs, nil
+}
+
+// WithdrawalProof returns the output at the block along with its preimage, and the storage proof of the
+// withdrawal in the L2ToL1MessagePasser: the inputs of `OptimismPortal.proveWithdrawalTransaction`
+// besides the withdrawal transaction and the index of the output proposed for the block.
+func (n *nodeAPI) WithdrawalProof(ctx context.Context, number hexutil.Uint64, withdrawalHash common.Hash) (*eth.WithdrawalProofResponse, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_withdrawalProof")
+	defer recordDur()
+
+	slot := withdrawals.StorageSlotOfWithdrawalHash(withdrawalHash)
+	output, proof, err := n.outputAtBlock(ctx, uint64(number), []common.Hash{slot})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get L2 block ref with sync status: %w", err)
+		return nil, err
+	}
+	if len(proof.StorageProof) != 1 || proof.StorageProof[0].Key != slot {
+		return nil, fmt.Errorf("missing storage proof of withdrawal %s at block %s", withdrawalHash, output.BlockRef)
+	}
+
+	return &eth.WithdrawalProofResponse{
+		OutputRoot: output.OutputRoot,
+		OutputRootProof: eth.OutputRootProof{
+			Version:                  output.Version,
+			StateRoot:                output.StateRoot,
+			MessagePasserStorageRoot: output.WithdrawalStorageRoot,
+			LatestBlockhash:          output.BlockRef.Hash,
+		},
+		BlockRef:     output.BlockRef,
+		StorageProof: proof.StorageProof[0],
+		Status:       output.Status,
+	}, nil
+}
+
+// outputAtBlock computes the output at the block, along with the proof of the L2ToL1MessagePasser account
+// and of the given storage slots, verified against the state root of the block. The storage slots must be set.
+func (n *nodeAPI) outputAtBlock(ctx context.Context, number uint64, storageSlots []common.Hash) (*eth.OutputResponse, *eth.AccountResult, error) {
+	ref, status, err := n.dr.BlockRefWithStatus(ctx, number)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get L2 block ref with sync status: %w", err)
 	}
 
 	head, err := n.client.InfoByHash(ctx, ref.Hash)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get L2 block by hash %s: %w", ref, err)
+		return nil, nil, fmt.Errorf("failed to get L2 block by hash %s: %w", ref, err)
 	}
 	if head == nil {
-		return nil, ethereum.NotFound
+		return nil, nil, ethereum.NotFound
 	}
 
-	proof, err := n.client.GetProof(ctx, predeploys.L2ToL1MessagePasserAddr, []common.Hash{}, ref.Hash.String())
+	proof, err := n.client.GetProof(ctx, predeploys.L2ToL1MessagePasserAddr, storageSlots, ref.Hash.String())
 	if err != nil {
-		return nil, fmt.Errorf("failed to get contract proof at block %s: %w", ref, err)
+		return nil, nil, fmt.Errorf("failed to get contract proof at block %s: %w", ref, err)
 	}
 	if proof == nil {
-		return nil, fmt.Errorf("proof %w", ethereum.NotFound)
+		return nil, nil, fmt.Errorf("proof %w", ethereum.NotFound)
+	}
+	// the proof of an unset slot is a proof of its absence, which is not verified
+	for _, entry := range proof.StorageProof {
+		if entry.Value.ToInt().Sign() == 0 {
+			return nil, nil, fmt.Errorf("storage slot %s of block %s %w", entry.Key, ref, ethereum.NotFound)
+		}
 	}
 	// make sure that the proof (including storage hash) that we retrieved is correct by verifying it against the state-root
 	if err := proof.Verify(head.Root()); err != nil {
 		n.log.Error("invalid withdrawal root detected in block", "stateRoot", head.Root(), "blocknum", number, "msg", err)
-		return nil, fmt.Errorf("invalid withdrawal root hash, state root was %s: %w", head.Root(), err)
+		return nil, nil, fmt.Errorf("invalid withdrawal root hash, state root was %s: %w", head.Root(), err)
 	}
 
 	var l2OutputRootVersion eth.Bytes32 // it's zero for now
 	l2OutputRoot, err := rollup.ComputeL2OutputRootV0(head, proof.StorageHash)
 	if err != nil {
 		n.log.Error("Error computing L2 output root, nil ptr passed to hashing function")
-		return nil, err
+		return nil, nil, err
 	}
 
 	return &eth.OutputResponse{
@@ -127,7 +247,7 @@ func (n *nodeAPI) OutputAtBlock(ctx context.Context, number hexutil.Uint64) (*et
 		WithdrawalStorageRoot: proof.StorageHash,
 		StateRoot:             head.Root(),
 		Status:                status,
-	}, nil
+	}, proof, nil
 }
 
 func (n *nodeAPI) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
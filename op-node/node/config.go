@@ -20,6 +20,8 @@ type Config struct {
 
 	DA da.Client
 
+	DACache DACacheConfig
+
 	Driver driver.Config
 
 	Rollup rollup.Config
@@ -44,6 +46,14 @@ type Config struct {
 	Heartbeat HeartbeatConfig
 }
 
+// DACacheConfig configures the disk cache of the batches retrieved from the DAC
+type DACacheConfig struct {
+	// Path of the LevelDB database, the batches are not cached if empty
+	Path string
+	// MaxSize is the maximum total size of the cached batches in bytes, 0 for no limit
+	MaxSize uint64
+}
+
 type RPCConfig struct {
 	ListenAddr  string
 	ListenPort  int
@@ -103,5 +113,8 @@ func (cfg *Config) Check() error {
 	if cfg.Rollup.DataAvailabilityComittee != nil && cfg.DA == nil {
 		return errors.New("DAC set but no da client configured")
 	}
+	if cfg.Rollup.BlobDA != nil && cfg.DA == nil {
+		return errors.New("blob DA set but no da client configured")
+	}
 	return nil
 }
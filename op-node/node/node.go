@@ -10,10 +10,13 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/da"
+	"github.com/ethereum-optimism/optimism/da/cache"
+	"github.com/ethereum-optimism/optimism/da/dac"
 	"github.com/ethereum-optimism/optimism/op-node/client"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
@@ -36,11 +39,12 @@ type OpNode struct {
 	l2Source  *sources.EngineClient // L2 Execution Engine RPC bindings
 	rpcSync   *sources.SyncClient   // Alt-sync RPC client, optional (may be nil)
 	server    *rpcServer            // RPC server hosting the rollup-node API
-	da        *da.Client
-	p2pNode   *p2p.NodeP2P   // P2P node functionality
-	p2pSigner p2p.Signer     // p2p gogssip application messages will be signed with this signer
-	tracer    Tracer         // tracer to get events for testing/debugging
-	runCfg    *RuntimeConfig // runtime configurables
+	da        da.Client             // DA the batches are retrieved from, optional (may be nil)
+	daCache   *cache.Cache          // Disk cache of the DAC batches, optional (may be nil)
+	p2pNode   *p2p.NodeP2P          // P2P node functionality
+	p2pSigner p2p.Signer            // p2p gogssip application messages will be signed with this signer
+	tracer    Tracer                // tracer to get events for testing/debugging
+	runCfg    *RuntimeConfig        // runtime configurables
 
 	// some resources cannot be stopped directly, like the p2p gossipsub router (not our design),
 	// and depend on this ctx to be closed.
@@ -86,6 +90,9 @@ func (n *OpNode) init(ctx context.Context, cfg *Config, snapshotLog log.Logger)
 	if err := n.initRuntimeConfig(ctx, cfg); err != nil {
 		return err
 	}
+	if err := n.initDA(ctx, cfg); err != nil {
+		return err
+	}
 	if err := n.initL2(ctx, cfg, snapshotLog); err != nil {
 		return err
 	}
@@ -184,6 +191,41 @@ func (n *OpNode) initRuntimeConfig(ctx context.Context, cfg *Config) error {
 	return errors.New("failed to load runtime configuration repeatedly")
 }
 
+func (n *OpNode) initDA(ctx context.Context, cfg *Config) error {
+	if cfg.DA == nil {
+		return nil
+	}
+	// the cache hits are metered by the cache, only the retrievals from the DA are metered here
+	n.da = da.NewMeteredClient(cfg.DA, n.metrics.DA)
+	if cfg.Rollup.DataAvailabilityComittee == nil || cfg.DACache.Path == "" {
+		return nil
+	}
+
+	committees, err := cfg.Rollup.DataAvailabilityComittee.Committees()
+	if err != nil {
+		return fmt.Errorf("could not create DAC committees: %w", err)
+	}
+	schemes := dac.SchemeConfig{ChainID: cfg.Rollup.L2ChainID, V1Time: cfg.Rollup.DACV1Time}
+	// only the DAC refs are cached, the other refs carry their batch
+	verify := func(ref []byte, l1Time uint64) (common.Hash, bool, error) {
+		if len(ref) == 0 || ref[0] != dac.DACBatchHeaderID {
+			return common.Hash{}, false, nil
+		}
+		// the refs of erasure coded batches certify a chunk commitment root, not the batch hash
+		if committees.At(l1Time).ErasureCoded() {
+			return common.Hash{}, false, nil
+		}
+		hash, err := dac.VerifyBatchRef(ref, committees, schemes, l1Time)
+		return hash, err == nil, err
+	}
+	n.daCache, err = cache.Open(n.log, cfg.DACache.Path, n.da, verify, cfg.DACache.MaxSize, n.metrics.DACache)
+	if err != nil {
+		return err
+	}
+	n.da = n.daCache
+	return nil
+}
+
 func (n *OpNode) initL2(ctx context.Context, cfg *Config, snapshotLog log.Logger) error {
 	rpcClient, rpcCfg, err := cfg.L2.Setup(ctx, n.log, &cfg.Rollup)
 	if err != nil {
@@ -201,7 +243,7 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config, snapshotLog log.Logger
 		return err
 	}
 
-	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n, n, cfg.DA, n.log, snapshotLog, n.metrics)
+	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n, n, n.da, n.log, snapshotLog, n.metrics)
 
 	return nil
 }
@@ -232,6 +274,9 @@ func (n *OpNode) initRPCServer(ctx context.Context, cfg *Config) error {
 	}
 	if cfg.RPC.EnableAdmin {
 		server.EnableAdminAPI(NewAdminAPI(n.l2Driver, n.metrics))
+		if n.daCache != nil {
+			server.EnableDACacheAPI(NewDACacheAPI(n.daCache, n.metrics))
+		}
 		n.log.Info("Admin RPC enabled")
 	}
 	n.log.Info("Starting JSON-RPC server")
@@ -449,6 +494,13 @@ func (n *OpNode) Close() error {
 	if n.l1Source != nil {
 		n.l1Source.Close()
 	}
+
+	// close DA blob cache, after the driver stopped reading batches
+	if n.daCache != nil {
+		if err := n.daCache.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close DA cache: %w", err))
+		}
+	}
 	return result.ErrorOrNil()
 }
 
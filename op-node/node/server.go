@@ -54,6 +54,15 @@ func (s *rpcServer) EnableAdminAPI(api *adminAPI) {
 	})
 }
 
+func (s *rpcServer) EnableDACacheAPI(api *daCacheAPI) {
+	s.apis = append(s.apis, rpc.API{
+		Namespace:     "admin",
+		Version:       "",
+		Service:       api,
+		Authenticated: false,
+	})
+}
+
 func (s *rpcServer) EnableP2P(backend *p2p.APIBackend) {
 	s.apis = append(s.apis, rpc.API{
 		Namespace:     p2p.NamespaceRPC,
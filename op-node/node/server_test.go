@@ -3,26 +3,36 @@ package node
 import (
 	"context"
 	"encoding/json"
+	"math/big"
 	"math/rand"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	rpcclient "github.com/ethereum-optimism/optimism/op-node/client"
 
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
 
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/sources"
 	"github.com/ethereum-optimism/optimism/op-node/testlog"
 	"github.com/ethereum-optimism/optimism/op-node/testutils"
 	"github.com/ethereum-optimism/optimism/op-node/version"
+	"github.com/ethereum-optimism/optimism/op-node/withdrawals"
 )
 
 func TestOutputAtBlock(t *testing.T) {
@@ -131,6 +141,155 @@ func TestOutputAtBlock(t *testing.T) {
 	drClient.Mock.AssertExpectations(t)
 }
 
+func TestOutputsAtBlocks(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	rng := rand.New(rand.NewSource(1234))
+
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	var refs []eth.L2BlockRef
+	for _, num := range []uint64{10, 20} {
+		stateRoot, proof := messagePasserState(t, nil)
+		info := &testutils.MockBlockInfo{InfoHash: testutils.RandomHash(rng), InfoRoot: stateRoot, InfoNum: num}
+		ref := eth.L2BlockRef{Hash: info.InfoHash, Number: num}
+		refs = append(refs, ref)
+
+		drClient.ExpectBlockRefWithStatus(num, ref, randomSyncStatus(rng), nil)
+		l2Client.ExpectInfoByHash(ref.Hash, info, nil)
+		l2Client.ExpectGetProof(predeploys.L2ToL1MessagePasserAddr, []common.Hash{}, ref.Hash.String(), proof, nil)
+	}
+
+	client := startRPCServer(t, log, l2Client, drClient)
+
+	var out []*eth.OutputResponse
+	err := client.CallContext(context.Background(), &out, "optimism_outputsAtBlocks", []hexutil.Uint64{20, 10})
+	require.NoError(t, err)
+	require.Len(t, out, 2)
+	require.Equal(t, refs[1], out[0].BlockRef)
+	require.Equal(t, refs[0], out[1].BlockRef)
+	l2Client.Mock.AssertExpectations(t)
+	drClient.Mock.AssertExpectations(t)
+
+	err = client.CallContext(context.Background(), &out, "optimism_outputsAtBlocks", make([]hexutil.Uint64, maxOutputsAtBlocks+1))
+	require.ErrorContains(t, err, "too many blocks requested")
+}
+
+func TestWithdrawalProof(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	rng := rand.New(rand.NewSource(1234))
+
+	withdrawalHash := testutils.RandomHash(rng)
+	missingWithdrawalHash := testutils.RandomHash(rng)
+	stateRoot, proof := messagePasserState(t, &withdrawalHash)
+	info := &testutils.MockBlockInfo{InfoHash: testutils.RandomHash(rng), InfoRoot: stateRoot, InfoNum: 10}
+	ref := eth.L2BlockRef{Hash: info.InfoHash, Number: 10}
+	status := randomSyncStatus(rng)
+
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	drClient.ExpectBlockRefWithStatus(10, ref, status, nil)
+	l2Client.ExpectInfoByHash(ref.Hash, info, nil)
+	slot := withdrawals.StorageSlotOfWithdrawalHash(withdrawalHash)
+	l2Client.ExpectGetProof(predeploys.L2ToL1MessagePasserAddr, []common.Hash{slot}, ref.Hash.String(), proof, nil)
+
+	client := startRPCServer(t, log, l2Client, drClient)
+
+	var out *eth.WithdrawalProofResponse
+	err := client.CallContext(context.Background(), &out, "optimism_withdrawalProof", hexutil.Uint64(10), withdrawalHash)
+	require.NoError(t, err)
+
+	expectedRoot, err := rollup.ComputeL2OutputRoot(&bindings.TypesOutputRootProof{
+		Version:                  out.OutputRootProof.Version,
+		StateRoot:                stateRoot,
+		MessagePasserStorageRoot: proof.StorageHash,
+		LatestBlockhash:          ref.Hash,
+	})
+	require.NoError(t, err)
+	require.Equal(t, expectedRoot, out.OutputRoot)
+	require.Equal(t, stateRoot, out.OutputRootProof.StateRoot)
+	require.Equal(t, proof.StorageHash, out.OutputRootProof.MessagePasserStorageRoot)
+	require.Equal(t, ref.Hash, out.OutputRootProof.LatestBlockhash)
+	require.Equal(t, ref, out.BlockRef)
+	require.Equal(t, slot, out.StorageProof.Key)
+	require.Equal(t, proof.StorageProof[0].Proof, out.StorageProof.Proof)
+	require.Equal(t, *status, *out.Status)
+	l2Client.Mock.AssertExpectations(t)
+	drClient.Mock.AssertExpectations(t)
+
+	// the proof of a withdrawal not passed through the message passer is not served
+	missingSlot := withdrawals.StorageSlotOfWithdrawalHash(missingWithdrawalHash)
+	missingProof := *proof
+	missingProof.StorageProof = []eth.StorageProofEntry{{Key: missingSlot, Proof: proof.StorageProof[0].Proof}}
+	drClient.ExpectBlockRefWithStatus(10, ref, status, nil)
+	l2Client.ExpectInfoByHash(ref.Hash, info, nil)
+	l2Client.ExpectGetProof(predeploys.L2ToL1MessagePasserAddr, []common.Hash{missingSlot}, ref.Hash.String(), &missingProof, nil)
+	err = client.CallContext(context.Background(), &out, "optimism_withdrawalProof", hexutil.Uint64(10), missingWithdrawalHash)
+	require.ErrorContains(t, err, "not found")
+}
+
+// messagePasserState builds an L2 state holding the L2ToL1MessagePasser account, with the withdrawal
+// stored when set. It returns the state root along with the proof of the account, and of the withdrawal.
+func messagePasserState(t *testing.T, withdrawalHash *common.Hash) (common.Hash, *eth.AccountResult) {
+	storageTrie := trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase()))
+	var storageProof []eth.StorageProofEntry
+	if withdrawalHash != nil {
+		slot := withdrawals.StorageSlotOfWithdrawalHash(*withdrawalHash)
+		value, err := rlp.EncodeToBytes([]byte{1})
+		require.NoError(t, err)
+		require.NoError(t, storageTrie.Update(crypto.Keccak256(slot[:]), value))
+		storageProof = append(storageProof, eth.StorageProofEntry{
+			Key:   slot,
+			Value: hexutil.Big(*big.NewInt(1)),
+			Proof: trieProof(t, storageTrie, crypto.Keccak256(slot[:])),
+		})
+	}
+
+	account := types.StateAccount{Balance: new(big.Int), Root: storageTrie.Hash(), CodeHash: crypto.Keccak256(nil)}
+	accountValue, err := rlp.EncodeToBytes(&account)
+	require.NoError(t, err)
+	accountKey := crypto.Keccak256(predeploys.L2ToL1MessagePasserAddr[:])
+	accountTrie := trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase()))
+	require.NoError(t, accountTrie.Update(accountKey, accountValue))
+
+	balance := hexutil.Big(*account.Balance)
+	return accountTrie.Hash(), &eth.AccountResult{
+		AccountProof: trieProof(t, accountTrie, accountKey),
+		Address:      predeploys.L2ToL1MessagePasserAddr,
+		Balance:      &balance,
+		CodeHash:     common.BytesToHash(account.CodeHash),
+		StorageHash:  account.Root,
+		StorageProof: storageProof,
+	}
+}
+
+func trieProof(t *testing.T, tr *trie.Trie, key []byte) []hexutil.Bytes {
+	proofDB := memorydb.New()
+	require.NoError(t, tr.Prove(key, 0, proofDB))
+
+	var proof []hexutil.Bytes
+	it := proofDB.NewIterator(nil, nil)
+	defer it.Release()
+	for it.Next() {
+		proof = append(proof, common.CopyBytes(it.Value()))
+	}
+	return proof
+}
+
+func startRPCServer(t *testing.T, log log.Logger, l2Client *testutils.MockL2Client, drClient *mockDriverClient) rpcclient.RPC {
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	server, err := newRPCServer(context.Background(), rpcCfg, &rollup.Config{}, l2Client, drClient, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	t.Cleanup(server.Stop)
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+	return client
+}
+
 func TestVersion(t *testing.T) {
 	log := testlog.Logger(t, log.LvlError)
 	l2Client := &testutils.MockL2Client{}
@@ -199,6 +358,55 @@ func TestSyncStatus(t *testing.T) {
 	assert.Equal(t, status, out)
 }
 
+type stubBlobCache struct {
+	blobs [][]byte
+}
+
+func (c *stubBlobCache) Export(cursor uint64, limit int) ([][]byte, uint64, error) {
+	end := cursor + uint64(limit)
+	if end >= uint64(len(c.blobs)) {
+		return c.blobs[cursor:], 0, nil
+	}
+	return c.blobs[cursor:end], end, nil
+}
+
+func (c *stubBlobCache) Import(blobs [][]byte) (int, error) {
+	c.blobs = append(c.blobs, blobs...)
+	return len(blobs), nil
+}
+
+func TestDACacheAPI(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	server, err := newRPCServer(context.Background(), rpcCfg, &rollup.Config{}, &testutils.MockL2Client{}, &mockDriverClient{}, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	cache := &stubBlobCache{blobs: [][]byte{[]byte("batch 0"), []byte("batch 1")}}
+	server.EnableDACacheAPI(NewDACacheAPI(cache, metrics.NoopMetrics))
+	require.NoError(t, server.Start())
+	defer server.Stop()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+	rollupClient := sources.NewRollupClient(client)
+
+	page, err := rollupClient.ExportDABlobs(context.Background(), 0, 1)
+	require.NoError(t, err)
+	require.Equal(t, []hexutil.Bytes{[]byte("batch 0")}, page.Blobs)
+	require.NotNil(t, page.Next)
+	page, err = rollupClient.ExportDABlobs(context.Background(), uint64(*page.Next), 0)
+	require.NoError(t, err)
+	require.Equal(t, []hexutil.Bytes{[]byte("batch 1")}, page.Blobs)
+	require.Nil(t, page.Next)
+
+	added, err := rollupClient.ImportDABlobs(context.Background(), []hexutil.Bytes{[]byte("batch 2")})
+	require.NoError(t, err)
+	require.Equal(t, 1, added)
+	require.Equal(t, []byte("batch 2"), cache.blobs[2])
+}
+
 type mockDriverClient struct {
 	mock.Mock
 }
@@ -0,0 +1,24 @@
+package rollup
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/da/dac"
+	"github.com/stretchr/testify/require"
+)
+
+// NewTestDACKeyset is a keyset of the DAC members signing with the given BLS private keys, along
+// with their proofs of possession, requiring all of them to be honnest. It is a test fixture.
+func NewTestDACKeyset(t *testing.T, privateKeys ...string) DACKeyset {
+	keyset := DACKeyset{HonnestMembersAssumption: uint(len(privateKeys))}
+	for _, privateKey := range privateKeys {
+		signer, err := dac.NewSigner(privateKey)
+		require.NoError(t, err)
+		proof, err := signer.ProofOfPossession()
+		require.NoError(t, err)
+		keyset.PublicKeys = append(keyset.PublicKeys, hex.EncodeToString(signer.GetPublicKey().ToBytes()))
+		keyset.ProofsOfPossession = append(keyset.ProofsOfPossession, hex.EncodeToString(proof.ToBytes()))
+	}
+	return keyset
+}
@@ -69,7 +69,7 @@ func NewDataSource(ctx context.Context, log log.Logger, cfg *rollup.Config, fetc
 // NewDataSourceWithDA creates a new calldata source. It suppresses errors in fetching the L1 block if they occur.
 // If there is an error, it will attempt to fetch the result on the next call to `Next`.
 func NewDataSourceWithDA(ctx context.Context, log log.Logger, cfg *rollup.Config, fetcher L1TransactionFetcher, block eth.BlockID, batcherAddr common.Address, da da.Client) DataIter {
-	_, txs, err := fetcher.InfoAndTxsByHash(ctx, block.Hash)
+	info, txs, err := fetcher.InfoAndTxsByHash(ctx, block.Hash)
 	if err != nil {
 		return &DataSource{
 			open:        false,
@@ -81,7 +81,7 @@ func NewDataSourceWithDA(ctx context.Context, log log.Logger, cfg *rollup.Config
       da: da,
 		}
 	} else {
-		data, err := DataFromEVMTransactions(cfg, batcherAddr, txs, da, log.New("origin", block))
+		data, err := DataFromEVMTransactions(ctx, cfg, batcherAddr, info.Time(), txs, da, log.New("origin", block))
 		if err != nil {
 			return &DataSource{
 				open:        false,
@@ -105,13 +105,13 @@ func NewDataSourceWithDA(ctx context.Context, log log.Logger, cfg *rollup.Config
 // otherwise it returns a temporary error if fetching the block returns an error.
 func (ds *DataSource) Next(ctx context.Context) (eth.Data, error) {
 	if !ds.open {
-		if _, txs, err := ds.fetcher.InfoAndTxsByHash(ctx, ds.id.Hash); err == nil {
-			ds.open = true
-
-			data, err := DataFromEVMTransactions(ds.cfg, ds.batcherAddr, txs, ds.da, log.New("origin", ds.id))
+		if info, txs, err := ds.fetcher.InfoAndTxsByHash(ctx, ds.id.Hash); err == nil {
+			data, err := DataFromEVMTransactions(ctx, ds.cfg, ds.batcherAddr, info.Time(), txs, ds.da, log.New("origin", ds.id))
 			if err != nil {
-				return nil, NewTemporaryError(fmt.Errorf("failed to retrieve data from transaction: %w", err))
+				// the source stays closed, so that the batches are retrieved again
+				return nil, daError(fmt.Errorf("failed to retrieve data from transaction: %w", err))
 			}
+			ds.open = true
 			ds.data = data
 		} else if errors.Is(err, ethereum.NotFound) {
 			return nil, NewResetError(fmt.Errorf("failed to open calldata source: %w", err))
@@ -128,10 +128,31 @@ func (ds *DataSource) Next(ctx context.Context) (eth.Data, error) {
 	}
 }
 
+// daError maps the errors of a DA client onto the error classes of the pipeline.
+// Invalid batch refs never get here, as they are skipped.
+// No DA error resets the pipeline: a reset derives again from the same L1 data, so it
+// would only fail on the same batch after dropping the progress of the pipeline.
+func daError(err error) error {
+  switch {
+  case errors.Is(err, da.ErrMisconfigured):
+    // no retry can succeed until the node is reconfigured
+    return NewCriticalError(err)
+  case errors.Is(err, da.ErrBatchNotFound):
+    // the L1 block still commits to the batch, which another member may store, or the node
+    // be pointed at a DA still storing it. Derivation halts on the batch until then.
+    return NewTemporaryError(err)
+  default:
+    // da.ErrUnavailable, and the errors of clients classifying none of their failures
+    return NewTemporaryError(err)
+  }
+}
+
 // DataFromEVMTransactions filters all of the transactions and returns the calldata from transactions
 // that are sent to the batch inbox address from the batch sender address.
+// l1Time is the timestamp of the L1 block of the transactions, which the batch refs are validated at.
+// Refs that are not valid are skipped, the other errors of the DA client are returned.
 // This will return an empty array if no valid transactions are found.
-func DataFromEVMTransactions(config *rollup.Config, batcherAddr common.Address, txs types.Transactions, daClient da.Client, log log.Logger) ([]eth.Data, error) {
+func DataFromEVMTransactions(ctx context.Context, config *rollup.Config, batcherAddr common.Address, l1Time uint64, txs types.Transactions, daClient da.Client, log log.Logger) ([]eth.Data, error) {
 	var out []eth.Data
 	l1Signer := config.L1Signer()
 	for j, tx := range txs {
@@ -152,8 +173,11 @@ func DataFromEVMTransactions(config *rollup.Config, batcherAddr common.Address,
 			}
 
       ref := tx.Data()
-      data, err := daClient.GetBatch(ref)
-      if err != nil {
+      data, err := daClient.GetBatch(ctx, ref, l1Time)
+      if errors.Is(err, da.ErrInvalidBatchRef) {
+        log.Warn("tx in inbox with invalid batch ref", "index", j, "err", err)
+        continue // invalid ref or certificate, ignore
+      } else if err != nil {
         return nil, fmt.Errorf("could not retrieve batch from ref: %w", err)
       }
 
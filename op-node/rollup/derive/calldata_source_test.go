@@ -1,7 +1,11 @@
 package derive
 
 import (
+	"bytes"
+	"context"
 	"crypto/ecdsa"
+	"errors"
+	"fmt"
 	"math/big"
 	"math/rand"
 	"testing"
@@ -14,6 +18,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 
+	"github.com/ethereum-optimism/optimism/da"
 	"github.com/ethereum-optimism/optimism/da/rollupda"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
@@ -122,8 +127,105 @@ func TestDataFromEVMTransactions(t *testing.T) {
 			}
 		}
 
-		out, _ := DataFromEVMTransactions(cfg, batcherAddr, txs, rollupda.NewClient(cfg.BatchInboxAddress), testlog.Logger(t, log.LvlCrit))
+		out, _ := DataFromEVMTransactions(context.Background(), cfg, batcherAddr, 0, txs, rollupda.NewClient(cfg.BatchInboxAddress), testlog.Logger(t, log.LvlCrit))
 		require.ElementsMatch(t, expectedData, out)
 	}
 
 }
+
+// rejectingDA resolves refs to themselves, except for the configured invalid and failing refs.
+// Failing refs fail with err, or with an unclassified error if not set.
+type rejectingDA struct {
+	invalid []byte
+	failing []byte
+	err     error
+}
+
+func (c *rejectingDA) PostBatch(ctx context.Context, data []byte, l1Time uint64) (da.BatchRef, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *rejectingDA) GetBatch(ctx context.Context, ref []byte, l1Time uint64) ([]byte, error) {
+	if bytes.Equal(ref, c.invalid) {
+		return nil, fmt.Errorf("bad certificate: %w", da.ErrInvalidBatchRef)
+	}
+	if bytes.Equal(ref, c.failing) {
+		if c.err != nil {
+			return nil, c.err
+		}
+		return nil, errors.New("DA unreachable")
+	}
+	return ref, nil
+}
+
+// TestDataFromEVMTransactionsInvalidRef asserts that refs rejected by the DA client are skipped,
+// while other DA errors abort the data retrieval so that it can be retried.
+func TestDataFromEVMTransactionsInvalidRef(t *testing.T) {
+	batcherPriv := testutils.RandomKey()
+	cfg := &rollup.Config{
+		L1ChainID:         big.NewInt(100),
+		BatchInboxAddress: testutils.RandomAddress(rand.New(rand.NewSource(1234))),
+	}
+	batcherAddr := crypto.PubkeyToAddress(batcherPriv.PublicKey)
+	signer := cfg.L1Signer()
+	rng := rand.New(rand.NewSource(42))
+
+	good := (&testTx{to: &cfg.BatchInboxAddress, dataLen: 1234, author: batcherPriv}).Create(t, signer, rng)
+	bad := (&testTx{to: &cfg.BatchInboxAddress, dataLen: 1234, author: batcherPriv}).Create(t, signer, rng)
+	txs := types.Transactions{good, bad}
+	logger := testlog.Logger(t, log.LvlCrit)
+
+	out, err := DataFromEVMTransactions(context.Background(), cfg, batcherAddr, 0, txs, &rejectingDA{invalid: bad.Data()}, logger)
+	require.NoError(t, err)
+	require.Equal(t, []eth.Data{good.Data()}, out)
+
+	_, err = DataFromEVMTransactions(context.Background(), cfg, batcherAddr, 0, txs, &rejectingDA{failing: bad.Data()}, logger)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, da.ErrInvalidBatchRef)
+}
+
+// TestDataSourceDAErrors asserts that the DA errors are mapped onto the pipeline error classes,
+// and that the batches are retrieved again once the DA recovers.
+func TestDataSourceDAErrors(t *testing.T) {
+	batcherPriv := testutils.RandomKey()
+	cfg := &rollup.Config{
+		L1ChainID:         big.NewInt(100),
+		BatchInboxAddress: testutils.RandomAddress(rand.New(rand.NewSource(1234))),
+	}
+	batcherAddr := crypto.PubkeyToAddress(batcherPriv.PublicKey)
+	rng := rand.New(rand.NewSource(42))
+	tx := (&testTx{to: &cfg.BatchInboxAddress, dataLen: 1234, author: batcherPriv}).Create(t, cfg.L1Signer(), rng)
+	info := testutils.RandomBlockInfo(rng)
+	block := info.ID()
+	logger := testlog.Logger(t, log.LvlCrit)
+
+	for _, tc := range []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{"unclassified", errors.New("DA unreachable"), ErrTemporary},
+		{"unavailable", fmt.Errorf("no member answered: %w", da.ErrUnavailable), ErrTemporary},
+		{"not found", fmt.Errorf("batch pruned: %w", da.ErrBatchNotFound), ErrTemporary},
+		{"misconfigured", fmt.Errorf("read-only: %w", da.ErrMisconfigured), ErrCritical},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			l1F := &testutils.MockL1Source{}
+			defer l1F.AssertExpectations(t)
+			l1F.ExpectInfoAndTxsByHash(block.Hash, info, types.Transactions{tx}, nil)
+			l1F.ExpectInfoAndTxsByHash(block.Hash, info, types.Transactions{tx}, nil)
+			l1F.ExpectInfoAndTxsByHash(block.Hash, info, types.Transactions{tx}, nil)
+
+			daClient := &rejectingDA{failing: tx.Data(), err: tc.err}
+			src := NewDataSourceWithDA(context.Background(), logger, cfg, l1F, block, batcherAddr, daClient)
+			_, err := src.Next(context.Background())
+			require.ErrorIs(t, err, tc.expected)
+			require.ErrorIs(t, err, tc.err)
+
+			daClient.failing = nil
+			data, err := src.Next(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, eth.Data(tx.Data()), data)
+		})
+	}
+}
@@ -8,10 +8,13 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 
+	"github.com/ethereum-optimism/optimism/da/celestia"
+	"github.com/ethereum-optimism/optimism/da/dac"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 )
 
@@ -37,6 +40,10 @@ var (
 
   ErrInvalidDACHonnestMembersAssumption = errors.New("missing DAC honnest member assumption")
   ErrInsufficientDACMembers             = errors.New("insufficient number of DAC members")
+  ErrInvalidDACProofsOfPossession       = errors.New("every DAC public key needs a proof of possession")
+  ErrInvalidDACSchedule                 = errors.New("DAC keyset activation times must be increasing")
+  ErrMultipleDA                         = errors.New("a DAC and a blob DA cannot both be configured")
+  ErrInvalidDACDataShards               = errors.New("DAC data shards must not exceed the honnest member assumption")
 )
 
 type Genesis struct {
@@ -52,12 +59,35 @@ type Genesis struct {
 	SystemConfig eth.SystemConfig `json:"system_config"`
 }
 
-type DAC struct {
+type DACKeyset struct {
   // List of the DAC members' public keys.
   // Proof of owneship of the associated private keys must be done.
   PublicKeys []string `json:"public_keys"`
+  // ProofsOfPossession[i] is the proof of ownership of the private key of PublicKeys[i],
+  // checked when the keyset is created.
+  ProofsOfPossession []string `json:"proofs_of_possession"`
 
   HonnestMembersAssumption uint `json:"honnest_members_assumption"`
+  // DataShards erasure codes the batches across the members when set: each member stores one
+  // chunk, and any DataShards chunks reconstruct a batch. The signers of a certificate must hold
+  // enough chunks, so it cannot exceed the honnest members assumption.
+  DataShards uint `json:"data_shards,omitempty"`
+}
+
+// ScheduledDACKeyset is a DAC keyset replacing the previous one from an activation time
+type ScheduledDACKeyset struct {
+  // ActivationTime is the timestamp of the L1 block from which batch refs are verified against the keyset.
+  // Batches certified by the previous keyset and included from then on are dropped, so the previous
+  // members should keep serving batches until the batcher switched over.
+  ActivationTime uint64 `json:"activation_time"`
+  DACKeyset
+}
+
+type DAC struct {
+  // Keyset active from genesis
+  DACKeyset
+  // Schedule of the keysets rotating the committee, by increasing activation time
+  Schedule []ScheduledDACKeyset `json:"schedule,omitempty"`
 }
 
 type Config struct {
@@ -86,11 +116,26 @@ type Config struct {
 	// Active if RegolithTime != nil && L2 block timestamp >= *RegolithTime, inactive otherwise.
 	RegolithTime *uint64 `json:"regolith_time,omitempty"`
 
+	// DACV1Time sets the activation time of the version 1 DAC signature scheme:
+	// batch certificates with a standard hash to curve, domain separated by the L2 chain ID.
+	// Unlike the other network upgrades, it is compared to the timestamp of the L1 block including the batch ref.
+	// Active if DACV1Time != nil && L1 block timestamp >= *DACV1Time, inactive otherwise.
+	// Legacy certificates are rejected once it is active.
+	DACV1Time *uint64 `json:"dac_v1_time,omitempty"`
+
+	// DACFallbackTime sets the activation time of the L1 calldata fallback: batches posted directly to
+	// the batch inbox, prefixed by the calldata header, while the DA of the chain is unavailable.
+	// Like DACV1Time, it is compared to the timestamp of the L1 block including the batch.
+	// Active if DACFallbackTime != nil && L1 block timestamp >= *DACFallbackTime, inactive otherwise.
+	DACFallbackTime *uint64 `json:"dac_fallback_time,omitempty"`
+
 	// Note: below addresses are part of the block-derivation process,
 	// and required to be the same network-wide to stay in consensus.
 
   // DAC configuration
   DataAvailabilityComittee *DAC `json:"data_availability_committee,omitempty"`
+  // BlobDA configures a Celestia-style DA, exclusive with the DAC
+  BlobDA *BlobDA `json:"blob_da,omitempty"`
 
 	// L1 address that batches are sent to.
 	BatchInboxAddress common.Address `json:"batch_inbox_address"`
@@ -258,23 +303,89 @@ func (cfg *Config) Check() error {
 	if cfg.L2ChainID.Sign() < 1 {
 		return ErrL2ChainIDNotPositive
 	}
+	if cfg.DataAvailabilityComittee != nil && cfg.BlobDA != nil {
+		return ErrMultipleDA
+	}
+	if err := cfg.BlobDA.Check(); err != nil {
+		return err
+	}
 	return cfg.DataAvailabilityComittee.Check()
 }
 
-func (dac *DAC) Check() error {
-  if (dac == nil) {
+// BlobDA is a DA node posting the batches as blobs of a namespace, the L1 inbox txs reference
+// the blobs by inclusion height and commitment
+type BlobDA struct {
+  Namespace hexutil.Bytes `json:"namespace"`
+}
+
+func (b *BlobDA) Check() error {
+  if b == nil {
     return nil
   }
+  return celestia.CheckNamespace(b.Namespace)
+}
 
-  if len(dac.PublicKeys) < 2 {
+func (d *DAC) Check() error {
+  if (d == nil) {
+    return nil
+  }
+
+  if err := d.DACKeyset.Check(); err != nil {
+    return err
+  }
+  for i, keyset := range d.Schedule {
+    // the genesis keyset is the one active from time zero
+    if (i == 0 && keyset.ActivationTime == 0) || (i > 0 && keyset.ActivationTime <= d.Schedule[i-1].ActivationTime) {
+      return fmt.Errorf("%w: keyset %v", ErrInvalidDACSchedule, i)
+    }
+    if err := keyset.Check(); err != nil {
+      return fmt.Errorf("scheduled DAC keyset %v: %w", i, err)
+    }
+  }
+  return nil
+}
+
+func (keyset *DACKeyset) Check() error {
+  if len(keyset.PublicKeys) < 2 {
     return ErrInsufficientDACMembers
   }
-  if dac.HonnestMembersAssumption == 0 || dac.HonnestMembersAssumption > uint(len(dac.PublicKeys)) {
+  if keyset.HonnestMembersAssumption == 0 || keyset.HonnestMembersAssumption > uint(len(keyset.PublicKeys)) {
     return ErrInvalidDACHonnestMembersAssumption
   }
+  if len(keyset.ProofsOfPossession) != len(keyset.PublicKeys) {
+    return ErrInvalidDACProofsOfPossession
+  }
+  if keyset.DataShards > keyset.HonnestMembersAssumption {
+    return ErrInvalidDACDataShards
+  }
   return nil
 }
 
+// Committees creates the DAC committee schedule, verifying the proofs of possession of every keyset
+func (d *DAC) Committees() (dac.CommitteeSchedule, error) {
+  newCommittee := func(keyset *DACKeyset) (dac.Committee, error) {
+    keys, err := dac.NewKeySetWithProofs(keyset.PublicKeys, keyset.ProofsOfPossession)
+    if err != nil {
+      return dac.Committee{}, err
+    }
+    return dac.Committee{Keyset: keys, Threshold: keyset.HonnestMembersAssumption, DataShards: keyset.DataShards}, nil
+  }
+
+  genesis, err := newCommittee(&d.DACKeyset)
+  if err != nil {
+    return nil, fmt.Errorf("invalid DAC keyset: %w", err)
+  }
+  committees := []dac.ScheduledCommittee{{Committee: genesis}}
+  for i := range d.Schedule {
+    committee, err := newCommittee(&d.Schedule[i].DACKeyset)
+    if err != nil {
+      return nil, fmt.Errorf("invalid scheduled DAC keyset %v: %w", i, err)
+    }
+    committees = append(committees, dac.ScheduledCommittee{ActivationTime: d.Schedule[i].ActivationTime, Committee: committee})
+  }
+  return dac.NewCommitteeSchedule(committees...)
+}
+
 func (c *Config) L1Signer() types.Signer {
 	return types.NewLondonSigner(c.L1ChainID)
 }
@@ -284,6 +395,16 @@ func (c *Config) IsRegolith(timestamp uint64) bool {
 	return c.RegolithTime != nil && timestamp >= *c.RegolithTime
 }
 
+// IsDACV1 returns true if the version 1 DAC signature scheme is active at or past the given L1 timestamp.
+func (c *Config) IsDACV1(l1Timestamp uint64) bool {
+	return c.DACV1Time != nil && l1Timestamp >= *c.DACV1Time
+}
+
+// IsDACFallback returns true if L1 calldata batches are accepted at or past the given L1 timestamp.
+func (c *Config) IsDACFallback(l1Timestamp uint64) bool {
+	return c.DACFallbackTime != nil && l1Timestamp >= *c.DACFallbackTime
+}
+
 // Description outputs a banner describing the important parts of rollup configuration in a human-readable form.
 // Optionally provide a mapping of L2 chain IDs to network names to label the L2 chain with if not unknown.
 // The config should be config.Check()-ed before creating a description.
@@ -311,6 +432,11 @@ func (c *Config) Description(l2Chains map[string]string) string {
 	// Report the upgrade configuration
 	banner += "Post-Bedrock Network Upgrades (timestamp based):\n"
 	banner += fmt.Sprintf("  - Regolith: %s\n", fmtForkTimeOrUnset(c.RegolithTime))
+	banner += fmt.Sprintf("  - DAC V1 (L1 timestamp): %s\n", fmtForkTimeOrUnset(c.DACV1Time))
+	banner += fmt.Sprintf("  - DAC fallback (L1 timestamp): %s\n", fmtForkTimeOrUnset(c.DACFallbackTime))
+	if c.BlobDA != nil {
+		banner += fmt.Sprintf("Blob DA namespace: %s\n", c.BlobDA.Namespace)
+	}
 	return banner
 }
 
@@ -333,7 +459,8 @@ func (c *Config) LogDescription(log log.Logger, l2Chains map[string]string) {
 	log.Info("Rollup Config", "l2_chain_id", c.L2ChainID, "l2_network", networkL2, "l1_chain_id", c.L1ChainID,
 		"l1_network", networkL1, "l2_start_time", c.Genesis.L2Time, "l2_block_hash", c.Genesis.L2.Hash.String(),
 		"l2_block_number", c.Genesis.L2.Number, "l1_block_hash", c.Genesis.L1.Hash.String(),
-		"l1_block_number", c.Genesis.L1.Number, "regolith_time", fmtForkTimeOrUnset(c.RegolithTime))
+		"l1_block_number", c.Genesis.L1.Number, "regolith_time", fmtForkTimeOrUnset(c.RegolithTime),
+		"dac_v1_time", fmtForkTimeOrUnset(c.DACV1Time), "dac_fallback_time", fmtForkTimeOrUnset(c.DACFallbackTime))
 }
 
 func fmtForkTimeOrUnset(v *uint64) string {
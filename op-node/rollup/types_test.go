@@ -14,6 +14,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 
+	"github.com/ethereum-optimism/optimism/da/celestia"
+	"github.com/ethereum-optimism/optimism/da/dac"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 )
 
@@ -396,3 +398,121 @@ func TestConfig_Check(t *testing.T) {
 		})
 	}
 }
+
+func TestDAC_Check(t *testing.T) {
+	genesis := NewTestDACKeyset(t, "0x1001", "0x1002")
+	rotated := NewTestDACKeyset(t, "0x1002", "0x1003")
+	tests := []struct {
+		name        string
+		schedule    []ScheduledDACKeyset
+		expectedErr error
+	}{
+		{
+			name:     "NoSchedule",
+			schedule: nil,
+		},
+		{
+			name:     "IncreasingSchedule",
+			schedule: []ScheduledDACKeyset{{100, rotated}, {200, genesis}},
+		},
+		{
+			name:        "GenesisActivation",
+			schedule:    []ScheduledDACKeyset{{0, rotated}},
+			expectedErr: ErrInvalidDACSchedule,
+		},
+		{
+			name:        "DecreasingSchedule",
+			schedule:    []ScheduledDACKeyset{{200, rotated}, {100, genesis}},
+			expectedErr: ErrInvalidDACSchedule,
+		},
+		{
+			name:        "SameActivationTime",
+			schedule:    []ScheduledDACKeyset{{100, rotated}, {100, genesis}},
+			expectedErr: ErrInvalidDACSchedule,
+		},
+		{
+			name:        "InsufficientMembers",
+			schedule:    []ScheduledDACKeyset{{100, NewTestDACKeyset(t, "0x1003")}},
+			expectedErr: ErrInsufficientDACMembers,
+		},
+		{
+			name: "ThresholdTooHigh",
+			schedule: []ScheduledDACKeyset{{100, DACKeyset{
+				PublicKeys:               rotated.PublicKeys,
+				ProofsOfPossession:       rotated.ProofsOfPossession,
+				HonnestMembersAssumption: 3,
+			}}},
+			expectedErr: ErrInvalidDACHonnestMembersAssumption,
+		},
+		{
+			name: "MissingProofs",
+			schedule: []ScheduledDACKeyset{{100, DACKeyset{
+				PublicKeys:               rotated.PublicKeys,
+				HonnestMembersAssumption: 2,
+			}}},
+			expectedErr: ErrInvalidDACProofsOfPossession,
+		},
+		{
+			name: "ErasureCoded",
+			schedule: []ScheduledDACKeyset{{100, DACKeyset{
+				PublicKeys:               rotated.PublicKeys,
+				ProofsOfPossession:       rotated.ProofsOfPossession,
+				HonnestMembersAssumption: 2,
+				DataShards:               2,
+			}}},
+		},
+		{
+			name: "TooManyDataShards",
+			schedule: []ScheduledDACKeyset{{100, DACKeyset{
+				PublicKeys:               rotated.PublicKeys,
+				ProofsOfPossession:       rotated.ProofsOfPossession,
+				HonnestMembersAssumption: 1,
+				DataShards:               2,
+			}}},
+			expectedErr: ErrInvalidDACDataShards,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := randConfig()
+			cfg.DataAvailabilityComittee = &DAC{DACKeyset: genesis, Schedule: test.schedule}
+			err := cfg.Check()
+			if test.expectedErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, test.expectedErr)
+			}
+		})
+	}
+}
+
+func TestBlobDA_Check(t *testing.T) {
+	cfg := randConfig()
+	cfg.BlobDA = &BlobDA{Namespace: make([]byte, celestia.NamespaceSize)}
+	require.NoError(t, cfg.Check())
+
+	cfg.BlobDA.Namespace = cfg.BlobDA.Namespace[1:]
+	require.ErrorIs(t, cfg.Check(), celestia.ErrInvalidNamespace)
+
+	cfg.BlobDA.Namespace = make([]byte, celestia.NamespaceSize)
+	cfg.DataAvailabilityComittee = &DAC{DACKeyset: NewTestDACKeyset(t, "0x1001", "0x1002")}
+	require.ErrorIs(t, cfg.Check(), ErrMultipleDA)
+}
+
+func TestDAC_Committees(t *testing.T) {
+	genesis := NewTestDACKeyset(t, "0x1001", "0x1002")
+	rotated := NewTestDACKeyset(t, "0x1002", "0x1003", "0x1004")
+	rotated.HonnestMembersAssumption = 2
+	d := &DAC{DACKeyset: genesis, Schedule: []ScheduledDACKeyset{{100, rotated}}}
+
+	committees, err := d.Committees()
+	require.NoError(t, err)
+	require.Len(t, committees.At(0).Keyset, 2)
+	require.Equal(t, uint(2), committees.At(99).Threshold)
+	require.Len(t, committees.At(100).Keyset, 3)
+	require.Equal(t, uint(2), committees.At(100).Threshold)
+
+	rotated.ProofsOfPossession[0], rotated.ProofsOfPossession[1] = rotated.ProofsOfPossession[1], rotated.ProofsOfPossession[0]
+	_, err = d.Committees()
+	require.ErrorIs(t, err, dac.ErrInvalidProofOfPossession)
+}
@@ -8,10 +8,13 @@ import (
 	"os"
 	"strings"
 
+	"github.com/ethereum-optimism/optimism/da/celestia"
 	"github.com/ethereum-optimism/optimism/da/dac"
+	"github.com/ethereum-optimism/optimism/da/fallback"
 	"github.com/ethereum-optimism/optimism/da/rollupda"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-node/sources"
+	ophttp "github.com/ethereum-optimism/optimism/op-service/httputil"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
 
 	"github.com/urfave/cli"
@@ -63,12 +66,44 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
   daURL := ctx.GlobalString(flags.CentralizedDAApiFlag.Name)
   if rollupConfig.DataAvailabilityComittee != nil {
     log.Info("initializing DAC da client", "url", daURL)
-    log.Info("public keys", "public_keys", rollupConfig.DataAvailabilityComittee.PublicKeys)
-    keyset, err := dac.NewKeySetFromString(rollupConfig.DataAvailabilityComittee.PublicKeys)
+    log.Info("public keys", "public_keys", rollupConfig.DataAvailabilityComittee.PublicKeys, "rotations", len(rollupConfig.DataAvailabilityComittee.Schedule))
+    committees, err := rollupConfig.DataAvailabilityComittee.Committees()
     if err != nil {
-      return nil, fmt.Errorf("could not create DAC keyset: %w", err)
+      return nil, fmt.Errorf("could not create DAC committees: %w", err)
     }
-    daClient = dac.NewClient(daURL, rollupConfig.BatchInboxAddress, keyset)
+    schemes := dac.SchemeConfig{ChainID: rollupConfig.L2ChainID, V1Time: rollupConfig.DACV1Time}
+    httpClient, err := ophttp.NewClient(log, ophttp.ReadClientCLIConfig(ctx, flags.DAHTTPFlagPrefix))
+    if err != nil {
+      return nil, fmt.Errorf("could not create DA http client: %w", err)
+    }
+    if members := ctx.GlobalStringSlice(flags.DACMembersFlag.Name); len(members) > 0 {
+      log.Info("retrieving batches from DAC members", "members", members)
+      memberUrls, err := dac.ParseMemberUrls(members)
+      if err != nil {
+        return nil, err
+      }
+      daClient, err = dac.NewReader(log, memberUrls, committees, schemes, httpClient)
+      if err != nil {
+        return nil, fmt.Errorf("could not create DAC reader: %w", err)
+      }
+    } else {
+      daClient = dac.NewClient(daURL, rollupConfig.BatchInboxAddress, committees, nil, schemes, httpClient)
+    }
+    // the batcher may post batches to L1 calldata while the DAC is unavailable
+    daClient = fallback.NewClient(log, daClient, rollupConfig.BatchInboxAddress, rollupConfig.DACFallbackTime, 0)
+  } else if rollupConfig.BlobDA != nil {
+    rpcUrl := ctx.GlobalString(flags.BlobDARPCFlag.Name)
+    log.Info("initializing blob da client", "rpc", rpcUrl, "namespace", rollupConfig.BlobDA.Namespace)
+    httpClient, err := ophttp.NewClient(log, ophttp.ReadClientCLIConfig(ctx, flags.DAHTTPFlagPrefix))
+    if err != nil {
+      return nil, fmt.Errorf("could not create DA http client: %w", err)
+    }
+    daClient, err = celestia.NewClient(rpcUrl, ctx.GlobalString(flags.BlobDAAuthTokenFlag.Name), rollupConfig.BlobDA.Namespace, rollupConfig.BatchInboxAddress, httpClient)
+    if err != nil {
+      return nil, fmt.Errorf("could not create blob DA client: %w", err)
+    }
+    // the batcher may post batches to L1 calldata while the blob DA is unavailable
+    daClient = fallback.NewClient(log, daClient, rollupConfig.BatchInboxAddress, rollupConfig.DACFallbackTime, 0)
   }
 
 	cfg := &node.Config{
@@ -77,6 +112,10 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		L2Sync: l2SyncEndpoint,
 
     DA: daClient,
+    DACache: node.DACacheConfig{
+      Path: ctx.GlobalString(flags.DACCachePathFlag.Name),
+      MaxSize: ctx.GlobalUint64(flags.DACCacheMaxSizeFlag.Name),
+    },
 
 		Rollup: *rollupConfig,
 		Driver: *driverConfig,
@@ -3,6 +3,7 @@ package sources
 import (
 	"context"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 
 	"github.com/ethereum-optimism/optimism/op-node/client"
@@ -24,6 +25,22 @@ func (r *RollupClient) OutputAtBlock(ctx context.Context, blockNum uint64) (*eth
 	return output, err
 }
 
+func (r *RollupClient) OutputsAtBlocks(ctx context.Context, blockNums []uint64) ([]*eth.OutputResponse, error) {
+	numbers := make([]hexutil.Uint64, len(blockNums))
+	for i, blockNum := range blockNums {
+		numbers[i] = hexutil.Uint64(blockNum)
+	}
+	var outputs []*eth.OutputResponse
+	err := r.rpc.CallContext(ctx, &outputs, "optimism_outputsAtBlocks", numbers)
+	return outputs, err
+}
+
+func (r *RollupClient) WithdrawalProof(ctx context.Context, blockNum uint64, withdrawalHash common.Hash) (*eth.WithdrawalProofResponse, error) {
+	var output *eth.WithdrawalProofResponse
+	err := r.rpc.CallContext(ctx, &output, "optimism_withdrawalProof", hexutil.Uint64(blockNum), withdrawalHash)
+	return output, err
+}
+
 func (r *RollupClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	var output *eth.SyncStatus
 	err := r.rpc.CallContext(ctx, &output, "optimism_syncStatus")
@@ -41,3 +58,15 @@ func (r *RollupClient) Version(ctx context.Context) (string, error) {
 	err := r.rpc.CallContext(ctx, &output, "optimism_version")
 	return output, err
 }
+
+func (r *RollupClient) ExportDABlobs(ctx context.Context, cursor uint64, limit int) (*eth.DABlobsPage, error) {
+	var output *eth.DABlobsPage
+	err := r.rpc.CallContext(ctx, &output, "admin_exportDABlobs", hexutil.Uint64(cursor), limit)
+	return output, err
+}
+
+func (r *RollupClient) ImportDABlobs(ctx context.Context, blobs []hexutil.Bytes) (int, error) {
+	var output int
+	err := r.rpc.CallContext(ctx, &output, "admin_importDABlobs", blobs)
+	return output, err
+}
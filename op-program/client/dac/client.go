@@ -0,0 +1,42 @@
+package dac
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/da"
+	opdac "github.com/ethereum-optimism/optimism/da/dac"
+)
+
+var ErrReadOnlyClient = fmt.Errorf("%w: oracle DA client cannot post batches", da.ErrMisconfigured)
+
+// OracleClient is a read-only da.Client verifying DAC batch refs like op-node does, and
+// retrieving the certified batch data from the pre-image oracle.
+type OracleClient struct {
+	oracle     Oracle
+	committees opdac.CommitteeSchedule
+	schemes    opdac.SchemeConfig
+}
+
+var _ da.Client = (*OracleClient)(nil)
+
+func NewOracleClient(oracle Oracle, committees opdac.CommitteeSchedule, schemes opdac.SchemeConfig) *OracleClient {
+	return &OracleClient{
+		oracle:     oracle,
+		committees: committees,
+		schemes:    schemes,
+	}
+}
+
+func (c *OracleClient) PostBatch(ctx context.Context, data []byte, l1Time uint64) (da.BatchRef, error) {
+	return nil, ErrReadOnlyClient
+}
+
+// GetBatch ignores the context, as the oracle cannot be interrupted
+func (c *OracleClient) GetBatch(ctx context.Context, ref []byte, l1Time uint64) ([]byte, error) {
+	dataHash, err := opdac.VerifyBatchRef(ref, c.committees, c.schemes, l1Time)
+	if err != nil {
+		return nil, err
+	}
+	return c.oracle.BatchByHash(dataHash), nil
+}
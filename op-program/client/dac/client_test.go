@@ -0,0 +1,72 @@
+package dac
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/da"
+	opdac "github.com/ethereum-optimism/optimism/da/dac"
+)
+
+type stubOracle struct {
+	t       *testing.T
+	batches map[common.Hash][]byte
+}
+
+func (o stubOracle) BatchByHash(dataHash common.Hash) []byte {
+	batch, ok := o.batches[dataHash]
+	if !ok {
+		o.t.Fatalf("unknown batch %s", dataHash)
+	}
+	return batch
+}
+
+func TestOracleClient(t *testing.T) {
+	batch := []byte("some batch data")
+	dataHash := crypto.Keccak256Hash(batch)
+
+	var (
+		keys       [][]byte
+		signatures []opdac.Signature
+	)
+	for _, privateKey := range []string{"0x1001", "0x1002"} {
+		signer, err := opdac.NewSigner(privateKey)
+		require.NoError(t, err)
+		sig, err := signer.Sign(opdac.BatchDomain(opdac.SchemeLegacy, nil), dataHash.Bytes())
+		require.NoError(t, err)
+		keys = append(keys, signer.GetPublicKey().ToBytes())
+		signatures = append(signatures, sig)
+	}
+	keyset, err := opdac.NewKeySet(keys)
+	require.NoError(t, err)
+
+	oracle := stubOracle{t: t, batches: map[common.Hash][]byte{dataHash: batch}}
+	client := NewOracleClient(oracle, opdac.SingleCommittee(keyset, 2), opdac.SchemeConfig{})
+
+	ref := append([]byte{opdac.DACBatchHeaderID}, dataHash.Bytes()...)
+	ref = append(ref, opdac.AggregateSignatures(signatures).ToBytes()...)
+	ref = binary.BigEndian.AppendUint64(ref, 0b11)
+
+	t.Run("Certified", func(t *testing.T) {
+		result, err := client.GetBatch(context.Background(), ref, 0)
+		require.NoError(t, err)
+		require.Equal(t, batch, result)
+	})
+
+	t.Run("NotEnoughSigners", func(t *testing.T) {
+		invalid := append([]byte{}, ref...)
+		binary.BigEndian.PutUint64(invalid[len(invalid)-8:], 0b01)
+		_, err := client.GetBatch(context.Background(), invalid, 0)
+		require.ErrorIs(t, err, da.ErrInvalidBatchRef)
+	})
+
+	t.Run("ReadOnly", func(t *testing.T) {
+		_, err := client.PostBatch(context.Background(), batch, 0)
+		require.ErrorIs(t, err, ErrReadOnlyClient)
+	})
+}
@@ -0,0 +1,20 @@
+package dac
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-program/preimage"
+)
+
+const (
+	HintDACBatch = "dac-batch"
+)
+
+// BatchHint requests the batch data of the given keccak256 hash
+type BatchHint common.Hash
+
+var _ preimage.Hint = BatchHint{}
+
+func (l BatchHint) Hint() string {
+	return HintDACBatch + " " + (common.Hash)(l).String()
+}
@@ -0,0 +1,33 @@
+package dac
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-program/preimage"
+)
+
+type Oracle interface {
+	// BatchByHash retrieves the DAC batch data with the given keccak256 hash.
+	BatchByHash(dataHash common.Hash) []byte
+}
+
+// PreimageOracle implements Oracle by interfacing with the pure preimage.Oracle.
+// The batch data is the keccak256 pre-image of its hash.
+type PreimageOracle struct {
+	oracle preimage.Oracle
+	hint   preimage.Hinter
+}
+
+var _ Oracle = (*PreimageOracle)(nil)
+
+func NewPreimageOracle(raw preimage.Oracle, hint preimage.Hinter) *PreimageOracle {
+	return &PreimageOracle{
+		oracle: raw,
+		hint:   hint,
+	}
+}
+
+func (p *PreimageOracle) BatchByHash(dataHash common.Hash) []byte {
+	p.hint.Hint(BatchHint(dataHash))
+	return p.oracle.Get(preimage.Keccak256Key(dataHash))
+}
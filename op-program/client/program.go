@@ -11,8 +11,12 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 
+	"github.com/ethereum-optimism/optimism/da"
+	opdac "github.com/ethereum-optimism/optimism/da/dac"
+	"github.com/ethereum-optimism/optimism/da/fallback"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-program/client/dac"
 	cldr "github.com/ethereum-optimism/optimism/op-program/client/driver"
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
 	"github.com/ethereum-optimism/optimism/op-program/client/l2"
@@ -45,6 +49,7 @@ func RunProgram(logger log.Logger, preimageOracle io.ReadWriter, preimageHinter
 	hClient := preimage.NewHintWriter(preimageHinter)
 	l1PreimageOracle := l1.NewCachingOracle(l1.NewPreimageOracle(pClient, hClient))
 	l2PreimageOracle := l2.NewCachingOracle(l2.NewPreimageOracle(pClient, hClient))
+	dacPreimageOracle := dac.NewPreimageOracle(pClient, hClient)
 
 	bootInfo := NewBootstrapClient(pClient).BootInfo()
 	logger.Info("Program Bootstrapped", "bootInfo", bootInfo)
@@ -58,20 +63,25 @@ func RunProgram(logger log.Logger, preimageOracle io.ReadWriter, preimageHinter
 		bootInfo.L2ClaimBlockNumber,
 		l1PreimageOracle,
 		l2PreimageOracle,
+		dacPreimageOracle,
 	)
 }
 
 // runDerivation executes the L2 state transition, given a minimal interface to retrieve data.
-func runDerivation(logger log.Logger, cfg *rollup.Config, l2Cfg *params.ChainConfig, l1Head common.Hash, l2Head common.Hash, l2Claim common.Hash, l2ClaimBlockNum uint64, l1Oracle l1.Oracle, l2Oracle l2.Oracle) error {
+func runDerivation(logger log.Logger, cfg *rollup.Config, l2Cfg *params.ChainConfig, l1Head common.Hash, l2Head common.Hash, l2Claim common.Hash, l2ClaimBlockNum uint64, l1Oracle l1.Oracle, l2Oracle l2.Oracle, dacOracle dac.Oracle) error {
 	l1Source := l1.NewOracleL1Client(logger, l1Oracle, l1Head)
 	engineBackend, err := l2.NewOracleBackedL2Chain(logger, l2Oracle, l2Cfg, l2Head)
 	if err != nil {
 		return fmt.Errorf("failed to create oracle-backed L2 chain: %w", err)
 	}
 	l2Source := l2.NewOracleEngine(cfg, logger, engineBackend)
+	daClient, err := newDAClient(logger, cfg, dacOracle)
+	if err != nil {
+		return err
+	}
 
 	logger.Info("Starting derivation")
-	d := cldr.NewDriver(logger, cfg, l1Source, l2Source, l2ClaimBlockNum)
+	d := cldr.NewDriver(logger, cfg, l1Source, l2Source, daClient, l2ClaimBlockNum)
 	for {
 		if err = d.Step(context.Background()); errors.Is(err, io.EOF) {
 			break
@@ -82,6 +92,31 @@ func runDerivation(logger log.Logger, cfg *rollup.Config, l2Cfg *params.ChainCon
 	return d.ValidateClaim(eth.Bytes32(l2Claim))
 }
 
+// newDAClient creates the DA client of DAC chains, retrieving the batches from the oracle like
+// op-node does from the DAC. It returns nil for chains with batches in the batch inbox calldata.
+func newDAClient(logger log.Logger, cfg *rollup.Config, dacOracle dac.Oracle) (da.Client, error) {
+	if cfg.BlobDA != nil {
+		// the blobs cannot be verified against their refs without a share commitment proof
+		return nil, errors.New("blob DA chains are not supported by the program")
+	}
+	if cfg.DataAvailabilityComittee == nil {
+		return nil, nil
+	}
+	committees, err := cfg.DataAvailabilityComittee.Committees()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create DAC committees: %w", err)
+	}
+	for _, scheduled := range committees {
+		if scheduled.ErasureCoded() {
+			// the preimages of the batches are keyed by their hash, which erasure coded refs do not certify
+			return nil, errors.New("erasure coded DAC chains are not supported by the program")
+		}
+	}
+	schemes := opdac.SchemeConfig{ChainID: cfg.L2ChainID, V1Time: cfg.DACV1Time}
+	// the batcher may post batches to L1 calldata while the DAC is unavailable
+	return fallback.NewClient(logger, dac.NewOracleClient(dacOracle, committees, schemes), cfg.BatchInboxAddress, cfg.DACFallbackTime, 0), nil
+}
+
 func CreateHinterChannel() oppio.FileChannel {
 	r := os.NewFile(HClientRFd, "preimage-hint-read")
 	w := os.NewFile(HClientWFd, "preimage-hint-write")
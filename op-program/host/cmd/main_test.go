@@ -157,6 +157,12 @@ func TestL1(t *testing.T) {
 	require.Equal(t, expected, cfg.L1URL)
 }
 
+func TestDAC(t *testing.T) {
+	expected := "https://example.com:3100"
+	cfg := configForArgs(t, addRequiredArgs("--dac", expected))
+	require.Equal(t, expected, cfg.DACURL)
+}
+
 func TestL1TrustRPC(t *testing.T) {
 	t.Run("DefaultFalse", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgs())
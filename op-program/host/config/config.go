@@ -26,6 +26,7 @@ var (
 	ErrInvalidL2ClaimBlock = errors.New("invalid l2 claim block number")
 	ErrDataDirRequired     = errors.New("datadir must be specified when in non-fetching mode")
 	ErrNoExecInServerMode  = errors.New("exec command must not be set when in server mode")
+	ErrMissingDACURL       = errors.New("dac url must be specified to fetch data of DAC chains")
 )
 
 type Config struct {
@@ -43,6 +44,8 @@ type Config struct {
 	// L2Head is the agreed L2 block to start derivation from
 	L2Head common.Hash
 	L2URL  string
+	// DACURL is the DAC HTTP api to fetch the batches of DAC chains from
+	DACURL string
 	// L2Claim is the claimed L2 output root to verify
 	L2Claim common.Hash
 	// L2ClaimBlockNumber is the block number the claimed L2 output root is from
@@ -87,6 +90,9 @@ func (c *Config) Check() error {
 	if !c.FetchingEnabled() && c.DataDir == "" {
 		return ErrDataDirRequired
 	}
+	if c.FetchingEnabled() && c.Rollup.DataAvailabilityComittee != nil && c.DACURL == "" {
+		return ErrMissingDACURL
+	}
 	if c.ServerMode && c.ExecCmd != "" {
 		return ErrNoExecInServerMode
 	}
@@ -157,6 +163,7 @@ func NewConfigFromCLI(ctx *cli.Context) (*Config, error) {
 		L1URL:              ctx.GlobalString(flags.L1NodeAddr.Name),
 		L1TrustRPC:         ctx.GlobalBool(flags.L1TrustRPC.Name),
 		L1RPCKind:          sources.RPCProviderKind(ctx.GlobalString(flags.L1RPCProviderKind.Name)),
+		DACURL:             ctx.GlobalString(flags.DACAddr.Name),
 		ExecCmd:            ctx.GlobalString(flags.Exec.Name),
 		ServerMode:         ctx.GlobalBool(flags.Server.Name),
 	}, nil
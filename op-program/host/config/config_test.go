@@ -150,6 +150,22 @@ func TestRejectExecAndServerMode(t *testing.T) {
 	require.ErrorIs(t, err, ErrNoExecInServerMode)
 }
 
+func TestRequireDACURLForDACChains(t *testing.T) {
+	rollupCfg := *validRollupConfig
+	rollupCfg.DataAvailabilityComittee = &rollup.DAC{DACKeyset: rollup.NewTestDACKeyset(t, "0x1001", "0x1002")}
+
+	cfg := validConfig()
+	cfg.Rollup = &rollupCfg
+	require.NoError(t, cfg.Check(), "Should not require a DAC url when not fetching")
+
+	cfg.L1URL = "https://example.com:1234"
+	cfg.L2URL = "https://example.com:5678"
+	require.ErrorIs(t, cfg.Check(), ErrMissingDACURL)
+
+	cfg.DACURL = "https://example.com:3100"
+	require.NoError(t, cfg.Check())
+}
+
 func validConfig() *Config {
 	cfg := NewConfig(validRollupConfig, validL2Genesis, validL1Head, validL2Head, validL2Claim, validL2ClaimBlockNum)
 	cfg.DataDir = "/tmp/configTest"
@@ -81,6 +81,11 @@ var (
 			return &out
 		}(),
 	}
+	DACAddr = cli.StringFlag{
+		Name:   "dac",
+		Usage:  "Address of the DAC HTTP api to fetch batches from, the DAS or a DAC member. Required to fetch data of DAC chains",
+		EnvVar: service.PrefixEnvVar(EnvVarPrefix, "DAC_API"),
+	}
 	Exec = cli.StringFlag{
 		Name:   "exec",
 		Usage:  "Run the specified client program as a separate process detached from the host. Default is to run the client program in the host process.",
@@ -111,6 +116,7 @@ var programFlags = []cli.Flag{
 	L1NodeAddr,
 	L1TrustRPC,
 	L1RPCProviderKind,
+	DACAddr,
 	Exec,
 	Server,
 }
@@ -7,9 +7,12 @@ import (
 	"io"
 	"io/fs"
 	"math"
+	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 
+	"github.com/ethereum-optimism/optimism/da/dac"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-node/client"
 	"github.com/ethereum-optimism/optimism/op-node/sources"
@@ -22,18 +25,47 @@ import (
 	oppio "github.com/ethereum-optimism/optimism/op-program/io"
 	"github.com/ethereum-optimism/optimism/op-program/preimage"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/backoff"
 	opclient "github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 )
 
-const maxRPCRetries = math.MaxInt
+const (
+	maxRPCRetries = math.MaxInt
+	// maxDACAttempts bounds the retries of a DAC batch, unlike RPCs a batch may never be served
+	maxDACAttempts = 10
+)
 
 type L2Source struct {
 	*sources.L2Client
 	*sources.DebugClient
 }
 
+// DACSource fetches the batches of DAC chains from a DAC HTTP api
+type DACSource struct {
+	url        *url.URL
+	httpClient *http.Client
+}
+
+func NewDACSource(apiUrl string) (*DACSource, error) {
+	parsed, err := url.Parse(apiUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DAC url: %w", err)
+	}
+	return &DACSource{url: parsed, httpClient: &http.Client{}}, nil
+}
+
+func (s *DACSource) BatchByHash(ctx context.Context, dataHash common.Hash) ([]byte, error) {
+	var data []byte
+	err := backoff.DoCtx(ctx, maxDACAttempts, backoff.Exponential(), func() error {
+		var err error
+		data, err = dac.FetchBatch(ctx, s.httpClient, s.url, dataHash.Bytes())
+		return err
+	})
+	return data, err
+}
+
 func Main(logger log.Logger, cfg *config.Config) error {
 	if err := cfg.Check(); err != nil {
 		return fmt.Errorf("invalid config: %w", err)
@@ -219,7 +251,16 @@ func makePrefetcher(ctx context.Context, logger log.Logger, kv kvstore.KV, cfg *
 	}
 
 	l2DebugCl := &L2Source{L2Client: l2Cl, DebugClient: sources.NewDebugClient(l2RPC.CallContext)}
-	return prefetcher.NewPrefetcher(logger, l1Cl, l2DebugCl, kv), nil
+
+	var dacSource prefetcher.DACSource
+	if cfg.DACURL != "" {
+		logger.Info("Using DAC api", "dac", cfg.DACURL)
+		dacSource, err = NewDACSource(cfg.DACURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return prefetcher.NewPrefetcher(logger, l1Cl, l2DebugCl, dacSource, kv), nil
 }
 
 func routeHints(logger log.Logger, hHostRW io.ReadWriter, hinter preimage.HintHandler) chan error {
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-program/client/dac"
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
 	"github.com/ethereum-optimism/optimism/op-program/client/l2"
 	"github.com/ethereum-optimism/optimism/op-program/client/mpt"
@@ -31,20 +32,29 @@ type L2Source interface {
 	CodeByHash(ctx context.Context, hash common.Hash) ([]byte, error)
 }
 
+type DACSource interface {
+	// BatchByHash retrieves the DAC batch data with the given keccak256 hash.
+	// It must check the data against the hash.
+	BatchByHash(ctx context.Context, dataHash common.Hash) ([]byte, error)
+}
+
 type Prefetcher struct {
-	logger    log.Logger
-	l1Fetcher L1Source
-	l2Fetcher L2Source
-	lastHint  string
-	kvStore   kvstore.KV
+	logger     log.Logger
+	l1Fetcher  L1Source
+	l2Fetcher  L2Source
+	dacFetcher DACSource
+	lastHint   string
+	kvStore    kvstore.KV
 }
 
-func NewPrefetcher(logger log.Logger, l1Fetcher L1Source, l2Fetcher L2Source, kvStore kvstore.KV) *Prefetcher {
+// NewPrefetcher creates a Prefetcher. dacFetcher may be nil for chains not using a DAC.
+func NewPrefetcher(logger log.Logger, l1Fetcher L1Source, l2Fetcher L2Source, dacFetcher DACSource, kvStore kvstore.KV) *Prefetcher {
 	return &Prefetcher{
-		logger:    logger,
-		l1Fetcher: l1Fetcher,
-		l2Fetcher: l2Fetcher,
-		kvStore:   kvStore,
+		logger:     logger,
+		l1Fetcher:  l1Fetcher,
+		l2Fetcher:  l2Fetcher,
+		dacFetcher: dacFetcher,
+		kvStore:    kvStore,
 	}
 }
 
@@ -124,6 +134,15 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 			return fmt.Errorf("failed to fetch L2 contract code %s: %w", hash, err)
 		}
 		return p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), code)
+	case dac.HintDACBatch:
+		if p.dacFetcher == nil {
+			return fmt.Errorf("no DAC source to fetch batch %s from", hash)
+		}
+		batch, err := p.dacFetcher.BatchByHash(ctx, hash)
+		if err != nil {
+			return fmt.Errorf("failed to fetch DAC batch %s: %w", hash, err)
+		}
+		return p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), batch)
 	}
 	return fmt.Errorf("unknown hint type: %v", hintType)
 }
@@ -2,6 +2,7 @@ package prefetcher
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
 	"testing"
 
@@ -15,6 +16,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/testutils"
+	"github.com/ethereum-optimism/optimism/op-program/client/dac"
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
 	"github.com/ethereum-optimism/optimism/op-program/client/l2"
 	"github.com/ethereum-optimism/optimism/op-program/client/mpt"
@@ -281,6 +283,52 @@ func TestBadHints(t *testing.T) {
 	})
 }
 
+func TestFetchDACBatch(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	batch := testutils.RandomData(rng, 30)
+	hash := crypto.Keccak256Hash(batch)
+	key := preimage.Keccak256Key(hash).PreimageKey()
+
+	t.Run("AlreadyKnown", func(t *testing.T) {
+		prefetcher, _, _, kv := createPrefetcher(t)
+		require.NoError(t, kv.Put(key, batch))
+
+		oracle := dac.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		result := oracle.BatchByHash(hash)
+		require.EqualValues(t, batch, result)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		prefetcher, _, _, _ := createPrefetcher(t)
+		prefetcher.dacFetcher = dacSource{hash: batch}
+
+		oracle := dac.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		result := oracle.BatchByHash(hash)
+		require.EqualValues(t, batch, result)
+	})
+
+	t.Run("NoDACSource", func(t *testing.T) {
+		prefetcher, _, _, _ := createPrefetcher(t)
+		prefetcher.dacFetcher = nil
+
+		require.NoError(t, prefetcher.Hint(dac.BatchHint(hash).Hint()))
+		pre, err := prefetcher.GetPreimage(context.Background(), key)
+		require.ErrorContains(t, err, "no DAC source")
+		require.Nil(t, pre)
+	})
+}
+
+// dacSource serves the batches it maps by hash
+type dacSource map[common.Hash][]byte
+
+func (s dacSource) BatchByHash(_ context.Context, dataHash common.Hash) ([]byte, error) {
+	batch, ok := s[dataHash]
+	if !ok {
+		return nil, fmt.Errorf("unknown batch %s", dataHash)
+	}
+	return batch, nil
+}
+
 type l2Client struct {
 	*testutils.MockL2Client
 	*testutils.MockDebugClient
@@ -296,7 +344,7 @@ func createPrefetcher(t *testing.T) (*Prefetcher, *testutils.MockL1Source, *l2Cl
 		MockDebugClient: new(testutils.MockDebugClient),
 	}
 
-	prefetcher := NewPrefetcher(logger, l1Source, l2Source, kv)
+	prefetcher := NewPrefetcher(logger, l1Source, l2Source, dacSource{}, kv)
 	return prefetcher, l1Source, l2Source, kv
 }
 
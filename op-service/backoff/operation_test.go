@@ -30,5 +30,6 @@ func TestDo(t *testing.T) {
 		return dummyErr
 	})
 	require.Equal(t, dummyErr, err.(*ErrFailedPermanently).LastErr)
+	require.ErrorIs(t, err, dummyErr)
 	require.True(t, time.Since(start) > 20*time.Millisecond)
 }
@@ -0,0 +1,119 @@
+package httputil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+	"github.com/ethereum-optimism/optimism/op-service/tls/certman"
+)
+
+const (
+	TimeoutFlagName = "timeout"
+	// DefaultTimeout bounds the requests of the clients by default
+	DefaultTimeout = 10 * time.Second
+)
+
+// ClientCLIFlags returns the flags of an HTTP client of an external API, prefixed by flagPrefix,
+// with env vars prefixed by envPrefix. Unlike the server TLS flags, TLS is disabled by default.
+func ClientCLIFlags(envPrefix string, flagPrefix string) []cli.Flag {
+	prefixFunc := func(flagName string) string {
+		return strings.Trim(fmt.Sprintf("%s.%s", flagPrefix, flagName), ".")
+	}
+	return []cli.Flag{
+		cli.DurationFlag{
+			Name:   prefixFunc(TimeoutFlagName),
+			Usage:  "Timeout of a request, including reading the response. 0 for no timeout",
+			Value:  DefaultTimeout,
+			EnvVar: opservice.PrefixEnvVar(envPrefix, "TIMEOUT"),
+		},
+		cli.StringFlag{
+			Name:   prefixFunc(optls.TLSCaCertFlagName),
+			Usage:  "tls ca cert path, enables TLS with the other tls flags",
+			EnvVar: opservice.PrefixEnvVar(envPrefix, "TLS_CA"),
+		},
+		cli.StringFlag{
+			Name:   prefixFunc(optls.TLSCertFlagName),
+			Usage:  "tls client cert path",
+			EnvVar: opservice.PrefixEnvVar(envPrefix, "TLS_CERT"),
+		},
+		cli.StringFlag{
+			Name:   prefixFunc(optls.TLSKeyFlagName),
+			Usage:  "tls client key path",
+			EnvVar: opservice.PrefixEnvVar(envPrefix, "TLS_KEY"),
+		},
+	}
+}
+
+type ClientCLIConfig struct {
+	Timeout   time.Duration
+	TLSConfig optls.CLIConfig
+}
+
+func (c ClientCLIConfig) Check() error {
+	if c.Timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+	return c.TLSConfig.Check()
+}
+
+// ReadClientCLIConfig reads the configs of the flags returned by ClientCLIFlags
+func ReadClientCLIConfig(ctx *cli.Context, flagPrefix string) ClientCLIConfig {
+	return ClientCLIConfig{
+		Timeout:   ctx.GlobalDuration(strings.Trim(fmt.Sprintf("%s.%s", flagPrefix, TimeoutFlagName), ".")),
+		TLSConfig: optls.ReadCLIConfigWithPrefix(ctx, flagPrefix),
+	}
+}
+
+// NewClient creates an HTTP client bounding every request by the configured timeout.
+// With TLS, the server is verified against the ca cert and the client authenticates with its
+// cert, which is reloaded when it changes on disk.
+func NewClient(logger log.Logger, cfg ClientCLIConfig) (*http.Client, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, err
+	}
+	client := &http.Client{Timeout: cfg.Timeout}
+	if !cfg.TLSConfig.TLSEnabled() {
+		return client, nil
+	}
+
+	caCert, err := os.ReadFile(cfg.TLSConfig.TLSCaCert)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tls ca cert: %w", err)
+	}
+	caCertPool := x509.NewCertPool()
+	if !caCertPool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("no certificate in tls ca cert %v", cfg.TLSConfig.TLSCaCert)
+	}
+
+	// certman watches for newer client certificates and automatically reloads them
+	cm, err := certman.New(logger, cfg.TLSConfig.TLSCert, cfg.TLSConfig.TLSKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tls cert or key: %w", err)
+	}
+	if err := cm.Watch(); err != nil {
+		return nil, fmt.Errorf("failed to start certman watcher: %w", err)
+	}
+
+	client.Transport = &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		TLSClientConfig: &tls.Config{
+			MinVersion: tls.VersionTLS13,
+			RootCAs:    caCertPool,
+			GetClientCertificate: func(_ *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+				return cm.GetCertificate(nil)
+			},
+		},
+	}
+	return client, nil
+}
@@ -18,8 +18,9 @@ const encodeHex = (arr) => Buffer.from(arr).toString('hex');
 // FIXME: WRAP PROMISES !!!!
 
 das.post("/batch", async (req, res) => {
-  const { data } = req.fields;
-  const body = { data };
+  // the version selects the signature scheme the members sign with
+  const { data, signature, version } = req.fields;
+  const body = { data, signature, version };
 
   const results = await Promise.allSettled(
     dasConfig.members.map(
@@ -71,8 +72,8 @@ das.get("/batch/:dataHash", async (req, res) => {
     promises.push(promise);
   }
   const result = await Promise.any(promises);
-  const data = result.data;
-  res.status(200).json({ data });
+  // members already answer with { data }
+  res.status(200).json(result.data);
 });
 
 const port = process.env.PORT || '3000'